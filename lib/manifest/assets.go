@@ -0,0 +1,25 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+const defaultAssetsMountPath = "/assets"
+
+type AssetsInfo struct {
+	Dir       string `json:"dir"`
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// GetMountPath returns the path at which the assets are mounted in the filesystem of each function.
+func (a *AssetsInfo) GetMountPath() string {
+	if a.MountPath == "" {
+		return defaultAssetsMountPath
+	}
+	return a.MountPath
+}
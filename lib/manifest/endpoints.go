@@ -29,10 +29,11 @@ const (
 )
 
 type GraphqlEndpointInfo struct {
-	Name string           `json:"-"`
-	Type EndpointType     `json:"type"`
-	Path string           `json:"path"`
-	Auth EndpointAuthType `json:"auth"`
+	Name           string           `json:"-"`
+	Type           EndpointType     `json:"type"`
+	Path           string           `json:"path"`
+	Auth           EndpointAuthType `json:"auth"`
+	OperationNames []string         `json:"operationNames,omitempty"`
 }
 
 func (e GraphqlEndpointInfo) EndpointName() string {
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+type LimitsInfo struct {
+	ExecutionLimits
+	MaxCallDepth int                        `json:"maxCallDepth,omitempty"`
+	Functions    map[string]ExecutionLimits `json:"functions,omitempty"`
+}
+
+type ExecutionLimits struct {
+	MaxMemoryPages uint32 `json:"maxMemoryPages,omitempty"`
+	TimeoutMs      int    `json:"timeoutMs,omitempty"`
+	MaxQueryRows   int    `json:"maxQueryRows,omitempty"`
+	MaxQueryBytes  int    `json:"maxQueryBytes,omitempty"`
+}
+
+// ForFunction returns the limits that apply to the given function.
+// Limits that are not set for the function are inherited from the app-level limits.
+// A value of zero means the limit is not set, in which case the runtime's default applies, if it has one.
+func (l *LimitsInfo) ForFunction(fnName string) ExecutionLimits {
+	limits := l.ExecutionLimits
+	if fl, ok := l.Functions[fnName]; ok {
+		if fl.MaxMemoryPages > 0 {
+			limits.MaxMemoryPages = fl.MaxMemoryPages
+		}
+		if fl.TimeoutMs > 0 {
+			limits.TimeoutMs = fl.TimeoutMs
+		}
+		if fl.MaxQueryRows > 0 {
+			limits.MaxQueryRows = fl.MaxQueryRows
+		}
+		if fl.MaxQueryBytes > 0 {
+			limits.MaxQueryBytes = fl.MaxQueryBytes
+		}
+	}
+	return limits
+}
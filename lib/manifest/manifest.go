@@ -35,6 +35,10 @@ type Manifest struct {
 	Models      map[string]ModelInfo      `json:"models"`
 	Connections map[string]ConnectionInfo `json:"connections"`
 	Collections map[string]CollectionInfo `json:"collections"`
+	Limits      LimitsInfo                `json:"limits"`
+	Schedules   map[string]ScheduleInfo   `json:"schedules"`
+	Triggers    map[string]TriggerInfo    `json:"triggers"`
+	Assets      *AssetsInfo               `json:"assets,omitempty"`
 }
 
 func (m *Manifest) IsCurrentVersion() bool {
@@ -95,6 +99,10 @@ func parseManifestJson(data []byte, manifest *Manifest) error {
 		Models      map[string]ModelInfo       `json:"models"`
 		Connections map[string]json.RawMessage `json:"connections"`
 		Collections map[string]CollectionInfo  `json:"collections"`
+		Limits      LimitsInfo                 `json:"limits"`
+		Schedules   map[string]ScheduleInfo    `json:"schedules"`
+		Triggers    map[string]TriggerInfo     `json:"triggers"`
+		Assets      *AssetsInfo                `json:"assets"`
 	}
 	if err := json.Unmarshal(data, &m); err != nil {
 		return fmt.Errorf("failed to parse manifest: %w", err)
@@ -103,6 +111,10 @@ func parseManifestJson(data []byte, manifest *Manifest) error {
 	manifest.Version = currentVersion
 	manifest.Models = m.Models
 	manifest.Collections = m.Collections
+	manifest.Limits = m.Limits
+	manifest.Schedules = m.Schedules
+	manifest.Triggers = m.Triggers
+	manifest.Assets = m.Assets
 
 	// Copy map keys to Name fields
 	for key, model := range manifest.Models {
@@ -113,6 +125,14 @@ func parseManifestJson(data []byte, manifest *Manifest) error {
 		collection.Name = key
 		manifest.Collections[key] = collection
 	}
+	for key, schedule := range manifest.Schedules {
+		schedule.Name = key
+		manifest.Schedules[key] = schedule
+	}
+	for key, trigger := range manifest.Triggers {
+		trigger.Name = key
+		manifest.Triggers[key] = trigger
+	}
 
 	// Parse the endpoints by type
 	manifest.Endpoints = make(map[string]EndpointInfo, len(m.Endpoints))
@@ -160,6 +180,20 @@ func parseManifestJson(data []byte, manifest *Manifest) error {
 			}
 			info.Name = name
 			manifest.Connections[name] = info
+		case ConnectionTypeMysql:
+			var info MysqlConnectionInfo
+			if err := json.Unmarshal(rawCon, &info); err != nil {
+				return fmt.Errorf("failed to parse mysql connection [%s]: %w", name, err)
+			}
+			info.Name = name
+			manifest.Connections[name] = info
+		case ConnectionTypeSqlite:
+			var info SqliteConnectionInfo
+			if err := json.Unmarshal(rawCon, &info); err != nil {
+				return fmt.Errorf("failed to parse sqlite connection [%s]: %w", name, err)
+			}
+			info.Name = name
+			manifest.Connections[name] = info
 		case ConnectionTypeDgraph:
 			var info DgraphConnectionInfo
 			if err := json.Unmarshal(rawCon, &info); err != nil {
@@ -174,6 +208,13 @@ func parseManifestJson(data []byte, manifest *Manifest) error {
 			}
 			info.Name = name
 			manifest.Connections[name] = info
+		case ConnectionTypeS3:
+			var info S3ConnectionInfo
+			if err := json.Unmarshal(rawCon, &info); err != nil {
+				return fmt.Errorf("failed to parse s3 connection [%s]: %w", name, err)
+			}
+			info.Name = name
+			manifest.Connections[name] = info
 		default:
 			return fmt.Errorf("unknown type [%s] for connection [%s]", conType, name)
 		}
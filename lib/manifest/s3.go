@@ -0,0 +1,40 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+const ConnectionTypeS3 ConnectionType = "s3"
+
+type S3ConnectionInfo struct {
+	Name            string         `json:"-"`
+	Type            ConnectionType `json:"type"`
+	Bucket          string         `json:"bucket"`
+	Prefix          string         `json:"prefix"`
+	Region          string         `json:"region"`
+	Endpoint        string         `json:"endpoint"`
+	AccessKeyId     string         `json:"accessKeyId"`
+	SecretAccessKey string         `json:"secretAccessKey"`
+	LocalDir        string         `json:"localDir"`
+}
+
+func (info S3ConnectionInfo) ConnectionName() string {
+	return info.Name
+}
+
+func (info S3ConnectionInfo) ConnectionType() ConnectionType {
+	return info.Type
+}
+
+func (info S3ConnectionInfo) Hash() string {
+	return computeHash(info.Name, info.Type, info.Bucket, info.Prefix, info.Region, info.Endpoint, info.LocalDir)
+}
+
+func (info S3ConnectionInfo) Variables() []string {
+	return append(extractVariables(info.AccessKeyId), extractVariables(info.SecretAccessKey)...)
+}
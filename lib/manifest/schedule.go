@@ -0,0 +1,18 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+type ScheduleInfo struct {
+	Name          string `json:"-"`
+	Function      string `json:"function"`
+	Cron          string `json:"cron"`
+	Args          []any  `json:"args,omitempty"`
+	JitterSeconds int    `json:"jitterSeconds,omitempty"`
+}
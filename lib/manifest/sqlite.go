@@ -0,0 +1,38 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+const ConnectionTypeSqlite ConnectionType = "sqlite"
+
+// SqliteMemoryPath is the path of an in-memory SQLite database.
+const SqliteMemoryPath = ":memory:"
+
+type SqliteConnectionInfo struct {
+	Name     string         `json:"-"`
+	Type     ConnectionType `json:"type"`
+	Path     string         `json:"path"`
+	ReadOnly bool           `json:"readOnly,omitempty"`
+}
+
+func (info SqliteConnectionInfo) ConnectionName() string {
+	return info.Name
+}
+
+func (info SqliteConnectionInfo) ConnectionType() ConnectionType {
+	return info.Type
+}
+
+func (info SqliteConnectionInfo) Hash() string {
+	return computeHash(info.Name, info.Type, info.Path, info.ReadOnly)
+}
+
+func (info SqliteConnectionInfo) Variables() []string {
+	return nil
+}
@@ -0,0 +1,17 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+type TriggerInfo struct {
+	Name       string `json:"-"`
+	Function   string `json:"function"`
+	Connection string `json:"connection"`
+	Channel    string `json:"channel"`
+}
@@ -90,6 +90,11 @@ func (t *TypeDefinition) WithField(name string, typ string, docs ...*Docs) *Type
 	return t
 }
 
+func (t *TypeDefinition) WithVariant(typ string) *TypeDefinition {
+	t.Variants = append(t.Variants, typ)
+	return t
+}
+
 func (t *TypeDefinition) WithDocs(docs Docs) *TypeDefinition {
 	t.Docs = &docs
 	return t
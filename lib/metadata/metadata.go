@@ -46,10 +46,11 @@ type Function struct {
 }
 
 type TypeDefinition struct {
-	Name   string   `json:"-"`
-	Id     uint32   `json:"id,omitempty"`
-	Fields []*Field `json:"fields,omitempty"`
-	Docs   *Docs    `json:"docs,omitempty"`
+	Name     string   `json:"-"`
+	Id       uint32   `json:"id,omitempty"`
+	Fields   []*Field `json:"fields,omitempty"`
+	Variants []string `json:"variants,omitempty"`
+	Docs     *Docs    `json:"docs,omitempty"`
 }
 
 type Parameter struct {
@@ -125,9 +126,9 @@ func (m *Metadata) SdkVersion() string {
 func (m *Metadata) GetTypeDefinition(typ string) (*TypeDefinition, error) {
 	switch typ {
 	case "[]byte":
-		return &TypeDefinition{typ, 1, nil, nil}, nil
+		return &TypeDefinition{Name: typ, Id: 1}, nil
 	case "string":
-		return &TypeDefinition{typ, 2, nil, nil}, nil
+		return &TypeDefinition{Name: typ, Id: 2}, nil
 	}
 
 	def, ok := m.Types[typ]
@@ -138,6 +139,12 @@ func (m *Metadata) GetTypeDefinition(typ string) (*TypeDefinition, error) {
 	return def, nil
 }
 
+// IsAbstract returns true if the type is an interface or abstract class,
+// whose values are always one of the concrete types listed in Variants.
+func (t *TypeDefinition) IsAbstract() bool {
+	return len(t.Variants) > 0
+}
+
 func (m *Metadata) GetExportedFunctions() []*Function {
 	var fns []*Function
 	for _, fn := range m.FnExports {
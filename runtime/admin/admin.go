@@ -0,0 +1,123 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package admin
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/pluginmanager"
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+// AdminHandler serves the admin API, which is used to manage the runtime while it is running.
+var AdminHandler = requireAdminToken(newAdminMux())
+
+func newAdminMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/executions", listExecutionsHandler)
+	mux.HandleFunc("POST /admin/executions/{id}/cancel", cancelExecutionHandler)
+	mux.HandleFunc("GET /admin/plugins", listPluginsHandler)
+	mux.HandleFunc("POST /admin/plugins/{name}/promote", promotePluginHandler)
+	mux.HandleFunc("POST /admin/plugins/{name}/rollback", rollbackPluginHandler)
+	return mux
+}
+
+func listExecutionsHandler(w http.ResponseWriter, r *http.Request) {
+	executions := wasmhost.GetRunningExecutions()
+
+	utils.WriteJsonContentHeader(w)
+	j, _ := utils.JsonSerialize(executions)
+	_, _ = w.Write(j)
+}
+
+func cancelExecutionHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !wasmhost.CancelExecution(id) {
+		http.Error(w, "Execution not found", http.StatusNotFound)
+		return
+	}
+
+	logger.Warn(r.Context()).Str("execution_id", id).Msg("Execution canceled by administrator.")
+
+	result := struct {
+		ExecutionId string `json:"executionId"`
+		Canceled    bool   `json:"canceled"`
+	}{id, true}
+
+	utils.WriteJsonContentHeader(w)
+	j, _ := utils.JsonSerialize(result)
+	_, _ = w.Write(j)
+}
+
+func listPluginsHandler(w http.ResponseWriter, r *http.Request) {
+	builds := pluginmanager.GetPluginBuilds()
+
+	utils.WriteJsonContentHeader(w)
+	j, _ := utils.JsonSerialize(builds)
+	_, _ = w.Write(j)
+}
+
+func promotePluginHandler(w http.ResponseWriter, r *http.Request) {
+	changePluginBuild(w, r, pluginmanager.PromotePlugin)
+}
+
+func rollbackPluginHandler(w http.ResponseWriter, r *http.Request) {
+	changePluginBuild(w, r, pluginmanager.RollbackPlugin)
+}
+
+func changePluginBuild(w http.ResponseWriter, r *http.Request, change func(name string) error) {
+	name := r.PathValue("name")
+	if err := change(name); err != nil {
+		logger.Warn(r.Context()).Err(err).Str("plugin", name).Msg("Failed to change plugin build.")
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	for _, b := range pluginmanager.GetPluginBuilds() {
+		if b.Name == name {
+			utils.WriteJsonContentHeader(w)
+			j, _ := utils.JsonSerialize(b)
+			_, _ = w.Write(j)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireAdminToken requires requests to have the token set in the MODUS_ADMIN_TOKEN environment variable
+// as a bearer token.  If the variable is not set, the admin API is only available in the dev environment.
+func requireAdminToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminToken := os.Getenv("MODUS_ADMIN_TOKEN")
+		if adminToken == "" {
+			if config.IsDevEnvironment() {
+				next.ServeHTTP(w, r)
+			} else {
+				http.NotFound(w, r)
+			}
+			return
+		}
+
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			logger.Warn(r.Context()).Msg("Invalid or missing admin token.")
+			http.Error(w, "Access Denied", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminHandler(t *testing.T) {
+	t.Setenv("MODUS_ADMIN_TOKEN", "secret")
+
+	tests := []struct {
+		method string
+		path   string
+		token  string
+		status int
+		body   string
+	}{
+		{"GET", "/admin/executions", "", http.StatusUnauthorized, ""},
+		{"GET", "/admin/executions", "wrong", http.StatusUnauthorized, ""},
+		{"GET", "/admin/executions", "secret", http.StatusOK, "[]"},
+		{"POST", "/admin/executions/unknown/cancel", "secret", http.StatusNotFound, ""},
+		{"GET", "/admin/executions/unknown/cancel", "secret", http.StatusMethodNotAllowed, ""},
+		{"GET", "/admin/plugins", "secret", http.StatusOK, "[]"},
+		{"POST", "/admin/plugins/unknown/promote", "secret", http.StatusConflict, ""},
+		{"POST", "/admin/plugins/unknown/rollback", "secret", http.StatusConflict, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+
+		w := httptest.NewRecorder()
+		AdminHandler.ServeHTTP(w, req)
+
+		if w.Code != tt.status {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.status, w.Code)
+		}
+		if tt.body != "" && w.Body.String() != tt.body {
+			t.Errorf("%s %s: expected body %q, got %q", tt.method, tt.path, tt.body, w.Body.String())
+		}
+	}
+}
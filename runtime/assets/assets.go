@@ -0,0 +1,96 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package assets
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"sync/atomic"
+
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/storage"
+)
+
+type mount struct {
+	fsys fs.FS
+	path string
+}
+
+var current atomic.Pointer[mount]
+
+// FS is the filesystem that is mounted into each module instance.
+// It always reads from the assets that are currently loaded, so module instances that were
+// created before the assets changed will see the new files.
+var FS fs.FS = assetsFS{}
+
+type assetsFS struct{}
+
+func (assetsFS) Open(name string) (fs.File, error) {
+	m := current.Load()
+	if m == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return m.fsys.Open(name)
+}
+
+// Initialize loads the assets directory declared in the manifest, and reloads it whenever the manifest changes.
+func Initialize(ctx context.Context) {
+	manifestdata.RegisterManifestLoadedCallback(load)
+}
+
+// GetMountPath returns the path at which the assets are mounted, and false if there are no assets.
+func GetMountPath() (string, bool) {
+	m := current.Load()
+	if m == nil {
+		return "", false
+	}
+	return m.path, true
+}
+
+func load(ctx context.Context) error {
+	info := manifestdata.GetManifest().Assets
+	if info == nil {
+		current.Store(nil)
+		return nil
+	}
+
+	dir := path.Clean(info.Dir)
+	if !fs.ValidPath(dir) || dir == "." {
+		logger.Error(ctx).
+			Str("dir", info.Dir).
+			Bool("user_visible", true).
+			Msg("Invalid assets directory in manifest.  It must be a relative path within the app.")
+		current.Store(nil)
+		return nil
+	}
+
+	// Errors are logged rather than returned, so that the rest of the manifest is still applied.
+	fsys, err := storage.GetDirFS(ctx, dir)
+	if err != nil {
+		logger.Err(ctx, err).
+			Str("dir", dir).
+			Bool("user_visible", true).
+			Msg("Failed to load assets directory.")
+		current.Store(nil)
+		return nil
+	}
+
+	mountPath := info.GetMountPath()
+	current.Store(&mount{fsys, mountPath})
+
+	logger.Info(ctx).
+		Str("dir", dir).
+		Str("mount_path", mountPath).
+		Msg("Loaded assets directory.")
+
+	return nil
+}
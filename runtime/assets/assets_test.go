@@ -0,0 +1,78 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package assets
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAssets(t *testing.T) {
+	ctx := context.Background()
+
+	config.AppPath = t.TempDir()
+	storage.Initialize(ctx)
+
+	dir := filepath.Join(config.AppPath, "assets", "prompts")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "greeting.txt"), []byte("Hello, {{name}}!"), 0644))
+
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+	defer current.Store(nil)
+
+	manifestdata.SetManifest(&manifest.Manifest{Assets: &manifest.AssetsInfo{Dir: "assets"}})
+	require.NoError(t, load(ctx))
+
+	mountPath, ok := GetMountPath()
+	require.True(t, ok)
+	assert.Equal(t, "/assets", mountPath)
+
+	content, err := fs.ReadFile(FS, "prompts/greeting.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, {{name}}!", string(content))
+
+	// Removing the assets from the manifest unmounts them.
+	manifestdata.SetManifest(&manifest.Manifest{})
+	require.NoError(t, load(ctx))
+
+	_, ok = GetMountPath()
+	assert.False(t, ok)
+
+	_, err = fs.ReadFile(FS, "prompts/greeting.txt")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLoadAssets_InvalidDir(t *testing.T) {
+	ctx := context.Background()
+
+	config.AppPath = t.TempDir()
+	storage.Initialize(ctx)
+
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	for _, dir := range []string{"../secrets", "missing"} {
+		manifestdata.SetManifest(&manifest.Manifest{Assets: &manifest.AssetsInfo{Dir: dir}})
+		require.NoError(t, load(ctx))
+
+		_, ok := GetMountPath()
+		assert.False(t, ok, dir)
+	}
+}
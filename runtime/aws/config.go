@@ -28,6 +28,20 @@ func GetAwsConfig() aws.Config {
 	return awsConfig
 }
 
+// LoadAwsConfig returns a copy of the AWS configuration that was loaded when the runtime started, if AWS storage is used.
+// Otherwise, it loads the default AWS configuration from the environment.
+func LoadAwsConfig(ctx context.Context) (aws.Config, error) {
+	if hmConfig.UseAwsStorage {
+		return awsConfig.Copy(), nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("error loading AWS configuration: %w", err)
+	}
+	return cfg, nil
+}
+
 func Initialize(ctx context.Context) {
 	if !(hmConfig.UseAwsStorage) {
 		return
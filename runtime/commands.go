@@ -0,0 +1,53 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hypermodeinc/modus/runtime/invoke"
+	"github.com/hypermodeinc/modus/runtime/replay"
+)
+
+type command func(ctx context.Context) error
+
+// getCommand returns the command given by the remaining command line arguments, or nil if there is none.
+// A command runs once after the services have started, instead of the HTTP server.
+func getCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	switch args[0] {
+	case "invoke":
+		if len(args) < 2 || len(args) > 3 {
+			return nil, errors.New("usage: invoke <function> [arguments as json]")
+		}
+		fnName, argsJson := args[1], ""
+		if len(args) == 3 {
+			argsJson = args[2]
+		}
+		return func(ctx context.Context) error {
+			return invoke.Run(ctx, os.Stdout, fnName, argsJson)
+		}, nil
+	case "replay":
+		if len(args) != 2 {
+			return nil, errors.New("usage: replay <recording file>")
+		}
+		return func(ctx context.Context) error {
+			return replay.Run(ctx, args[1])
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown command: %s", args[0])
+}
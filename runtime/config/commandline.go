@@ -23,6 +23,12 @@ var S3Bucket string
 var S3Path string
 var RefreshInterval time.Duration
 var UseJsonLogging bool
+var MaxUploadSize int64
+var ModulePoolSize int
+var CompilationCacheDir string
+var JobWorkers int
+var RecordDir string
+var CanaryPercent int
 
 func parseCommandLineFlags() {
 	flag.StringVar(&AppPath, "appPath", "", "REQUIRED - The path to the Modus app to load and run.")
@@ -34,6 +40,12 @@ func parseCommandLineFlags() {
 
 	flag.DurationVar(&RefreshInterval, "refresh", time.Second*5, "The refresh interval to reload any changes.")
 	flag.BoolVar(&UseJsonLogging, "jsonlogs", false, "Use JSON format for logging.")
+	flag.Int64Var(&MaxUploadSize, "maxUploadSize", 32<<20, "The maximum size in bytes of a GraphQL request that includes uploaded files.")
+	flag.StringVar(&CompilationCacheDir, "compilationCacheDir", "", "A directory in which to cache compiled plugins across restarts.  If not set, plugins are compiled each time they are loaded.")
+	flag.IntVar(&JobWorkers, "jobWorkers", 2, "The number of background jobs to run concurrently.  Set to 0 to disable running jobs on this instance.")
+	flag.StringVar(&RecordDir, "recordDir", "", "A directory in which to record each function execution, including its calls to host functions, so that it can be replayed with the replay command.  If not set, executions are not recorded.")
+	flag.IntVar(&CanaryPercent, "canaryPercent", 0, "The percentage of function calls to route to a new build of a plugin when it is loaded, until it is promoted or rolled back via the admin API.  Set to 0 to route all calls to a new build as soon as it is loaded.")
+	flag.IntVar(&ModulePoolSize, "modulePoolSize", 0, "The number of pre-instantiated module instances to keep ready for each plugin.  Set to 0 to disable pooling.")
 
 	var showVersion bool
 	const versionUsage = "Show the Runtime version number and exit."
@@ -18,26 +18,38 @@ import (
 
 func TestParseCommandLineFlags(t *testing.T) {
 	tests := []struct {
-		name                    string
-		args                    []string
-		expectedPort            int
-		expectedAppPath         string
-		expectedUseAwsStorage   bool
-		expectedS3Bucket        string
-		expectedS3Path          string
-		expectedRefreshInterval time.Duration
-		expectedUseJsonLogging  bool
+		name                        string
+		args                        []string
+		expectedPort                int
+		expectedAppPath             string
+		expectedUseAwsStorage       bool
+		expectedS3Bucket            string
+		expectedS3Path              string
+		expectedRefreshInterval     time.Duration
+		expectedUseJsonLogging      bool
+		expectedMaxUploadSize       int64
+		expectedModulePoolSize      int
+		expectedCompilationCacheDir string
+		expectedJobWorkers          int
+		expectedRecordDir           string
+		expectedCanaryPercent       int
 	}{
 		{
-			name:                    "default values",
-			args:                    []string{},
-			expectedPort:            8686,
-			expectedAppPath:         "",
-			expectedUseAwsStorage:   false,
-			expectedS3Bucket:        "",
-			expectedS3Path:          "",
-			expectedRefreshInterval: time.Second * 5,
-			expectedUseJsonLogging:  false,
+			name:                        "default values",
+			args:                        []string{},
+			expectedPort:                8686,
+			expectedAppPath:             "",
+			expectedUseAwsStorage:       false,
+			expectedS3Bucket:            "",
+			expectedS3Path:              "",
+			expectedRefreshInterval:     time.Second * 5,
+			expectedUseJsonLogging:      false,
+			expectedMaxUploadSize:       32 << 20,
+			expectedModulePoolSize:      0,
+			expectedCompilationCacheDir: "",
+			expectedJobWorkers:          2,
+			expectedRecordDir:           "",
+			expectedCanaryPercent:       0,
 		},
 		{
 			name: "custom values",
@@ -49,14 +61,26 @@ func TestParseCommandLineFlags(t *testing.T) {
 				"-s3path=my-path",
 				"-refresh=10s",
 				"-jsonlogs=true",
+				"-maxUploadSize=1048576",
+				"-modulePoolSize=4",
+				"-compilationCacheDir=/tmp/modus-cache",
+				"-jobWorkers=8",
+				"-recordDir=/tmp/modus-recordings",
+				"-canaryPercent=10",
 			},
-			expectedPort:            9090,
-			expectedAppPath:         "/path/to/app",
-			expectedUseAwsStorage:   true,
-			expectedS3Bucket:        "my-bucket",
-			expectedS3Path:          "my-path",
-			expectedRefreshInterval: 10 * time.Second,
-			expectedUseJsonLogging:  true,
+			expectedPort:                9090,
+			expectedAppPath:             "/path/to/app",
+			expectedUseAwsStorage:       true,
+			expectedS3Bucket:            "my-bucket",
+			expectedS3Path:              "my-path",
+			expectedRefreshInterval:     10 * time.Second,
+			expectedUseJsonLogging:      true,
+			expectedMaxUploadSize:       1 << 20,
+			expectedModulePoolSize:      4,
+			expectedCompilationCacheDir: "/tmp/modus-cache",
+			expectedJobWorkers:          8,
+			expectedRecordDir:           "/tmp/modus-recordings",
+			expectedCanaryPercent:       10,
 		},
 	}
 
@@ -71,6 +95,12 @@ func TestParseCommandLineFlags(t *testing.T) {
 			S3Path = ""
 			RefreshInterval = 0
 			UseJsonLogging = false
+			MaxUploadSize = 0
+			ModulePoolSize = 0
+			CompilationCacheDir = ""
+			JobWorkers = 2
+			RecordDir = ""
+			CanaryPercent = 0
 
 			// Set command line arguments
 			os.Args = append([]string{os.Args[0]}, tt.args...)
@@ -100,6 +130,24 @@ func TestParseCommandLineFlags(t *testing.T) {
 			if UseJsonLogging != tt.expectedUseJsonLogging {
 				t.Errorf("expected UseJsonLogging %v, got %v", tt.expectedUseJsonLogging, UseJsonLogging)
 			}
+			if MaxUploadSize != tt.expectedMaxUploadSize {
+				t.Errorf("expected MaxUploadSize %d, got %d", tt.expectedMaxUploadSize, MaxUploadSize)
+			}
+			if ModulePoolSize != tt.expectedModulePoolSize {
+				t.Errorf("expected ModulePoolSize %d, got %d", tt.expectedModulePoolSize, ModulePoolSize)
+			}
+			if CompilationCacheDir != tt.expectedCompilationCacheDir {
+				t.Errorf("expected CompilationCacheDir %s, got %s", tt.expectedCompilationCacheDir, CompilationCacheDir)
+			}
+			if JobWorkers != tt.expectedJobWorkers {
+				t.Errorf("expected JobWorkers %d, got %d", tt.expectedJobWorkers, JobWorkers)
+			}
+			if RecordDir != tt.expectedRecordDir {
+				t.Errorf("expected RecordDir %s, got %s", tt.expectedRecordDir, RecordDir)
+			}
+			if CanaryPercent != tt.expectedCanaryPercent {
+				t.Errorf("expected CanaryPercent %d, got %d", tt.expectedCanaryPercent, CanaryPercent)
+			}
 		})
 	}
 }
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/secrets"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"sha224":   sha256.New224,
+	"sha256":   sha256.New,
+	"sha384":   sha512.New384,
+	"sha512":   sha512.New,
+	"sha3-224": sha3.New224,
+	"sha3-256": sha3.New256,
+	"sha3-384": sha3.New384,
+	"sha3-512": sha3.New512,
+}
+
+var keyNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// getKey returns the key material of a named key.  Keys are secrets, which are read from the
+// MODUS_KEY_<NAME> environment variable, so that they never need to be passed to the function.
+func getKey(keyName string) (string, error) {
+	if !keyNameRegex.MatchString(keyName) {
+		return "", fmt.Errorf("invalid key name: %q", keyName)
+	}
+
+	name := "MODUS_KEY_" + strings.ToUpper(strings.ReplaceAll(keyName, "-", "_"))
+	key, err := secrets.GetSecretValue(name)
+	if err != nil {
+		return "", fmt.Errorf("key %s was not found: %w", keyName, err)
+	}
+	return key, nil
+}
+
+func getHashAlgorithm(algorithm string) (func() hash.Hash, error) {
+	h, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+	}
+	return h, nil
+}
+
+// Hash returns the hex-encoded digest of the data, using the given SHA-2 or SHA-3 algorithm.
+func Hash(algorithm, data string) (string, error) {
+	newHash, err := getHashAlgorithm(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	h := newHash()
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// HMAC returns the hex-encoded HMAC of the data, using the named key and the given hash algorithm.
+func HMAC(algorithm, keyName, data string) (string, error) {
+	mac, err := computeHMAC(algorithm, keyName, data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac), nil
+}
+
+// VerifyHMAC reports whether the hex-encoded HMAC matches the data, using the named key and the given hash algorithm.
+func VerifyHMAC(algorithm, keyName, data, mac string) (bool, error) {
+	expected, err := computeHMAC(algorithm, keyName, data)
+	if err != nil {
+		return false, err
+	}
+
+	actual, err := hex.DecodeString(mac)
+	if err != nil {
+		return false, nil
+	}
+	return hmac.Equal(expected, actual), nil
+}
+
+func computeHMAC(algorithm, keyName, data string) ([]byte, error) {
+	newHash, err := getHashAlgorithm(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := getKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hmac.New(newHash, []byte(key))
+	h.Write([]byte(data))
+	return h.Sum(nil), nil
+}
+
+// EncryptAESGCM encrypts the plaintext with AES-GCM, using the named key, which must be a base64-encoded
+// 128, 192 or 256-bit key.  It returns the base64-encoded nonce, followed by the ciphertext.
+func EncryptAESGCM(keyName, plaintext, associatedData string) (string, error) {
+	aead, err := getAESGCM(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(associatedData))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptAESGCM decrypts a ciphertext that was returned by EncryptAESGCM, using the same key and associated data.
+func DecryptAESGCM(keyName, ciphertext, associatedData string) (string, error) {
+	aead, err := getAESGCM(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid ciphertext: too short")
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(associatedData))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func getAESGCM(keyName string) (cipher.AEAD, error) {
+	encodedKey, err := getKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not base64-encoded: %w", keyName, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a valid AES key: %w", keyName, err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/secrets"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	secrets.Initialize(context.Background())
+}
+
+func setPrivateKey(t *testing.T, envName string, key any) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	t.Setenv(envName, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+}
+
+func setPublicKey(t *testing.T, envName string, key any) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	t.Setenv(envName, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+}
+
+func TestHash(t *testing.T) {
+	tests := map[string]string{
+		"sha256":   "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"SHA256":   "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"sha3-256": "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+	}
+
+	for algorithm, expected := range tests {
+		digest, err := Hash(algorithm, "abc")
+		require.NoError(t, err)
+		assert.Equal(t, expected, digest, algorithm)
+	}
+
+	_, err := Hash("md5", "abc")
+	assert.Error(t, err)
+}
+
+func TestHMAC(t *testing.T) {
+	t.Setenv("MODUS_KEY_WEBHOOK_SECRET", "key")
+
+	// Test vector from https://en.wikipedia.org/wiki/HMAC
+	mac, err := HMAC("sha256", "webhook-secret", "The quick brown fox jumps over the lazy dog")
+	require.NoError(t, err)
+	assert.Equal(t, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", mac)
+
+	ok, err := VerifyHMAC("sha256", "webhook-secret", "The quick brown fox jumps over the lazy dog", mac)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyHMAC("sha256", "webhook-secret", "tampered", mac)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = HMAC("sha256", "missing", "data")
+	assert.Error(t, err)
+
+	// Key names can't be used to read other environment variables.
+	_, err = HMAC("sha256", "../PATH", "data")
+	assert.Error(t, err)
+}
+
+func TestAESGCM(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	t.Setenv("MODUS_KEY_DATA", base64.StdEncoding.EncodeToString(key))
+
+	ciphertext, err := EncryptAESGCM("data", "secret message", "context")
+	require.NoError(t, err)
+
+	plaintext, err := DecryptAESGCM("data", ciphertext, "context")
+	require.NoError(t, err)
+	assert.Equal(t, "secret message", plaintext)
+
+	_, err = DecryptAESGCM("data", ciphertext, "other context")
+	assert.Error(t, err)
+
+	t.Setenv("MODUS_KEY_BAD", base64.StdEncoding.EncodeToString([]byte("too short")))
+	_, err = EncryptAESGCM("bad", "secret message", "")
+	assert.Error(t, err)
+}
+
+func TestSignAndVerify_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	setPrivateKey(t, "MODUS_KEY_SIGNING", priv)
+	setPublicKey(t, "MODUS_KEY_PARTNER", pub)
+
+	sig, err := Sign("ed25519", "signing", "payload")
+	require.NoError(t, err)
+
+	for _, keyName := range []string{"signing", "partner"} {
+		ok, err := Verify("ed25519", keyName, "payload", sig)
+		require.NoError(t, err)
+		assert.True(t, ok, keyName)
+
+		ok, err = Verify("ed25519", keyName, "tampered", sig)
+		require.NoError(t, err)
+		assert.False(t, ok, keyName)
+	}
+
+	_, err = Sign("ecdsa", "signing", "payload")
+	assert.Error(t, err)
+
+	_, err = Sign("ed25519", "partner", "payload")
+	assert.Error(t, err)
+}
+
+func TestSignAndVerify_ECDSA(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+		setPrivateKey(t, "MODUS_KEY_SIGNING", priv)
+		setPublicKey(t, "MODUS_KEY_PARTNER", &priv.PublicKey)
+
+		sig, err := Sign("ecdsa", "signing", "payload")
+		require.NoError(t, err)
+
+		ok, err := Verify("ecdsa", "partner", "payload", sig)
+		require.NoError(t, err)
+		assert.True(t, ok, curve.Params().Name)
+
+		ok, err = Verify("ecdsa", "partner", "tampered", sig)
+		require.NoError(t, err)
+		assert.False(t, ok, curve.Params().Name)
+	}
+}
+
+func TestJWT_HMAC(t *testing.T) {
+	t.Setenv("MODUS_KEY_TOKENS", "shared-secret")
+
+	token, err := CreateJWT("HS256", "tokens", map[string]any{
+		"sub": "user1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	result, err := VerifyJWT("tokens", token)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "user1", result.Claims["sub"])
+
+	expired, err := CreateJWT("HS256", "tokens", map[string]any{
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	result, err = VerifyJWT("tokens", expired)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.NotEmpty(t, result.Error)
+
+	_, err = CreateJWT("none", "tokens", map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestJWT_ECDSA(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	setPrivateKey(t, "MODUS_KEY_SIGNING", priv)
+	setPublicKey(t, "MODUS_KEY_PARTNER", &priv.PublicKey)
+
+	token, err := CreateJWT("ES256", "signing", map[string]any{"sub": "user1"})
+	require.NoError(t, err)
+
+	result, err := VerifyJWT("partner", token)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "user1", result.Claims["sub"])
+
+	// A token signed with HMAC, using the public key as the secret, must not be accepted.
+	t.Setenv("MODUS_KEY_FORGED", getTestKey(t, "MODUS_KEY_PARTNER"))
+	forged, err := CreateJWT("HS256", "forged", map[string]any{"sub": "attacker"})
+	require.NoError(t, err)
+
+	result, err = VerifyJWT("partner", forged)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func getTestKey(t *testing.T, envName string) string {
+	key, err := secrets.GetSecretValue(envName)
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTVerification is the outcome of verifying a JWT.  An invalid token is not an error of the verification,
+// so it is reported in the outcome rather than returned as an error.
+type JWTVerification struct {
+	Valid  bool           `json:"valid"`
+	Claims map[string]any `json:"claims,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// CreateJWT returns a JWT with the given claims, signed with the named key using the given algorithm.
+// HMAC algorithms (HS256, HS384, HS512) use the key as a shared secret.  Other algorithms (RS*, PS*, ES*, EdDSA)
+// require the key to be a PEM-encoded private key of the matching type.
+func CreateJWT(algorithm, keyName string, claims map[string]any) (string, error) {
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil || method == jwt.SigningMethodNone {
+		return "", fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+
+	var key any
+	var err error
+	if isHMACMethod(method) {
+		var secret string
+		secret, err = getKey(keyName)
+		key = []byte(secret)
+	} else {
+		key, err = getPrivateKey(keyName)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, jwt.MapClaims(claims))
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT with key %s: %w", keyName, err)
+	}
+	return signed, nil
+}
+
+// VerifyJWT verifies the signature and the time-based claims of a JWT, using the named key.
+// Only the algorithms that match the type of the key are accepted.
+func VerifyJWT(keyName, token string) (*JWTVerification, error) {
+	key, validMethods, err := getJWTVerificationKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(validMethods))
+	if _, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) { return key, nil }); err != nil {
+		return &JWTVerification{Valid: false, Error: err.Error()}, nil
+	}
+
+	return &JWTVerification{Valid: true, Claims: claims}, nil
+}
+
+func getJWTVerificationKey(keyName string) (any, []string, error) {
+	secret, err := getKey(keyName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A key that isn't PEM-encoded is an HMAC shared secret.
+	if !strings.HasPrefix(strings.TrimSpace(secret), "-----BEGIN") {
+		return []byte(secret), []string{"HS256", "HS384", "HS512"}, nil
+	}
+
+	key, err := getPublicKey(keyName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	case *ecdsa.PublicKey:
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	case ed25519.PublicKey:
+		return key, []string{"EdDSA"}, nil
+	default:
+		return nil, nil, fmt.Errorf("key %s has an unsupported type", keyName)
+	}
+}
+
+func isHMACMethod(method jwt.SigningMethod) bool {
+	_, ok := method.(*jwt.SigningMethodHMAC)
+	return ok
+}
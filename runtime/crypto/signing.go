@@ -0,0 +1,186 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sign returns the base64-encoded signature of the data, using the named private key.
+// The algorithm is either "ed25519" or "ecdsa".  ECDSA signatures are ASN.1 encoded, and use the hash
+// that matches the curve of the key: SHA-256 for P-256, SHA-384 for P-384, and SHA-512 for P-521.
+func Sign(algorithm, keyName, data string) (string, error) {
+	key, err := getPrivateKey(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	var sig []byte
+	switch strings.ToLower(algorithm) {
+	case "ed25519":
+		k, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("key %s is not an Ed25519 private key", keyName)
+		}
+		sig = ed25519.Sign(k, []byte(data))
+	case "ecdsa":
+		k, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("key %s is not an ECDSA private key", keyName)
+		}
+		digest, err := ecdsaDigest(k.Curve, data)
+		if err != nil {
+			return "", err
+		}
+		sig, err = ecdsa.SignASN1(rand.Reader, k, digest)
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported signature algorithm: %s", algorithm)
+	}
+
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Verify reports whether the base64-encoded signature of the data is valid, using the named key.
+// The key can be a public key, or a private key from which the public key is derived.
+func Verify(algorithm, keyName, data, signature string) (bool, error) {
+	key, err := getPublicKey(keyName)
+	if err != nil {
+		return false, err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false, nil
+	}
+
+	switch strings.ToLower(algorithm) {
+	case "ed25519":
+		k, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return false, fmt.Errorf("key %s is not an Ed25519 key", keyName)
+		}
+		return ed25519.Verify(k, []byte(data), sig), nil
+	case "ecdsa":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return false, fmt.Errorf("key %s is not an ECDSA key", keyName)
+		}
+		digest, err := ecdsaDigest(k.Curve, data)
+		if err != nil {
+			return false, err
+		}
+		return ecdsa.VerifyASN1(k, digest, sig), nil
+	default:
+		return false, fmt.Errorf("unsupported signature algorithm: %s", algorithm)
+	}
+}
+
+func ecdsaDigest(curve elliptic.Curve, data string) ([]byte, error) {
+	switch curve {
+	case elliptic.P256():
+		h := sha256.Sum256([]byte(data))
+		return h[:], nil
+	case elliptic.P384():
+		h := sha512.Sum384([]byte(data))
+		return h[:], nil
+	case elliptic.P521():
+		h := sha512.Sum512([]byte(data))
+		return h[:], nil
+	default:
+		return nil, fmt.Errorf("unsupported ECDSA curve: %s", curve.Params().Name)
+	}
+}
+
+// getPrivateKey returns the named key, which must be a PEM-encoded private key.
+func getPrivateKey(keyName string) (any, error) {
+	block, err := getPEMKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a valid private key: %w", keyName, err)
+	}
+	return key, nil
+}
+
+// getPublicKey returns the public key of the named key, which must be a PEM-encoded public or private key.
+func getPublicKey(keyName string) (any, error) {
+	block, err := getPEMKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "PUBLIC KEY" {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %s is not a valid public key: %w", keyName, err)
+		}
+		return key, nil
+	}
+
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a valid key: %w", keyName, err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k.Public(), nil
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey, nil
+	case *rsa.PrivateKey:
+		return &k.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("key %s has an unsupported type", keyName)
+	}
+}
+
+func getPEMKey(keyName string) (*pem.Block, error) {
+	key, err := getKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("key %s is not PEM-encoded", keyName)
+	}
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (any, error) {
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, errors.New("unsupported PEM block type: " + block.Type)
+	}
+}
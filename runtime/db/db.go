@@ -45,6 +45,7 @@ const pluginsTable = "plugins"
 const inferencesTable = "inferences"
 const collectionTextsTable = "collection_texts"
 const collectionVectorsTable = "collection_vectors"
+const scheduleRunsTable = "schedule_runs"
 
 const inferenceRefresherInterval = 5 * time.Second
 
@@ -254,6 +255,18 @@ func logDbWarningOrError(ctx context.Context, err error, msg string) {
 	}
 }
 
+// IsConfigured reports whether the runtime database has been configured.
+// It does not check that the database is reachable.
+func IsConfigured(ctx context.Context) bool {
+	_, err := globalRuntimePostgresWriter.GetPool(ctx)
+	return err == nil
+}
+
+// IsNotConfigured reports whether the error is because the runtime database has not been configured.
+func IsNotConfigured(err error) bool {
+	return errors.Is(err, errDbNotConfigured)
+}
+
 func getPluginId(ctx context.Context, tx pgx.Tx, buildId string) (string, error) {
 	query := fmt.Sprintf("SELECT id FROM %s WHERE build_id = $1", pluginsTable)
 	rows, err := tx.Query(ctx, query, buildId)
@@ -0,0 +1,220 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const jobsTable = "jobs"
+
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead"
+)
+
+// ErrJobLeaseLost is returned when a job can't be updated by the worker that claimed it,
+// because its lease expired and the job was claimed again, or because it is no longer running.
+var ErrJobLeaseLost = errors.New("the lease on the job was lost")
+
+const jobColumns = `id, function, args, priority, status, attempts, max_attempts, run_at,
+result, error, execution_id, created_at, updated_at, finished_at`
+
+type Job struct {
+	Id          string
+	Function    string
+	Args        []byte
+	Priority    int
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	Result      []byte
+	Error       *string
+	ExecutionId *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+func scanJob(row pgx.Row) (*Job, error) {
+	var job Job
+	err := row.Scan(
+		&job.Id,
+		&job.Function,
+		&job.Args,
+		&job.Priority,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.RunAt,
+		&job.Result,
+		&job.Error,
+		&job.ExecutionId,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.FinishedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// InsertJob adds a new pending job to the queue.
+func InsertJob(ctx context.Context, job *Job) error {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s
+(id, function, args, priority, status, max_attempts, run_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING created_at, updated_at`,
+		jobsTable)
+
+	row := pool.QueryRow(ctx, query, job.Id, job.Function, job.Args, job.Priority, job.Status, job.MaxAttempts, job.RunAt)
+	return row.Scan(&job.CreatedAt, &job.UpdatedAt)
+}
+
+// ClaimJob takes the next job that is ready to run, locking it for the duration of the lease.
+// Jobs that are still running when their lease expires are assumed to have been abandoned, and can be claimed again.
+// It returns nil if there are no jobs ready to run.
+func ClaimJob(ctx context.Context, lease time.Duration) (*Job, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`UPDATE %[1]s
+SET status = $1, attempts = attempts + 1, locked_until = NOW() + make_interval(secs => $3), updated_at = NOW()
+WHERE id = (
+	SELECT id FROM %[1]s
+	WHERE (status = $2 AND run_at <= NOW()) OR (status = $1 AND locked_until < NOW())
+	ORDER BY priority DESC, run_at
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING %[2]s`,
+		jobsTable, jobColumns)
+
+	return scanJob(pool.QueryRow(ctx, query, JobStatusRunning, JobStatusPending, lease.Seconds()))
+}
+
+// RenewJobLease extends the lease of a running job that was claimed on the given attempt.
+// It returns ErrJobLeaseLost if the job is no longer held by that attempt.
+func RenewJobLease(ctx context.Context, id string, attempt int, lease time.Duration) error {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s
+SET locked_until = NOW() + make_interval(secs => $4), updated_at = NOW()
+WHERE id = $1 AND status = $2 AND attempts = $3`,
+		jobsTable)
+
+	tag, err := pool.Exec(ctx, query, id, JobStatusRunning, attempt, lease.Seconds())
+	return checkJobLease(tag, err)
+}
+
+// CompleteJob records the successful result of a job that was claimed on the given attempt.
+func CompleteJob(ctx context.Context, id string, attempt int, executionId *string, result []byte) error {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s
+SET status = $2, result = $3, execution_id = $4, error = NULL, locked_until = NULL, updated_at = NOW(), finished_at = NOW()
+WHERE id = $1 AND status = $5 AND attempts = $6`,
+		jobsTable)
+
+	tag, err := pool.Exec(ctx, query, id, JobStatusSucceeded, result, executionId, JobStatusRunning, attempt)
+	return checkJobLease(tag, err)
+}
+
+// FailJob records a failed attempt of a job that was claimed on the given attempt.  If retryAt is nil, the job will not be retried,
+// and is moved to the dead state.  Otherwise, it is returned to the queue to run again at that time.
+func FailJob(ctx context.Context, id string, attempt int, executionId *string, errMsg string, retryAt *time.Time) error {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	var query string
+	var args []any
+	if retryAt == nil {
+		query = fmt.Sprintf(`UPDATE %s
+SET status = $2, error = $3, execution_id = $4, locked_until = NULL, updated_at = NOW(), finished_at = NOW()
+WHERE id = $1 AND status = $5 AND attempts = $6`,
+			jobsTable)
+		args = []any{id, JobStatusDead, errMsg, executionId, JobStatusRunning, attempt}
+	} else {
+		query = fmt.Sprintf(`UPDATE %s
+SET status = $2, error = $3, execution_id = $4, run_at = $5, locked_until = NULL, updated_at = NOW()
+WHERE id = $1 AND status = $6 AND attempts = $7`,
+			jobsTable)
+		args = []any{id, JobStatusPending, errMsg, executionId, *retryAt, JobStatusRunning, attempt}
+	}
+
+	tag, err := pool.Exec(ctx, query, args...)
+	return checkJobLease(tag, err)
+}
+
+// ReleaseJob returns a running job to the queue without counting the attempt,
+// such as when the runtime is shutting down before the job could complete.
+// If runAt is nil, the job keeps its place in the queue.  Otherwise, it is run again at that time.
+func ReleaseJob(ctx context.Context, id string, attempt int, runAt *time.Time) error {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s
+SET status = $2, attempts = attempts - 1, run_at = COALESCE($5, run_at), locked_until = NULL, updated_at = NOW()
+WHERE id = $1 AND status = $3 AND attempts = $4`,
+		jobsTable)
+
+	tag, err := pool.Exec(ctx, query, id, JobStatusPending, JobStatusRunning, attempt, runAt)
+	return checkJobLease(tag, err)
+}
+
+func checkJobLease(tag pgconn.CommandTag, err error) error {
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobLeaseLost
+	}
+	return nil
+}
+
+// GetJob returns the job with the given ID, or nil if there is no such job.
+func GetJob(ctx context.Context, id string) (*Job, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", jobColumns, jobsTable)
+	return scanJob(pool.QueryRow(ctx, query, id))
+}
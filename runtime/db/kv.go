@@ -0,0 +1,171 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const kvTable = "kv"
+
+// Entries that have expired are treated as if they don't exist, even before they are deleted.
+const kvNotExpired = "(expires_at IS NULL OR expires_at > NOW())"
+
+type KVEntry struct {
+	Key       string
+	Value     string
+	Version   int64
+	ExpiresAt *time.Time
+}
+
+func scanKVEntry(row pgx.Row) (*KVEntry, error) {
+	var entry KVEntry
+	err := row.Scan(&entry.Key, &entry.Value, &entry.Version, &entry.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetKV returns the entry for the given key, or nil if there is no such entry.
+func GetKV(ctx context.Context, app, namespace, key string) (*KVEntry, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT key, value, version, expires_at FROM %s
+WHERE app = $1 AND namespace = $2 AND key = $3 AND %s`,
+		kvTable, kvNotExpired)
+
+	return scanKVEntry(pool.QueryRow(ctx, query, app, namespace, key))
+}
+
+// SetKV sets the value for the given key, replacing any existing value, and returns the updated entry.
+func SetKV(ctx context.Context, app, namespace, key, value string, expiresAt *time.Time) (*KVEntry, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %[1]s AS t (app, namespace, key, value, version, expires_at)
+VALUES ($1, $2, $3, $4, 1, $5)
+ON CONFLICT (app, namespace, key) DO UPDATE
+SET value = EXCLUDED.value, version = t.version + 1, expires_at = EXCLUDED.expires_at, updated_at = NOW()
+RETURNING key, value, version, expires_at`,
+		kvTable)
+
+	return scanKVEntry(pool.QueryRow(ctx, query, app, namespace, key, value, expiresAt))
+}
+
+// CompareAndSwapKV sets the value for the given key, only if the current version of the entry matches the
+// expected version.  An expected version of zero means that there must not be an existing entry.
+// It returns the updated entry, or nil if the version did not match.
+func CompareAndSwapKV(ctx context.Context, app, namespace, key string, version int64, value string, expiresAt *time.Time) (*KVEntry, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 {
+		// Insert, or replace an entry that has expired but not yet been deleted.
+		query := fmt.Sprintf(`INSERT INTO %[1]s AS t (app, namespace, key, value, version, expires_at)
+VALUES ($1, $2, $3, $4, 1, $5)
+ON CONFLICT (app, namespace, key) DO UPDATE
+SET value = EXCLUDED.value, version = t.version + 1, expires_at = EXCLUDED.expires_at, updated_at = NOW()
+WHERE t.expires_at IS NOT NULL AND t.expires_at <= NOW()
+RETURNING key, value, version, expires_at`,
+			kvTable)
+
+		return scanKVEntry(pool.QueryRow(ctx, query, app, namespace, key, value, expiresAt))
+	}
+
+	query := fmt.Sprintf(`UPDATE %s
+SET value = $4, version = version + 1, expires_at = $5, updated_at = NOW()
+WHERE app = $1 AND namespace = $2 AND key = $3 AND version = $6 AND %s
+RETURNING key, value, version, expires_at`,
+		kvTable, kvNotExpired)
+
+	return scanKVEntry(pool.QueryRow(ctx, query, app, namespace, key, value, expiresAt, version))
+}
+
+// DeleteKV deletes the entry for the given key, returning true if the entry existed.
+func DeleteKV(ctx context.Context, app, namespace, key string) (bool, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s
+WHERE app = $1 AND namespace = $2 AND key = $3
+RETURNING %s`,
+		kvTable, kvNotExpired)
+
+	var existed bool
+	err = pool.QueryRow(ctx, query, app, namespace, key).Scan(&existed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return existed, err
+}
+
+// ScanKV returns the entries whose keys start with the given prefix, in key order, up to the given limit.
+func ScanKV(ctx context.Context, app, namespace, prefix string, limit int) ([]*KVEntry, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT key, value, version, expires_at FROM %s
+WHERE app = $1 AND namespace = $2 AND starts_with(key, $3) AND %s
+ORDER BY key
+LIMIT $4`,
+		kvTable, kvNotExpired)
+
+	rows, err := pool.Query(ctx, query, app, namespace, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*KVEntry, 0)
+	for rows.Next() {
+		entry, err := scanKVEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// DeleteExpiredKV deletes all entries that have expired, returning the number of entries deleted.
+func DeleteExpiredKV(ctx context.Context) (int64, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE expires_at <= NOW()", kvTable)
+	tag, err := pool.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "schedule_runs" (
+    "id" UUID PRIMARY KEY,
+    "schedule" TEXT NOT NULL,
+    "function" TEXT NOT NULL,
+    "scheduled_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "started_at" TIMESTAMP(3) WITH TIME ZONE NOT NULL,
+    "duration_ms" INTEGER,
+    "status" TEXT NOT NULL,
+    "execution_id" TEXT,
+    "error" TEXT,
+    "plugin_id" UUID REFERENCES plugins (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS schedule_runs_schedule_scheduled_at_idx ON schedule_runs (schedule, scheduled_at);
+CREATE INDEX IF NOT EXISTS schedule_runs_started_at_idx ON schedule_runs (started_at);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "jobs" (
+    "id" UUID PRIMARY KEY,
+    "function" TEXT NOT NULL,
+    "args" JSONB NOT NULL,
+    "priority" INTEGER NOT NULL DEFAULT 0,
+    "status" TEXT NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "max_attempts" INTEGER NOT NULL,
+    "run_at" TIMESTAMP(3) WITH TIME ZONE NOT NULL,
+    "locked_until" TIMESTAMP(3) WITH TIME ZONE,
+    "result" JSONB,
+    "error" TEXT,
+    "execution_id" TEXT,
+    "created_at" TIMESTAMP(3) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP(3) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "finished_at" TIMESTAMP(3) WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS jobs_pending_idx ON jobs (priority DESC, run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS jobs_running_locked_until_idx ON jobs (locked_until) WHERE status = 'running';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "kv" (
+    "app" TEXT NOT NULL,
+    "namespace" TEXT NOT NULL,
+    "key" TEXT NOT NULL,
+    "value" TEXT NOT NULL,
+    "version" BIGINT NOT NULL,
+    "expires_at" TIMESTAMP(3) WITH TIME ZONE,
+    "updated_at" TIMESTAMP(3) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("app", "namespace", "key")
+);
+
+CREATE INDEX IF NOT EXISTS kv_expires_at_idx ON kv (expires_at) WHERE expires_at IS NOT NULL;
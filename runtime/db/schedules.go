@@ -0,0 +1,102 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ScheduleLock is a lock on a schedule that is shared by all instances of the runtime using the same database.
+// It is a Postgres session-level advisory lock, so it is released automatically if the instance holding it goes away.
+type ScheduleLock struct {
+	conn *pgxpool.Conn
+	key  string
+}
+
+// TryLockSchedule attempts to lock the named schedule, returning nil if it is already locked by another run.
+func TryLockSchedule(ctx context.Context, scheduleName string) (*ScheduleLock, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := "modus_schedule:" + scheduleName
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", key).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, err
+	}
+
+	if !acquired {
+		conn.Release()
+		return nil, nil
+	}
+
+	return &ScheduleLock{conn: conn, key: key}, nil
+}
+
+// Release unlocks the schedule and returns the connection to the pool.
+func (l *ScheduleLock) Release(ctx context.Context) {
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock(hashtext($1))", l.key); err != nil {
+		// Closing the connection ensures the lock is released, since it is bound to the session.
+		logger.Warn(ctx).Err(err).Str("lock", l.key).Msg("Failed to release schedule lock.  Closing the connection instead.")
+		_ = l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+}
+
+// StartScheduleRun records the start of a scheduled run.  It returns false if the run for the
+// given scheduled time has already been recorded, which means another instance has already run it.
+func StartScheduleRun(ctx context.Context, id, scheduleName, function string, pluginId *string, scheduledAt, startedAt time.Time) (bool, error) {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s
+(id, schedule, function, scheduled_at, started_at, status, plugin_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (schedule, scheduled_at) DO NOTHING`,
+		scheduleRunsTable)
+
+	ct, err := pool.Exec(ctx, query, id, scheduleName, function, scheduledAt, startedAt, "running", pluginId)
+	if err != nil {
+		return false, err
+	}
+
+	return ct.RowsAffected() == 1, nil
+}
+
+// FinishScheduleRun records the outcome of a scheduled run.
+func FinishScheduleRun(ctx context.Context, id, status string, executionId, errMsg *string, duration time.Duration) error {
+	pool, err := globalRuntimePostgresWriter.GetPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s
+SET status = $2, duration_ms = $3, execution_id = $4, error = $5
+WHERE id = $1`,
+		scheduleRunsTable)
+
+	_, err = pool.Exec(ctx, query, id, status, duration.Milliseconds(), executionId, errMsg)
+	return err
+}
@@ -11,13 +11,19 @@ package functions
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
 
 	"github.com/hypermodeinc/modus/runtime/logger"
 	"github.com/hypermodeinc/modus/runtime/plugins"
 	"github.com/hypermodeinc/modus/runtime/utils"
 )
 
+// ErrFunctionNotRegistered is returned when calling a function that no loaded plugin exports.
+var ErrFunctionNotRegistered = errors.New("no function registered")
+
 func NewFunctionRegistry() FunctionRegistry {
 	return &functionRegistry{
 		functions: make(map[string]FunctionInfo),
@@ -29,17 +35,33 @@ type FunctionRegistry interface {
 	RegisterAllFunctions(ctx context.Context, plugins ...*plugins.Plugin)
 	RegisterImports(ctx context.Context, plugin *plugins.Plugin) []string
 	RegisterExports(ctx context.Context, plugin *plugins.Plugin) []string
+	RegisterCanaryFunctions(ctx context.Context, percent int, plugins ...*plugins.Plugin)
 }
 
 type functionRegistry struct {
 	functions map[string]FunctionInfo
+	canaries  atomic.Pointer[canaryFunctions]
+}
+
+// canaryFunctions are the functions of new plugin builds, which receive a percentage of the calls
+// to functions of the same name, until they are promoted or rolled back.
+type canaryFunctions struct {
+	percent   int
+	functions map[string]FunctionInfo
 }
 
 func (fr *functionRegistry) GetFunctionInfo(fnName string) (FunctionInfo, error) {
 	info, ok := fr.functions[fnName]
 	if !ok {
-		return nil, fmt.Errorf("no function registered named %s", fnName)
+		return nil, fmt.Errorf("%w named %s", ErrFunctionNotRegistered, fnName)
 	}
+
+	if c := fr.canaries.Load(); c != nil {
+		if canaryInfo, ok := c.functions[fnName]; ok && rand.IntN(100) < c.percent {
+			return canaryInfo, nil
+		}
+	}
+
 	return info, nil
 }
 
@@ -59,6 +81,33 @@ func (fr *functionRegistry) RegisterAllFunctions(ctx context.Context, plugins ..
 	triggerFunctionsLoaded(ctx)
 }
 
+// RegisterCanaryFunctions replaces the canary functions with the exported functions of the given plugins,
+// which will receive the given percentage of calls.  Only functions that are also registered are routed
+// to a canary, so functions that a canary adds can't be called until it is promoted.
+func (fr *functionRegistry) RegisterCanaryFunctions(ctx context.Context, percent int, plugins ...*plugins.Plugin) {
+	if len(plugins) == 0 || percent <= 0 {
+		fr.canaries.Store(nil)
+		return
+	}
+
+	fns := make(map[string]FunctionInfo)
+	for _, plugin := range plugins {
+		for fnName := range plugin.Module.ExportedFunctions() {
+			if info, ok := NewFunctionInfo(fnName, plugin, false); ok {
+				fns[fnName] = info
+			}
+		}
+
+		logger.Info(ctx).
+			Str("plugin", plugin.Name()).
+			Str("build_id", plugin.BuildId()).
+			Int("percent", percent).
+			Msg("Registered canary functions.")
+	}
+
+	fr.canaries.Store(&canaryFunctions{percent, fns})
+}
+
 func (fr *functionRegistry) RegisterExports(ctx context.Context, plugin *plugins.Plugin) []string {
 	fnExports := plugin.Module.ExportedFunctions()
 	names := make([]string, 0, len(fnExports))
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/jobs"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+type builtinFunction func(ctx context.Context, params map[string]any) (any, error)
+
+// builtinFunctions handle the GraphQL fields that are provided by the runtime, rather than by functions of a plugin.
+var builtinFunctions = map[string]builtinFunction{
+	jobs.EnqueueJobFunction: enqueueJob,
+	jobs.GetJobFunction:     getJob,
+}
+
+func enqueueJob(ctx context.Context, params map[string]any) (any, error) {
+	fnName, _ := params["function"].(string)
+
+	var args []any
+	if s, ok := params["args"].(string); ok && s != "" {
+		if err := utils.JsonDeserialize([]byte(s), &args); err != nil {
+			return nil, fmt.Errorf("args must be a JSON array: %w", err)
+		}
+	}
+
+	var opts jobs.EnqueueOptions
+	if n, ok := params["priority"].(json.Number); ok {
+		if v, err := n.Int64(); err == nil {
+			opts.Priority = int(v)
+		}
+	}
+	if n, ok := params["maxAttempts"].(json.Number); ok {
+		if v, err := n.Int64(); err == nil {
+			opts.MaxAttempts = int(v)
+		}
+	}
+
+	job, err := jobs.Enqueue(ctx, fnName, args, &opts)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func getJob(ctx context.Context, params map[string]any) (any, error) {
+	id, _ := params["id"].(string)
+	job, err := jobs.Get(ctx, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return job, nil
+}
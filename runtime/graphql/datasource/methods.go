@@ -0,0 +1,133 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/engine/resolve"
+)
+
+// methodResolver calls the methods that resolve fields of objects returned by a function.
+// Methods are only called for the fields that are selected, and receive the parent object as their receiver.
+type methodResolver struct {
+	ctx    context.Context
+	ds     *ModusDataSource
+	ci     *callInfo
+	path   []any
+	errors []resolve.GraphQLError
+}
+
+// newMethodResolver returns a resolver for the method fields selected for the call, or nil if there are none.
+func (ds *ModusDataSource) newMethodResolver(ctx context.Context, ci *callInfo) *methodResolver {
+	if !hasMethodFields(&ci.FieldInfo) {
+		return nil
+	}
+
+	return &methodResolver{
+		ctx:  ctx,
+		ds:   ds,
+		ci:   ci,
+		path: []any{ci.FieldInfo.AliasOrName()},
+	}
+}
+
+func hasMethodFields(tf *fieldInfo) bool {
+	for i := range tf.Fields {
+		if tf.Fields[i].Function != "" || hasMethodFields(&tf.Fields[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// enter and leave track the path within the response, so that method calls can be reported at the correct path.
+// They are no-ops when there are no method fields, to keep the transformation fast.
+
+func (r *methodResolver) enter(p any) {
+	if r != nil {
+		r.path = append(r.path, p)
+	}
+}
+
+func (r *methodResolver) leave() {
+	if r != nil {
+		r.path = r.path[:len(r.path)-1]
+	}
+}
+
+func (r *methodResolver) getErrors() []resolve.GraphQLError {
+	if r == nil {
+		return nil
+	}
+	return r.errors
+}
+
+// resolve calls the method for the field, passing the parent object as the receiver, and returns the result as json.
+// The receiver is rebuilt from the JSON of the parent object, so it only has the state that was serialized.
+// The build tool rejects methods of types that have fields which would not be serialized.
+func (r *methodResolver) resolve(tf *fieldInfo, parent []byte) ([]byte, error) {
+	var receiver map[string]any
+	if err := utils.JsonDeserialize(parent, &receiver); err != nil {
+		return nil, err
+	}
+	delete(receiver, "__typename")
+
+	params := make(map[string]any)
+	if tf.ArgsIndex < len(r.ci.FieldArgs) {
+		for k, v := range r.ci.FieldArgs[tf.ArgsIndex] {
+			params[k] = v
+		}
+	}
+
+	ci := &callInfo{
+		FieldInfo:    *tf,
+		FunctionName: tf.Function,
+		Parameters:   params,
+		receiver:     receiver,
+		path:         slices.Clone(r.path),
+	}
+
+	result, gqlErrors, err := r.ds.callFunction(r.ctx, ci)
+	r.errors = append(r.errors, gqlErrors...)
+	if err != nil {
+		r.errors = append(r.errors, resolve.GraphQLError{
+			Message: err.Error(),
+			Path:    ci.Path(),
+			Extensions: map[string]interface{}{
+				"level": "error",
+			},
+		})
+		return nullWord, nil
+	}
+
+	return utils.JsonSerialize(result)
+}
+
+// getOutputKey returns the key used to report a function's output in the response extensions.
+// Nested paths are reported under a single key, such as "products.0.reviews", so the dots are escaped.
+func getOutputKey(path []any) string {
+	if len(path) == 1 {
+		if s, ok := path[0].(string); ok {
+			return s
+		}
+	}
+
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = fmt.Sprint(p)
+	}
+	return strings.Join(parts, `\.`)
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package datasource
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// A cursor encodes either the id of an item, or the position of an item that has no id.
+// The kind of cursor is encoded with its key, so that a numeric id is never mistaken for a position.
+const (
+	idCursorPrefix     = "id:"
+	offsetCursorPrefix = "offset:"
+)
+
+// Cursors are opaque to the client.  Functions receive the key that the cursor encodes,
+// which is either the id of the last item received, or the number of items received so far.
+func encodeIdCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(idCursorPrefix + id))
+}
+
+func encodeOffsetCursor(position int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.Itoa(position)))
+}
+
+// decodeCursor returns the key that the cursor encodes, and the number of items that precede
+// the next page, if it is known.  It is only known for a cursor that encodes a position.
+func decodeCursor(cursor string) (key string, offset int, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		s := string(b)
+		if id, ok := strings.CutPrefix(s, idCursorPrefix); ok {
+			return id, 0, nil
+		}
+		if pos, ok := strings.CutPrefix(s, offsetCursorPrefix); ok {
+			if n, err := strconv.Atoi(pos); err == nil && n >= 0 {
+				return pos, n, nil
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("invalid cursor: %s", cursor)
+}
+
+// decodeCursorParameter replaces the "after" cursor of a paginated function with the key it encodes,
+// and returns the number of items that precede the requested page, if it is known.
+// When the first page is requested without a cursor, the function receives an empty string.
+func decodeCursorParameter(parameters map[string]any) (int, error) {
+	after, _ := parameters["after"].(string)
+	if after == "" {
+		parameters["after"] = ""
+		return 0, nil
+	}
+
+	key, offset, err := decodeCursor(after)
+	if err != nil {
+		return 0, err
+	}
+	parameters["after"] = key
+	return offset, nil
+}
+
+// transformConnection wraps the result of a paginated function in a connection object.
+// The result can be either a list of items, or a page object containing a list of items and page info.
+// The offset is the number of items that precede the page, which is used for the cursors of items that have no id.
+func transformConnection(data []byte, parameters map[string]any, offset int) ([]byte, error) {
+
+	items := data
+	isPage := len(data) > 0 && data[0] == '{'
+	var hasNextPage, hasPreviousPage bool
+	if isPage {
+		v, _, _, err := jsonparser.Get(data, "items")
+		if err != nil && err != jsonparser.KeyPathNotFoundError {
+			return nil, err
+		}
+		items = v
+		hasNextPage, _ = jsonparser.GetBoolean(data, "pageInfo", "hasNextPage")
+		hasPreviousPage, _ = jsonparser.GetBoolean(data, "pageInfo", "hasPreviousPage")
+	}
+
+	nodes := make([][]byte, 0)
+	if len(items) > 0 && !bytes.Equal(items, nullWord) {
+		if _, err := jsonparser.ArrayEach(items, func(val []byte, dataType jsonparser.ValueType, _ int, _ error) {
+			if dataType == jsonparser.String {
+				// Note, string values here will be escaped for internal quotes, newlines, etc.,
+				// but will be missing outer quotes.  So we need to add them back.
+				val = []byte(`"` + string(val) + `"`)
+			}
+			nodes = append(nodes, val)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	after, _ := parameters["after"].(string)
+	if !isPage {
+		// A function that returns a list indicates there are more items by returning more than were requested.
+		if first, ok := getIntParameter(parameters, "first"); ok && first >= 0 && len(nodes) > first {
+			nodes = nodes[:first]
+			hasNextPage = true
+		}
+		hasPreviousPage = after != ""
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString(`{"edges":[`)
+	cursors := make([]string, len(nodes))
+	for i, node := range nodes {
+		cursors[i] = getCursor(node, offset+i+1)
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"cursor":"`)
+		buf.WriteString(cursors[i])
+		buf.WriteString(`","node":`)
+		buf.Write(node)
+		buf.WriteByte('}')
+	}
+
+	buf.WriteString(`],"nodes":[`)
+	buf.Write(bytes.Join(nodes, []byte{','}))
+
+	buf.WriteString(`],"pageInfo":{"hasNextPage":`)
+	buf.WriteString(strconv.FormatBool(hasNextPage))
+	buf.WriteString(`,"hasPreviousPage":`)
+	buf.WriteString(strconv.FormatBool(hasPreviousPage))
+	if len(cursors) > 0 {
+		buf.WriteString(`,"startCursor":"`)
+		buf.WriteString(cursors[0])
+		buf.WriteString(`","endCursor":"`)
+		buf.WriteString(cursors[len(cursors)-1])
+		buf.WriteString(`"}}`)
+	} else {
+		buf.WriteString(`,"startCursor":null,"endCursor":null}}`)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// getCursor returns a cursor for the item's id if it has one, or else for its position in the full list of items.
+func getCursor(node []byte, position int) string {
+	if len(node) > 0 && node[0] == '{' {
+		if id, dataType, _, err := jsonparser.Get(node, "id"); err == nil {
+			switch dataType {
+			case jsonparser.String:
+				if s, err := jsonparser.ParseString(id); err == nil {
+					return encodeIdCursor(s)
+				}
+			case jsonparser.Number:
+				return encodeIdCursor(string(id))
+			}
+		}
+	}
+	return encodeOffsetCursor(position)
+}
+
+func getIntParameter(parameters map[string]any, name string) (int, bool) {
+	switch v := parameters[name].(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n), true
+		}
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
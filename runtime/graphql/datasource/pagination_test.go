@@ -0,0 +1,68 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package datasource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorsWithNumericIds(t *testing.T) {
+	parameters := map[string]any{"first": json.Number("2"), "after": ""}
+	offset, err := decodeCursorParameter(parameters)
+	require.NoError(t, err)
+
+	data, err := transformConnection([]byte(`[{"id":10},{"id":20},{"id":30}]`), parameters, offset)
+	require.NoError(t, err)
+
+	endCursor, err := jsonparser.GetString(data, "pageInfo", "endCursor")
+	require.NoError(t, err)
+
+	// The function receives the id of the last item, not a position.
+	parameters = map[string]any{"first": json.Number("2"), "after": endCursor}
+	offset, err = decodeCursorParameter(parameters)
+	require.NoError(t, err)
+	assert.Equal(t, "20", parameters["after"])
+	assert.Equal(t, 0, offset)
+}
+
+func TestCursorsWithPositions(t *testing.T) {
+	parameters := map[string]any{"first": json.Number("2"), "after": ""}
+	data, err := transformConnection([]byte(`["a","b","c"]`), parameters, 0)
+	require.NoError(t, err)
+
+	endCursor, err := jsonparser.GetString(data, "pageInfo", "endCursor")
+	require.NoError(t, err)
+
+	parameters = map[string]any{"first": json.Number("2"), "after": endCursor}
+	offset, err := decodeCursorParameter(parameters)
+	require.NoError(t, err)
+	assert.Equal(t, "2", parameters["after"])
+	assert.Equal(t, 2, offset)
+
+	// The positions of the next page continue from the offset.
+	data, err = transformConnection([]byte(`["c"]`), parameters, offset)
+	require.NoError(t, err)
+	startCursor, err := jsonparser.GetString(data, "pageInfo", "startCursor")
+	require.NoError(t, err)
+	assert.Equal(t, encodeOffsetCursor(3), startCursor)
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	_, err := decodeCursorParameter(map[string]any{"after": "20"})
+	assert.Error(t, err)
+
+	_, err = decodeCursorParameter(map[string]any{"after": encodeOffsetCursor(-1)})
+	assert.Error(t, err)
+}
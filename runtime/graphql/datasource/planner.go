@@ -34,18 +34,22 @@ type HypDSPlanner struct {
 		fieldInfo    *fieldInfo
 		functionName string
 		data         []byte
+		fieldArgs    [][]byte
 	}
 }
 
 type fieldInfo struct {
-	ref        int         `json:"-"`
-	Name       string      `json:"name"`
-	Alias      string      `json:"alias,omitempty"`
-	TypeName   string      `json:"type,omitempty"`
-	ParentType string      `json:"parentType,omitempty"`
-	Fields     []fieldInfo `json:"fields,omitempty"`
-	IsMapType  bool        `json:"isMapType,omitempty"`
-	fieldRefs  []int       `json:"-"`
+	ref              int         `json:"-"`
+	Name             string      `json:"name"`
+	Alias            string      `json:"alias,omitempty"`
+	TypeName         string      `json:"type,omitempty"`
+	ParentType       string      `json:"parentType,omitempty"`
+	Fields           []fieldInfo `json:"fields,omitempty"`
+	IsMapType        bool        `json:"isMapType,omitempty"`
+	IsConnectionType bool        `json:"isConnectionType,omitempty"`
+	Function         string      `json:"function,omitempty"`
+	ArgsIndex        int         `json:"argsIndex,omitempty"`
+	fieldRefs        []int       `json:"-"`
 }
 
 func (t *fieldInfo) AliasOrName() string {
@@ -99,12 +103,26 @@ func (p *HypDSPlanner) Register(visitor *plan.Visitor, configuration plan.DataSo
 
 func (p *HypDSPlanner) EnterDocument(operation, definition *ast.Document) {
 	p.fields = make(map[int]fieldInfo, len(operation.Fields))
+	p.variables = resolve.NewVariables()
 }
 
 func (p *HypDSPlanner) EnterField(ref int) {
 
 	// Capture information about every field in the operation.
 	f := p.captureField(ref)
+
+	// Capture the arguments of fields that are resolved by calling a method of their parent object.
+	if fn, ok := p.config.FieldsToFunctions[f.ParentType+"."+f.Name]; ok {
+		data, err := p.captureArguments(ref)
+		if err != nil {
+			logger.Err(p.ctx, err).Msg("Error capturing field arguments.")
+		} else {
+			f.Function = fn
+			f.ArgsIndex = len(p.template.fieldArgs)
+			p.template.fieldArgs = append(p.template.fieldArgs, data)
+		}
+	}
+
 	p.fields[ref] = *f
 
 	// Capture only the fields that represent function calls.
@@ -171,21 +189,53 @@ func (p *HypDSPlanner) captureField(ref int) *fieldInfo {
 		f.TypeName = definition.FieldDefinitionTypeNameString(def)
 		f.ParentType = walker.EnclosingTypeDefinition.NameString(definition)
 		f.IsMapType = slices.Contains(p.config.MapTypes, f.TypeName)
+		f.IsConnectionType = slices.Contains(p.config.ConnectionTypes, f.TypeName)
 	}
 
 	if operation.FieldHasSelections(ref) {
 		ssRef, ok := operation.FieldSelectionSet(ref)
 		if ok {
-			f.fieldRefs = operation.SelectionSetFieldSelections(ssRef)
+			f.fieldRefs = p.getFieldRefs(ssRef)
 		}
 	}
 
 	return f
 }
 
+// getFieldRefs returns the fields of a selection set, including those selected within inline fragments.
+// Fragments are used to select fields of the possible types of an interface or union.
+func (p *HypDSPlanner) getFieldRefs(ssRef int) []int {
+	operation := p.visitor.Operation
+	selectionRefs := operation.SelectionSets[ssRef].SelectionRefs
+
+	refs := make([]int, 0, len(selectionRefs))
+	for _, selectionRef := range selectionRefs {
+		selection := operation.Selections[selectionRef]
+		switch selection.Kind {
+		case ast.SelectionKindField:
+			refs = append(refs, selection.Ref)
+		case ast.SelectionKindInlineFragment:
+			if fragmentSSRef, ok := operation.InlineFragmentSelectionSet(selection.Ref); ok {
+				refs = append(refs, p.getFieldRefs(fragmentSSRef)...)
+			}
+		}
+	}
+
+	return refs
+}
+
 func (p *HypDSPlanner) captureInputData(fieldRef int) error {
+	data, err := p.captureArguments(fieldRef)
+	if err != nil {
+		return err
+	}
+
+	p.template.data = data
+	return nil
+}
+
+func (p *HypDSPlanner) captureArguments(fieldRef int) ([]byte, error) {
 	operation := p.visitor.Operation
-	variables := resolve.NewVariables()
 	var buf bytes.Buffer
 	buf.WriteByte('{')
 
@@ -203,7 +253,7 @@ func (p *HypDSPlanner) captureInputData(fieldRef int) error {
 		argName := operation.ArgumentNameString(arg)
 
 		variableName := operation.VariableValueNameString(argValue.Ref)
-		placeHolder, _ := variables.AddVariable(
+		placeHolder, _ := p.variables.AddVariable(
 			&resolve.ContextVariable{
 				Path:     []string{variableName},
 				Renderer: resolve.NewJSONVariableRenderer(),
@@ -211,7 +261,7 @@ func (p *HypDSPlanner) captureInputData(fieldRef int) error {
 
 		escapedKey, err := utils.JsonSerialize(argName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		buf.Write(escapedKey)
@@ -219,9 +269,7 @@ func (p *HypDSPlanner) captureInputData(fieldRef int) error {
 		buf.WriteString(placeHolder)
 	}
 	buf.WriteByte('}')
-	p.template.data = buf.Bytes()
-	p.variables = variables
-	return nil
+	return buf.Bytes(), nil
 }
 
 func (p *HypDSPlanner) ConfigureFetch() resolve.FetchConfiguration {
@@ -240,7 +288,7 @@ func (p *HypDSPlanner) ConfigureFetch() resolve.FetchConfiguration {
 	// Note: we have to build the rest of the template manually, because the data field may
 	// contain placeholders for variables, such as $$0$$ which are not valid in JSON.
 	// They are replaced with the actual values by the time Load is called.
-	inputTemplate := fmt.Sprintf(`{"field":%s,"function":%s,"data":%s}`, fieldInfoJson, functionNameJson, p.template.data)
+	inputTemplate := fmt.Sprintf(`{"field":%s,"function":%s,"data":%s,"fieldArgs":[%s]}`, fieldInfoJson, functionNameJson, p.template.data, bytes.Join(p.template.fieldArgs, []byte{','}))
 
 	return resolve.FetchConfiguration{
 		Input:     inputTemplate,
@@ -28,9 +28,28 @@ import (
 const DataSourceName = "ModusDataSource"
 
 type callInfo struct {
-	FieldInfo    fieldInfo      `json:"field"`
-	FunctionName string         `json:"function"`
-	Parameters   map[string]any `json:"data"`
+	FieldInfo    fieldInfo        `json:"field"`
+	FunctionName string           `json:"function"`
+	Parameters   map[string]any   `json:"data"`
+	FieldArgs    []map[string]any `json:"fieldArgs"`
+
+	// These are only set when calling a method of a parent object.
+	receiver any
+	path     []any
+
+	// afterOffset is the number of items that precede the requested page of a paginated function, if known.
+	afterOffset int
+
+	// files holds the placeholders of files provided by the GraphQL engine, which are passed to a file parameter.
+	files []any
+}
+
+// Path returns the path of the field in the response, for use in errors and function output.
+func (ci *callInfo) Path() []any {
+	if ci.path != nil {
+		return ci.path
+	}
+	return []any{ci.FieldInfo.AliasOrName()}
 }
 
 type ModusDataSource struct {
@@ -38,6 +57,14 @@ type ModusDataSource struct {
 }
 
 func (ds *ModusDataSource) Load(ctx context.Context, input []byte, out *bytes.Buffer) error {
+	return ds.load(ctx, input, nil, out)
+}
+
+func (ds *ModusDataSource) LoadWithFiles(ctx context.Context, input []byte, files []httpclient.File, out *bytes.Buffer) error {
+	return ds.load(ctx, input, files, out)
+}
+
+func (ds *ModusDataSource) load(ctx context.Context, input []byte, files []httpclient.File, out *bytes.Buffer) error {
 
 	// Parse the input to get the function call info
 	var ci callInfo
@@ -46,11 +73,20 @@ func (ds *ModusDataSource) Load(ctx context.Context, input []byte, out *bytes.Bu
 		return fmt.Errorf("error parsing input: %w", err)
 	}
 
+	// Add any files provided by the GraphQL engine
+	if len(files) > 0 {
+		ctx, err = addFilesToCallInfo(ctx, &ci, files)
+		if err != nil {
+			return fmt.Errorf("error reading uploaded files: %w", err)
+		}
+	}
+
 	// Load the data
 	result, gqlErrors, err := ds.callFunction(ctx, &ci)
 
 	// Write the response
-	err = writeGraphQLResponse(ctx, out, result, gqlErrors, err, &ci)
+	mr := ds.newMethodResolver(ctx, &ci)
+	err = writeGraphQLResponse(ctx, out, result, gqlErrors, err, &ci, mr)
 	if err != nil {
 		logger.Error(ctx).Err(err).Msg("Error creating GraphQL response.")
 	}
@@ -58,11 +94,6 @@ func (ds *ModusDataSource) Load(ctx context.Context, input []byte, out *bytes.Bu
 	return err
 }
 
-func (*ModusDataSource) LoadWithFiles(ctx context.Context, input []byte, files []httpclient.File, out *bytes.Buffer) (err error) {
-	// See https://github.com/wundergraph/graphql-go-tools/pull/758
-	panic("not implemented")
-}
-
 func (ds *ModusDataSource) callFunction(ctx context.Context, callInfo *callInfo) (any, []resolve.GraphQLError, error) {
 
 	// Handle special case for __typename on root Query or Mutation
@@ -70,12 +101,46 @@ func (ds *ModusDataSource) callFunction(ctx context.Context, callInfo *callInfo)
 		return callInfo.FieldInfo.ParentType, nil, nil
 	}
 
+	// Handle fields that are provided by the runtime
+	if fn, ok := builtinFunctions[callInfo.FunctionName]; ok {
+		ctx = context.WithValue(ctx, utils.WasmHostContextKey, ds.WasmHost)
+		result, err := fn(ctx, callInfo.Parameters)
+		return result, nil, err
+	}
+
 	// Get the function info
 	fnInfo, err := ds.WasmHost.GetFunctionInfo(callInfo.FunctionName)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Pass the parent object to a method as its first parameter
+	if callInfo.receiver != nil {
+		fnMeta := fnInfo.Metadata()
+		callInfo.Parameters[fnMeta.Parameters[0].Name] = callInfo.receiver
+	}
+
+	// Decode the cursor of a paginated function
+	if callInfo.FieldInfo.IsConnectionType {
+		offset, err := decodeCursorParameter(callInfo.Parameters)
+		if err != nil {
+			return nil, nil, err
+		}
+		callInfo.afterOffset = offset
+	}
+
+	// Bind any uploaded files to the function parameters
+	if len(callInfo.files) > 0 {
+		if err := addFilesToParameters(fnInfo, callInfo.Parameters, callInfo.files); err != nil {
+			return nil, nil, err
+		}
+	}
+	if uploads := getUploads(ctx); len(uploads) > 0 {
+		if err := uploads.bindUploads(fnInfo, callInfo.Parameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Call the function
 	execInfo, err := ds.WasmHost.CallFunction(ctx, fnInfo, callInfo.Parameters)
 	if err != nil {
@@ -85,7 +150,7 @@ func (ds *ModusDataSource) callFunction(ctx context.Context, callInfo *callInfo)
 
 	// Store the execution info into the function output map.
 	outputMap := ctx.Value(utils.FunctionOutputContextKey).(map[string]wasmhost.ExecutionInfo)
-	outputMap[callInfo.FieldInfo.AliasOrName()] = execInfo
+	outputMap[getOutputKey(callInfo.Path())] = execInfo
 
 	// Transform messages (and error lines in the output buffers) to GraphQL errors.
 	messages := append(execInfo.Messages(), utils.TransformConsoleOutput(execInfo.Buffers())...)
@@ -111,7 +176,7 @@ func (ds *ModusDataSource) callFunction(ctx context.Context, callInfo *callInfo)
 	return result, gqlErrors, err
 }
 
-func writeGraphQLResponse(ctx context.Context, out *bytes.Buffer, result any, gqlErrors []resolve.GraphQLError, fnErr error, ci *callInfo) error {
+func writeGraphQLResponse(ctx context.Context, out *bytes.Buffer, result any, gqlErrors []resolve.GraphQLError, fnErr error, ci *callInfo, mr *methodResolver) error {
 
 	fieldName := ci.FieldInfo.AliasOrName()
 
@@ -126,16 +191,6 @@ func writeGraphQLResponse(ctx context.Context, out *bytes.Buffer, result any, gq
 		})
 	}
 
-	// If there are GraphQL errors, serialize them as json
-	var jsonErrors []byte
-	if len(gqlErrors) > 0 {
-		var err error
-		jsonErrors, err = utils.JsonSerialize(gqlErrors)
-		if err != nil {
-			return err
-		}
-	}
-
 	// If there is any result data, or if the data is null without errors, serialize the data as json
 	var jsonData []byte
 	if result != nil || len(gqlErrors) == 0 {
@@ -154,14 +209,34 @@ func writeGraphQLResponse(ctx context.Context, out *bytes.Buffer, result any, gq
 			return err
 		}
 
+		// Wrap the result of a paginated function in a connection
+		if ci.FieldInfo.IsConnectionType {
+			if jsonResult, err = transformConnection(jsonResult, ci.Parameters, ci.afterOffset); err != nil {
+				return err
+			}
+		}
+
 		// Transform the data
-		if r, err := transformValue(jsonResult, &ci.FieldInfo); err != nil {
+		if r, err := transformValue(jsonResult, &ci.FieldInfo, mr); err != nil {
 			return err
 		} else {
 			jsonData = r
 		}
 	}
 
+	// Include any errors from methods that were called while transforming the data
+	gqlErrors = append(gqlErrors, mr.getErrors()...)
+
+	// If there are GraphQL errors, serialize them as json
+	var jsonErrors []byte
+	if len(gqlErrors) > 0 {
+		var err error
+		jsonErrors, err = utils.JsonSerialize(gqlErrors)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Write the response.  This should be as efficient as possible, as it is called for every function invocation.
 	out.Grow(len(jsonData) + len(jsonErrors) + len(fieldName) + 26)
 	out.WriteByte('{')
@@ -186,7 +261,7 @@ func writeGraphQLResponse(ctx context.Context, out *bytes.Buffer, result any, gq
 
 var nullWord = []byte("null")
 
-func transformValue(data []byte, tf *fieldInfo) (result []byte, err error) {
+func transformValue(data []byte, tf *fieldInfo, mr *methodResolver) (result []byte, err error) {
 	if len(tf.Fields) == 0 || len(data) == 0 || bytes.Equal(data, nullWord) {
 		return data, nil
 	}
@@ -194,27 +269,31 @@ func transformValue(data []byte, tf *fieldInfo) (result []byte, err error) {
 	switch data[0] {
 	case '{':
 		if tf.IsMapType {
-			return transformMap(data, tf)
+			return transformMap(data, tf, mr)
 		} else {
-			return transformObject(data, tf)
+			return transformObject(data, tf, mr)
 		}
 	case '[':
-		return transformArray(data, tf)
+		return transformArray(data, tf, mr)
 	default:
 		return nil, fmt.Errorf("expected object or array")
 	}
 }
 
-func transformArray(data []byte, tf *fieldInfo) ([]byte, error) {
+func transformArray(data []byte, tf *fieldInfo, mr *methodResolver) ([]byte, error) {
 	buf := bytes.Buffer{}
 	buf.WriteByte('[')
 
 	var loopErr error
+	i := 0
 	_, err := jsonparser.ArrayEach(data, func(val []byte, _ jsonparser.ValueType, _ int, _ error) {
 		if loopErr != nil {
 			return
 		}
-		val, err := transformValue(val, tf)
+		mr.enter(i)
+		val, err := transformValue(val, tf, mr)
+		mr.leave()
+		i++
 		if err != nil {
 			loopErr = err
 			return
@@ -235,13 +314,46 @@ func transformArray(data []byte, tf *fieldInfo) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func transformObject(data []byte, tf *fieldInfo) ([]byte, error) {
+func transformObject(data []byte, tf *fieldInfo, mr *methodResolver) ([]byte, error) {
+
+	// Objects that were returned through an interface or union type will include the name of their concrete type.
+	typeName := tf.TypeName
+	concreteTypeName, err := jsonparser.GetString(data, "__typename")
+	isAbstract := err == nil
+	if isAbstract {
+		typeName = concreteTypeName
+	}
+
 	buf := bytes.Buffer{}
 	buf.WriteByte('{')
-	for i, f := range tf.Fields {
+	written := make(map[string]bool, len(tf.Fields))
+	for _, f := range tf.Fields {
+		name := f.AliasOrName()
+
+		// Skip fields that were selected from fragments on other possible types,
+		// and fields that were selected more than once across fragments.
+		if isAbstract {
+			if f.ParentType != "" && f.ParentType != tf.TypeName && f.ParentType != typeName {
+				continue
+			}
+			if written[name] {
+				continue
+			}
+		}
+
 		var val []byte
 		if f.Name == "__typename" {
-			val = []byte(`"` + tf.TypeName + `"`)
+			val = []byte(`"` + typeName + `"`)
+		} else if f.Function != "" {
+			mr.enter(name)
+			v, err := mr.resolve(&f, data)
+			if err == nil {
+				val, err = transformValue(v, &f, mr)
+			}
+			mr.leave()
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			v, dataType, _, err := jsonparser.Get(data, f.Name)
 			if err != nil {
@@ -252,29 +364,43 @@ func transformObject(data []byte, tf *fieldInfo) ([]byte, error) {
 				// but will be missing outer quotes.  So we need to add them back.
 				v = []byte(`"` + string(v) + `"`)
 			}
-			val, err = transformValue(v, &f)
+			mr.enter(name)
+			val, err = transformValue(v, &f, mr)
+			mr.leave()
 			if err != nil {
 				return nil, err
 			}
 		}
-		if i > 0 {
+		if len(written) > 0 {
 			buf.WriteByte(',')
 		}
 		buf.WriteByte('"')
-		buf.WriteString(f.AliasOrName())
+		buf.WriteString(name)
 		buf.WriteString(`":`)
 		buf.Write(val)
+		written[name] = true
 	}
+
+	// The resolver requires the concrete type name to resolve fragments on interfaces and unions.
+	if isAbstract && !written["__typename"] {
+		if len(written) > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`"__typename":"`)
+		buf.WriteString(typeName)
+		buf.WriteByte('"')
+	}
+
 	buf.WriteByte('}')
 	return buf.Bytes(), nil
 }
 
-func transformMap(data []byte, tf *fieldInfo) ([]byte, error) {
+func transformMap(data []byte, tf *fieldInfo, mr *methodResolver) ([]byte, error) {
 
 	// check for pseudo map
 	md, dt, _, err := jsonparser.Get(data, "$mapdata")
 	if err == nil && dt == jsonparser.Array {
-		return transformPseudoMap(md, tf)
+		return transformPseudoMap(md, tf, mr)
 	}
 
 	var keyType string
@@ -314,7 +440,7 @@ func transformMap(data []byte, tf *fieldInfo) ([]byte, error) {
 		}
 		b.WriteByte('}')
 
-		val, err := transformObject(b.Bytes(), tf)
+		val, err := transformObject(b.Bytes(), tf, mr)
 		if err != nil {
 			return err
 		}
@@ -329,7 +455,7 @@ func transformMap(data []byte, tf *fieldInfo) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func transformPseudoMap(data []byte, tf *fieldInfo) ([]byte, error) {
+func transformPseudoMap(data []byte, tf *fieldInfo, mr *methodResolver) ([]byte, error) {
 	buf := bytes.Buffer{}
 	buf.WriteByte('[')
 
@@ -375,7 +501,7 @@ func transformPseudoMap(data []byte, tf *fieldInfo) ([]byte, error) {
 		}
 		b.WriteByte('}')
 
-		val, err := transformObject(b.Bytes(), tf)
+		val, err := transformObject(b.Bytes(), tf, mr)
 		if err != nil {
 			loopErr = err
 			return
@@ -401,7 +527,7 @@ func transformErrors(messages []utils.LogMessage, ci *callInfo) []resolve.GraphQ
 		if msg.IsError() {
 			errors = append(errors, resolve.GraphQLError{
 				Message: msg.Message,
-				Path:    []any{ci.FieldInfo.AliasOrName()},
+				Path:    ci.Path(),
 				Extensions: map[string]interface{}{
 					"level": msg.Level,
 				},
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/functions"
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/rs/xid"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/engine/datasource/httpclient"
+)
+
+const uploadPlaceholderPrefix = "modus-upload:"
+
+// Upload is a file that was uploaded with a GraphQL request.
+type Upload struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Uploads holds the files uploaded with a GraphQL request, keyed by the placeholder
+// that takes the place of each file in the request variables.
+type Uploads map[string]*Upload
+
+// Add adds an uploaded file, and returns the placeholder that should be used to reference it.
+func (u Uploads) Add(upload *Upload) string {
+	placeholder := uploadPlaceholderPrefix + xid.New().String()
+	u[placeholder] = upload
+	return placeholder
+}
+
+func getUploads(ctx context.Context) Uploads {
+	if uploads, ok := ctx.Value(utils.UploadsContextKey).(Uploads); ok {
+		return uploads
+	}
+	return nil
+}
+
+// addFilesToCallInfo reads files provided by the GraphQL engine, and holds them for binding to the function parameters.
+// Unlike files from a multipart request, these files don't carry the variable paths that reference them.
+func addFilesToCallInfo(ctx context.Context, ci *callInfo, files []httpclient.File) (context.Context, error) {
+	uploads := getUploads(ctx)
+	if uploads == nil {
+		uploads = make(Uploads, len(files))
+		ctx = context.WithValue(ctx, utils.UploadsContextKey, uploads)
+	}
+
+	if ci.Parameters == nil {
+		ci.Parameters = make(map[string]any, 1)
+	}
+
+	ci.files = make([]any, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file.Path())
+		if err != nil {
+			return ctx, err
+		}
+
+		ci.files[i] = uploads.Add(&Upload{
+			Name:        file.Name(),
+			ContentType: http.DetectContentType(data),
+			Data:        data,
+		})
+	}
+
+	return ctx, nil
+}
+
+// addFilesToParameters passes files that don't carry variable paths to the one parameter of the function
+// that takes a file or a list of files, and that isn't already set.  If there isn't exactly one such parameter,
+// the files can't be matched to the function, and must be referenced by the variables of the operation instead.
+func addFilesToParameters(fnInfo functions.FunctionInfo, parameters map[string]any, files []any) error {
+	lti := fnInfo.Plugin().Language.TypeInfo()
+
+	var target *metadata.Parameter
+	for _, p := range fnInfo.Metadata().Parameters {
+		if parameters[p.Name] != nil {
+			continue
+		}
+
+		typ := lti.GetUnderlyingType(p.Type)
+		if lti.IsListType(typ) {
+			typ = lti.GetUnderlyingType(lti.GetListSubtype(typ))
+		}
+		if !lti.IsFileType(typ) {
+			continue
+		}
+
+		if target != nil {
+			return fmt.Errorf("function %s has more than one file parameter, so uploaded files must be referenced by the variables of the operation", fnInfo.Name())
+		}
+		target = p
+	}
+
+	if target == nil {
+		return fmt.Errorf("function %s has no file parameter for the uploaded files", fnInfo.Name())
+	}
+
+	if lti.IsListType(lti.GetUnderlyingType(target.Type)) {
+		parameters[target.Name] = files
+	} else if len(files) == 1 {
+		parameters[target.Name] = files[0]
+	} else {
+		return fmt.Errorf("parameter %s of function %s takes a single file, but %d files were uploaded", target.Name, fnInfo.Name(), len(files))
+	}
+
+	return nil
+}
+
+// bindUploads replaces upload placeholders in the parameters with the uploaded files,
+// in the form expected by the type of each function parameter.  Placeholders are found at any depth,
+// including within lists and the fields of input objects.
+func (u Uploads) bindUploads(fnInfo functions.FunctionInfo, parameters map[string]any) error {
+	b := &uploadBinder{
+		uploads: u,
+		lti:     fnInfo.Plugin().Language.TypeInfo(),
+		md:      fnInfo.Plugin().Metadata,
+	}
+	for _, p := range fnInfo.Metadata().Parameters {
+		if val, ok := parameters[p.Name]; ok {
+			v, err := b.bindValue(p.Type, val)
+			if err != nil {
+				return fmt.Errorf("error binding parameter %s: %w", p.Name, err)
+			}
+			parameters[p.Name] = v
+		}
+	}
+	return nil
+}
+
+type uploadBinder struct {
+	uploads Uploads
+	lti     langsupport.LanguageTypeInfo
+	md      *metadata.Metadata
+}
+
+func (b *uploadBinder) bindValue(typ string, val any) (any, error) {
+	lti := b.lti
+	typ = lti.GetUnderlyingType(typ)
+
+	switch v := val.(type) {
+	case string:
+		upload, ok := b.uploads[v]
+		if !ok {
+			return val, nil
+		}
+
+		switch {
+		case lti.IsFileType(typ):
+			return map[string]any{
+				"name":        upload.Name,
+				"contentType": upload.ContentType,
+				"data":        upload.Data,
+			}, nil
+		case lti.IsByteSequenceType(typ):
+			return upload.Data, nil
+		default:
+			return nil, fmt.Errorf("uploaded file %s cannot be used for type %s", upload.Name, typ)
+		}
+
+	case []any:
+		if !lti.IsListType(typ) {
+			return val, nil
+		}
+
+		elemType := lti.GetListSubtype(typ)
+		for i, item := range v {
+			bv, err := b.bindValue(elemType, item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = bv
+		}
+
+	case map[string]any:
+		if !lti.IsObjectType(typ) || lti.IsFileType(typ) {
+			return val, nil
+		}
+
+		def, err := b.md.GetTypeDefinition(typ)
+		if err != nil {
+			return val, nil
+		}
+
+		for _, f := range def.Fields {
+			if item, ok := v[f.Name]; ok {
+				bv, err := b.bindValue(f.Type, item)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", f.Name, err)
+				}
+				v[f.Name] = bv
+			}
+		}
+	}
+
+	return val, nil
+}
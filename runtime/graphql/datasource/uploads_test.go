@@ -0,0 +1,101 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package datasource
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+	"github.com/hypermodeinc/modus/runtime/languages"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFileType = "github.com/hypermodeinc/modus/sdk/go/pkg/files.File"
+
+type testFunctionInfo struct {
+	fn     *metadata.Function
+	plugin *plugins.Plugin
+}
+
+func (f *testFunctionInfo) Name() string                             { return f.fn.Name }
+func (f *testFunctionInfo) IsImport() bool                           { return false }
+func (f *testFunctionInfo) Plugin() *plugins.Plugin                  { return f.plugin }
+func (f *testFunctionInfo) Metadata() *metadata.Function             { return f.fn }
+func (f *testFunctionInfo) ExecutionPlan() langsupport.ExecutionPlan { return nil }
+
+func newTestFunctionInfo(params ...*metadata.Parameter) *testFunctionInfo {
+	md := &metadata.Metadata{
+		Types: metadata.TypeMap{
+			"main.DocumentInput": {
+				Name: "main.DocumentInput",
+				Fields: []*metadata.Field{
+					{Name: "title", Type: "string"},
+					{Name: "file", Type: "*" + testFileType},
+					{Name: "attachments", Type: "[]" + testFileType},
+				},
+			},
+		},
+	}
+	return &testFunctionInfo{
+		fn:     &metadata.Function{Name: "addDocument", Parameters: params},
+		plugin: &plugins.Plugin{Metadata: md, Language: languages.GoLang()},
+	}
+}
+
+func TestBindUploadsInInputObject(t *testing.T) {
+	uploads := make(Uploads)
+	p1 := uploads.Add(&Upload{Name: "a.txt", ContentType: "text/plain", Data: []byte("a")})
+	p2 := uploads.Add(&Upload{Name: "b.txt", ContentType: "text/plain", Data: []byte("b")})
+
+	fnInfo := newTestFunctionInfo(&metadata.Parameter{Name: "doc", Type: "main.DocumentInput"})
+	parameters := map[string]any{
+		"doc": map[string]any{
+			"title":       "Report",
+			"file":        p1,
+			"attachments": []any{p2},
+		},
+	}
+
+	require.NoError(t, uploads.bindUploads(fnInfo, parameters))
+
+	doc := parameters["doc"].(map[string]any)
+	assert.Equal(t, "Report", doc["title"])
+	assert.Equal(t, map[string]any{"name": "a.txt", "contentType": "text/plain", "data": []byte("a")}, doc["file"])
+	assert.Equal(t, []any{map[string]any{"name": "b.txt", "contentType": "text/plain", "data": []byte("b")}}, doc["attachments"])
+}
+
+func TestAddFilesToParameters(t *testing.T) {
+	fnInfo := newTestFunctionInfo(
+		&metadata.Parameter{Name: "collection", Type: "string"},
+		&metadata.Parameter{Name: "document", Type: testFileType},
+	)
+
+	parameters := map[string]any{"collection": "docs"}
+	require.NoError(t, addFilesToParameters(fnInfo, parameters, []any{"f1"}))
+	assert.Equal(t, "f1", parameters["document"])
+
+	parameters = map[string]any{"collection": "docs"}
+	assert.Error(t, addFilesToParameters(fnInfo, parameters, []any{"f1", "f2"}))
+
+	fnInfo = newTestFunctionInfo(&metadata.Parameter{Name: "images", Type: "[]" + testFileType})
+	parameters = map[string]any{}
+	require.NoError(t, addFilesToParameters(fnInfo, parameters, []any{"f1", "f2"}))
+	assert.Equal(t, []any{"f1", "f2"}, parameters["images"])
+
+	fnInfo = newTestFunctionInfo(
+		&metadata.Parameter{Name: "front", Type: testFileType},
+		&metadata.Parameter{Name: "back", Type: testFileType},
+	)
+	assert.Error(t, addFilesToParameters(fnInfo, map[string]any{}, []any{"f1"}))
+}
@@ -97,6 +97,7 @@ func generateSchema(ctx context.Context, md *metadata.Metadata) (*gql.Schema, *d
 		WasmHost:          wasmhost.GetWasmHost(ctx),
 		FieldsToFunctions: generated.FieldsToFunctions,
 		MapTypes:          generated.MapTypes,
+		ConnectionTypes:   generated.ConnectionTypes,
 	}
 
 	return schema, cfg, nil
@@ -126,6 +127,7 @@ func getDatasourceConfig(ctx context.Context, schema *gql.Schema, cfg *datasourc
 	childNodes := []plan.TypeField{}
 	childNodes = append(childNodes, getChildNodes(queryFieldNames, schema, queryTypeName)...)
 	childNodes = append(childNodes, getChildNodes(mutationFieldNames, schema, mutationTypeName)...)
+	childNodes = append(childNodes, getPossibleTypeChildNodes(ctx, schema, childNodes)...)
 
 	return plan.NewDataSourceConfiguration(
 		datasource.DataSourceName,
@@ -153,6 +155,42 @@ func getChildNodes(fieldNames []string, schema *gql.Schema, typeName string) []p
 	return childNodes
 }
 
+// getPossibleTypeChildNodes returns the child nodes for the possible types of interfaces and unions,
+// which are not otherwise reachable by walking the fields of the root types.
+func getPossibleTypeChildNodes(ctx context.Context, schema *gql.Schema, existing []plan.TypeField) []plan.TypeField {
+	doc := schema.Document()
+
+	possibleTypes := make([]string, 0)
+	for _, unionType := range doc.UnionTypeDefinitions {
+		for _, ref := range unionType.UnionMemberTypes.Refs {
+			possibleTypes = append(possibleTypes, doc.TypeNameString(ref))
+		}
+	}
+	for _, objectType := range doc.ObjectTypeDefinitions {
+		if len(objectType.ImplementsInterfaces.Refs) > 0 {
+			possibleTypes = append(possibleTypes, doc.Input.ByteSliceString(objectType.Name))
+		}
+	}
+
+	var foundTypes = make(map[string]bool, len(existing))
+	for _, node := range existing {
+		foundTypes[node.TypeName] = true
+	}
+
+	var childNodes []plan.TypeField
+	for _, typeName := range possibleTypes {
+		fieldNames := getTypeFields(ctx, schema, typeName)
+		nodes := append([]plan.TypeField{{TypeName: typeName, FieldNames: fieldNames}}, getChildNodes(fieldNames, schema, typeName)...)
+		for _, node := range nodes {
+			if !foundTypes[node.TypeName] {
+				foundTypes[node.TypeName] = true
+				childNodes = append(childNodes, node)
+			}
+		}
+	}
+	return childNodes
+}
+
 func makeEngine(ctx context.Context, schema *gql.Schema, datasourceConfig plan.DataSourceConfiguration[datasource.HypDSConfig]) (*engine.ExecutionEngine, error) {
 	span, ctx := utils.NewSentrySpanForCurrentFunc(ctx)
 	defer span.Finish()
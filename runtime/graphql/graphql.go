@@ -11,12 +11,15 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/graphql/datasource"
 	"github.com/hypermodeinc/modus/runtime/graphql/engine"
 	"github.com/hypermodeinc/modus/runtime/logger"
 	"github.com/hypermodeinc/modus/runtime/manifestdata"
@@ -68,13 +71,28 @@ func handleGraphQLRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
+	start := time.Now()
 
 	// Read the incoming GraphQL request
 	var gqlRequest gql.Request
-	if err := gql.UnmarshalHttpRequest(r, &gqlRequest); err != nil {
-		// TODO: we should capture metrics here
+	var err error
+	if isMultipartRequest(r) {
+		var uploads datasource.Uploads
+		uploads, err = unmarshalMultipartRequest(w, r, &gqlRequest)
+		ctx = context.WithValue(ctx, utils.UploadsContextKey, uploads)
+	} else {
+		err = gql.UnmarshalHttpRequest(r, &gqlRequest)
+	}
+	if err != nil {
+		recordOperationMetrics(&gqlRequest, outcomeParseError, start)
 		msg := "Failed to parse GraphQL request."
-		http.Error(w, msg, http.StatusBadRequest)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			msg = fmt.Sprintf("The request exceeds the maximum upload size of %d bytes.", config.MaxUploadSize)
+			http.Error(w, msg, http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, msg, http.StatusBadRequest)
+		}
 
 		// NOTE: We only log these in dev, to avoid a bad actor spamming the logs in prod.
 		if config.IsDevEnvironment() {
@@ -117,6 +135,7 @@ func handleGraphQLRequest(w http.ResponseWriter, r *http.Request) {
 		if report, ok := err.(operationreport.Report); ok {
 			if len(report.InternalErrors) > 0 {
 				// Log internal errors, but don't return them to the client
+				recordOperationMetrics(&gqlRequest, outcomeInternalError, start)
 				msg := "Failed to execute GraphQL operation."
 				logger.Err(ctx, err).Msg(msg)
 				http.Error(w, msg, http.StatusInternalServerError)
@@ -125,7 +144,11 @@ func handleGraphQLRequest(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if requestErrors := graphqlerrors.RequestErrorsFromError(err); len(requestErrors) > 0 {
-			// TODO: we should capture metrics here
+			if _, err := gqlRequest.OperationType(); err != nil {
+				recordOperationMetrics(&gqlRequest, outcomeParseError, start)
+			} else {
+				recordOperationMetrics(&gqlRequest, outcomeValidationError, start)
+			}
 			utils.WriteJsonContentHeader(w)
 			_, _ = requestErrors.WriteResponse(w)
 
@@ -137,6 +160,7 @@ func handleGraphQLRequest(w http.ResponseWriter, r *http.Request) {
 				logger.Warn(ctx).Str("error", errMsg).Msg("Failed to execute GraphQL operation.")
 			}
 		} else {
+			recordOperationMetrics(&gqlRequest, outcomeInternalError, start)
 			msg := "Failed to execute GraphQL operation."
 			logger.Err(ctx, err).Msg(msg)
 			http.Error(w, fmt.Sprintf("%s\n%v", msg, err), http.StatusInternalServerError)
@@ -145,10 +169,18 @@ func handleGraphQLRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if response, err := addOutputToResponse(resultWriter.Bytes(), output); err != nil {
+		recordOperationMetrics(&gqlRequest, outcomeInternalError, start)
 		msg := "Failed to add function output to response."
 		logger.Err(ctx, err).Msg(msg)
 		http.Error(w, fmt.Sprintf("%s\n%v", msg, err), http.StatusInternalServerError)
 	} else {
+		// Errors in the response are reported by the functions that were called.
+		if gjson.GetBytes(response, "errors").Exists() {
+			recordOperationMetrics(&gqlRequest, outcomeFunctionError, start)
+		} else {
+			recordOperationMetrics(&gqlRequest, outcomeSuccess, start)
+		}
+
 		utils.WriteJsonContentHeader(w)
 
 		// An introspection query will always return a Query type, but if only mutations were defined,
@@ -180,44 +212,68 @@ func addOutputToResponse(response []byte, output map[string]wasmhost.ExecutionIn
 	var invocations []byte
 
 	for key, item := range output {
-
-		if b, err := sjson.SetBytesOptions(invocations, key+".executionId", item.ExecutionId(), jsonOptions); err != nil {
+		if b, err := addExecutionInfo(invocations, key, item, jsonOptions); err != nil {
 			return nil, err
 		} else {
 			invocations = b
 		}
+	}
+
+	if len(invocations) > 0 {
+		return sjson.SetRawBytesOptions(response, "extensions.invocations", invocations, jsonOptions)
+	}
+
+	return response, nil
+}
+
+func addExecutionInfo(invocations []byte, key string, item wasmhost.ExecutionInfo, jsonOptions *sjson.Options) ([]byte, error) {
 
-		logMessages := utils.TransformConsoleOutput(item.Buffers())
-		if len(logMessages) == 0 {
-			continue
+	if b, err := sjson.SetBytesOptions(invocations, key+".executionId", item.ExecutionId(), jsonOptions); err != nil {
+		return nil, err
+	} else {
+		invocations = b
+	}
+
+	if parentId := item.ParentExecutionId(); parentId != "" {
+		if b, err := sjson.SetBytesOptions(invocations, key+".parentExecutionId", parentId, jsonOptions); err != nil {
+			return nil, err
+		} else {
+			invocations = b
 		}
+	}
 
-		i := 0
-		for _, logMessage := range logMessages {
-			// Only include non-error messages here.
-			// Error messages are already included in the response as GraphQL errors.
-			if !logMessage.IsError() {
-				path := key + ".logs." + strconv.Itoa(i)
-				if len(logMessage.Level) > 0 {
-					if b, err := sjson.SetBytesOptions(invocations, path+".level", logMessage.Level, jsonOptions); err != nil {
-						return nil, err
-					} else {
-						invocations = b
-					}
-				}
-				if b, err := sjson.SetBytesOptions(invocations, path+".message", logMessage.Message, jsonOptions); err != nil {
+	logMessages := utils.TransformConsoleOutput(item.Buffers())
+
+	i := 0
+	for _, logMessage := range logMessages {
+		// Only include non-error messages here.
+		// Error messages are already included in the response as GraphQL errors.
+		if !logMessage.IsError() {
+			path := key + ".logs." + strconv.Itoa(i)
+			if len(logMessage.Level) > 0 {
+				if b, err := sjson.SetBytesOptions(invocations, path+".level", logMessage.Level, jsonOptions); err != nil {
 					return nil, err
 				} else {
 					invocations = b
 				}
-				i++
 			}
+			if b, err := sjson.SetBytesOptions(invocations, path+".message", logMessage.Message, jsonOptions); err != nil {
+				return nil, err
+			} else {
+				invocations = b
+			}
+			i++
 		}
 	}
 
-	if len(invocations) > 0 {
-		return sjson.SetRawBytesOptions(response, "extensions.invocations", invocations, jsonOptions)
+	// Include the executions of any functions that were called by this function.
+	for j, child := range item.Children() {
+		if b, err := addExecutionInfo(invocations, key+".calls."+strconv.Itoa(j), child, jsonOptions); err != nil {
+			return nil, err
+		} else {
+			invocations = b
+		}
 	}
 
-	return response, nil
+	return invocations, nil
 }
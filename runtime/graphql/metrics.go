@@ -0,0 +1,95 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graphql
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+
+	gql "github.com/wundergraph/graphql-go-tools/execution/graphql"
+	"github.com/wundergraph/graphql-go-tools/v2/pkg/ast"
+)
+
+// The outcomes of a GraphQL operation, as reported in metrics.
+const (
+	outcomeSuccess         = "success"
+	outcomeParseError      = "parse_error"
+	outcomeValidationError = "validation_error"
+	outcomeFunctionError   = "function_error"
+	outcomeInternalError   = "internal_error"
+)
+
+// otherOperationName is reported in place of the names of operations that are not in the manifest.
+const otherOperationName = "other"
+
+func recordOperationMetrics(request *gql.Request, outcome string, start time.Time) {
+	opName, opType := getOperationLabels(request, outcome)
+	metrics.GraphQLOperationsNum.WithLabelValues(opName, opType, outcome).Inc()
+	metrics.GraphQLOperationDurationSeconds.WithLabelValues(opName, opType, outcome).Observe(time.Since(start).Seconds())
+}
+
+// getOperationLabels returns the operation name and type that are reported in metrics for the request.
+func getOperationLabels(request *gql.Request, outcome string) (opName string, opType string) {
+	if outcome != outcomeParseError && outcome != outcomeValidationError {
+		opName, opType = getOperationInfo(request)
+	}
+
+	// The operation name is provided by the client, so it is only reported if it is listed in the manifest.
+	// Otherwise, a bad actor could flood the metrics with arbitrary names.
+	if opName != "" && !isKnownOperationName(opName) {
+		opName = otherOperationName
+	}
+
+	return opName, opType
+}
+
+// isKnownOperationName reports whether the operation name is listed by any GraphQL endpoint in the manifest.
+func isKnownOperationName(name string) bool {
+	for _, ep := range manifestdata.GetManifest().Endpoints {
+		if info, ok := ep.(manifest.GraphqlEndpointInfo); ok && slices.Contains(info.OperationNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func getOperationInfo(request *gql.Request) (name string, opType string) {
+	t, err := request.OperationType()
+	if err != nil {
+		return "", ""
+	}
+
+	switch t {
+	case gql.OperationTypeQuery:
+		opType = "query"
+	case gql.OperationTypeMutation:
+		opType = "mutation"
+	case gql.OperationTypeSubscription:
+		opType = "subscription"
+	}
+
+	if request.OperationName != "" {
+		return request.OperationName, opType
+	}
+
+	// Use the name of the operation in the document, if it has one.
+	doc := request.Document()
+	for _, node := range doc.RootNodes {
+		if node.Kind == ast.NodeKindOperationDefinition {
+			return doc.OperationDefinitionNameString(node.Ref), opType
+		}
+	}
+
+	return "", opType
+}
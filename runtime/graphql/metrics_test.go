@@ -0,0 +1,54 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+
+	"github.com/stretchr/testify/assert"
+	gql "github.com/wundergraph/graphql-go-tools/execution/graphql"
+)
+
+func TestGetOperationLabels(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Endpoints: map[string]manifest.EndpointInfo{
+			"default": manifest.GraphqlEndpointInfo{
+				Name:           "default",
+				Type:           manifest.EndpointTypeGraphQL,
+				Path:           "/graphql",
+				OperationNames: []string{"GetProducts"},
+			},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	request := &gql.Request{Query: "query GetProducts { products { id } }"}
+	name, opType := getOperationLabels(request, outcomeSuccess)
+	assert.Equal(t, "GetProducts", name)
+	assert.Equal(t, "query", opType)
+
+	// Names chosen by the client that aren't in the manifest are not reported.
+	request = &gql.Request{Query: "mutation Random1234 { placeOrder(id: 1) }", OperationName: "Random1234"}
+	name, opType = getOperationLabels(request, outcomeFunctionError)
+	assert.Equal(t, otherOperationName, name)
+	assert.Equal(t, "mutation", opType)
+
+	request = &gql.Request{Query: "{ products { id } }"}
+	name, _ = getOperationLabels(request, outcomeSuccess)
+	assert.Equal(t, "", name)
+
+	request = &gql.Request{Query: "query GetProducts { products { id } }"}
+	name, opType = getOperationLabels(request, outcomeValidationError)
+	assert.Equal(t, "", name)
+	assert.Equal(t, "", opType)
+}
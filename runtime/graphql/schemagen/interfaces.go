@@ -0,0 +1,39 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package schemagen
+
+import (
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+)
+
+// goInterfaceTypeInfo treats the abstract types of a Go app as nullable, because they are interfaces,
+// and a nil interface (or an interface holding a nil pointer) is read as null.
+type goInterfaceTypeInfo struct {
+	langsupport.LanguageTypeInfo
+	interfaces map[string]bool
+}
+
+func newGoInterfaceTypeInfo(lti langsupport.LanguageTypeInfo, types metadata.TypeMap) langsupport.LanguageTypeInfo {
+	interfaces := make(map[string]bool)
+	for _, t := range types {
+		if t.IsAbstract() {
+			interfaces[t.Name] = true
+		}
+	}
+	if len(interfaces) == 0 {
+		return lti
+	}
+	return &goInterfaceTypeInfo{lti, interfaces}
+}
+
+func (lti *goInterfaceTypeInfo) IsNullableType(typ string) bool {
+	return lti.interfaces[typ] || lti.LanguageTypeInfo.IsNullableType(typ)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package schemagen
+
+import (
+	"slices"
+
+	"github.com/hypermodeinc/modus/runtime/jobs"
+)
+
+const jobTypeName = "Job"
+
+// jobsEnabled reports whether the fields for jobs should be included.  It can be replaced in tests.
+var jobsEnabled = jobs.IsEnabled
+
+// addJobFields adds the fields for working with background jobs, which are provided by the runtime rather than by functions.
+// They are omitted if any of their names are already used by the app.
+func addJobFields(root *RootObjects, resultTypeDefs map[string]*TypeDefinition) {
+	if _, found := resultTypeDefs[jobTypeName]; found {
+		return
+	}
+	if slices.ContainsFunc(root.AllFields(), func(f *FieldDefinition) bool { return f.Name == "job" || f.Name == "enqueueJob" }) {
+		return
+	}
+
+	timestamp := newScalar("Timestamp", resultTypeDefs)
+	jobType := newType(jobTypeName, []*FieldDefinition{
+		{Name: "id", Type: "ID!"},
+		{Name: "function", Type: "String!"},
+		{Name: "priority", Type: "Int!"},
+		{Name: "status", Type: "String!"},
+		{Name: "attempts", Type: "Int!"},
+		{Name: "maxAttempts", Type: "Int!"},
+		{Name: "result", Type: "String", DocLines: []string{"The result of the function, as JSON."}},
+		{Name: "error", Type: "String"},
+		{Name: "executionId", Type: "String"},
+		{Name: "createdAt", Type: timestamp + "!"},
+		{Name: "updatedAt", Type: timestamp + "!"},
+		{Name: "runAt", Type: timestamp + "!"},
+		{Name: "finishedAt", Type: timestamp},
+	}, resultTypeDefs)
+
+	root.QueryFields = append(root.QueryFields, &FieldDefinition{
+		Name:      "job",
+		Type:      jobType,
+		Arguments: []*ArgumentDefinition{{Name: "id", Type: "ID!"}},
+		Function:  jobs.GetJobFunction,
+		DocLines:  []string{"Gets the status and result of a background job."},
+	})
+
+	root.MutationFields = append(root.MutationFields, &FieldDefinition{
+		Name: "enqueueJob",
+		Type: jobType + "!",
+		Arguments: []*ArgumentDefinition{
+			{Name: "function", Type: "String!"},
+			{Name: "args", Type: "String"},
+			{Name: "priority", Type: "Int"},
+			{Name: "maxAttempts", Type: "Int"},
+		},
+		Function: jobs.EnqueueJobFunction,
+		DocLines: []string{
+			"Enqueues a call to a function, to be run in the background.",
+			"The arguments are given as a JSON array of values, in order.",
+		},
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package schemagen
+
+import (
+	"strings"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+)
+
+// Methods are exported as functions named "<Type>.<method>", which take the receiver as the first parameter.
+// They become fields of the receiver's type, and are only called when those fields are selected.
+// Only methods that opt in are exported: in Go with a //modus:field directive, and in AssemblyScript with a @field decorator.
+func isMethod(fn *metadata.Function) bool {
+	return strings.Contains(fn.Name, ".") && len(fn.Parameters) > 0
+}
+
+func transformMethod(fn *metadata.Function, inputTypeDefs, resultTypeDefs map[string]*TypeDefinition, lti langsupport.LanguageTypeInfo) error {
+	receiverType := lti.GetUnderlyingType(fn.Parameters[0].Type)
+	typeDef, ok := resultTypeDefs[lti.GetNameForType(receiverType)]
+	if !ok || len(typeDef.Fields) == 0 || typeDef.IsMapType || typeDef.IsAbstract() {
+		// methods of other types can't be represented as fields
+		return nil
+	}
+
+	args, err := convertParameters(fn.Parameters[1:], lti, inputTypeDefs)
+	if err != nil {
+		return err
+	}
+
+	returnType, err := convertResults(fn.Results, lti, resultTypeDefs)
+	if err != nil {
+		return err
+	}
+
+	field := &FieldDefinition{
+		Name:      fn.Name[strings.LastIndex(fn.Name, ".")+1:],
+		Arguments: args,
+		Type:      returnType,
+		Function:  fn.Name,
+	}
+
+	if fn.Docs != nil {
+		field.DocLines = fn.Docs.Lines
+	}
+
+	typeDef.Fields = append(typeDef.Fields, field)
+	return nil
+}
+
+// getMethodFields returns the fields that are resolved by calling methods, keyed by "<Type>.<field>".
+func getMethodFields(typeDefs []*TypeDefinition) map[string]*FieldDefinition {
+	results := make(map[string]*FieldDefinition)
+	for _, t := range typeDefs {
+		for _, f := range t.Fields {
+			if f.Function != "" {
+				results[t.Name+"."+f.Name] = f
+			}
+		}
+	}
+	return results
+}
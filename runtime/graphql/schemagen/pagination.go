@@ -0,0 +1,158 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package schemagen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Functions that accept these arguments are paginated, following the Relay connection specification.
+// See https://relay.dev/graphql/connections.htm
+const firstArgName = "first"
+const afterArgName = "after"
+
+// A function can return a page type instead of a list, to control the page info reported to the client.
+// A page type is an object with a list field named "items", and a field named "pageInfo" of the SDK's PageInfo type.
+const pageItemsFieldName = "items"
+const pageInfoFieldName = "pageInfo"
+const pageInfoTypeName = "PageInfo"
+
+// The fields of the PageInfo type of all connections.
+var pageInfoFields = []*FieldDefinition{
+	{Name: "hasNextPage", Type: "Boolean!"},
+	{Name: "hasPreviousPage", Type: "Boolean!"},
+	{Name: "startCursor", Type: "String"},
+	{Name: "endCursor", Type: "String"},
+}
+
+// getConnectionType returns the connection type that should be used in place of the result type,
+// or false if the function is not paginated.
+func getConnectionType(args []*ArgumentDefinition, resultType string, typeDefs map[string]*TypeDefinition) (string, bool, error) {
+	if !hasPaginationArgs(args) {
+		return "", false, nil
+	}
+
+	listType, ok := getPaginatedListType(resultType, typeDefs)
+	if !ok {
+		return "", false, nil
+	}
+
+	// nested lists can't be paginated
+	itemType := listType[1 : len(listType)-1]
+	if strings.HasPrefix(itemType, "[") {
+		return "", false, nil
+	}
+
+	// PageInfo is reserved for use by all connections.  It replaces the SDK's type of the same name,
+	// but a different type of the same name can't be replaced without changing the schema of the app.
+	if t, ok := typeDefs[pageInfoTypeName]; ok && !isPageInfoType(t) {
+		return "", false, fmt.Errorf("the type name %s is reserved for paginated functions, but is used by another type", pageInfoTypeName)
+	}
+
+	// The first page is requested without a cursor.
+	for _, arg := range args {
+		if arg.Name == afterArgName {
+			arg.Type = strings.TrimSuffix(arg.Type, "!")
+		}
+	}
+
+	baseType := getBaseType(itemType)
+	edgeType := newType(baseType+"Edge", []*FieldDefinition{
+		{Name: "cursor", Type: "String!"},
+		{Name: "node", Type: itemType},
+	}, typeDefs)
+
+	typeDefs[pageInfoTypeName] = &TypeDefinition{
+		Name:   pageInfoTypeName,
+		Fields: pageInfoFields,
+	}
+
+	connectionType := baseType + "Connection"
+	if _, ok := typeDefs[connectionType]; !ok {
+		typeDefs[connectionType] = &TypeDefinition{
+			Name: connectionType,
+			Fields: []*FieldDefinition{
+				{Name: "edges", Type: "[" + edgeType + "!]!"},
+				{Name: "nodes", Type: listType + "!"},
+				{Name: "pageInfo", Type: pageInfoTypeName + "!"},
+			},
+			IsConnectionType: true,
+		}
+	}
+
+	return connectionType + "!", true, nil
+}
+
+// isPageInfoType reports whether the type is the SDK's PageInfo type, or the PageInfo type of all connections.
+// Both have the boolean fields of the page info, and no fields other than those of the PageInfo type of all connections.
+func isPageInfoType(t *TypeDefinition) bool {
+	found := 0
+	for _, f := range t.Fields {
+		i := slices.IndexFunc(pageInfoFields, func(pf *FieldDefinition) bool {
+			return pf.Name == f.Name && pf.Type == f.Type
+		})
+		if i < 0 {
+			return false
+		}
+		if i < 2 {
+			found++
+		}
+	}
+	return found == 2
+}
+
+func hasPaginationArgs(args []*ArgumentDefinition) bool {
+	var hasFirst, hasAfter bool
+	for _, arg := range args {
+		switch arg.Name {
+		case firstArgName:
+			switch strings.TrimSuffix(arg.Type, "!") {
+			case "Int", "UInt", "Int64", "UInt64":
+				hasFirst = true
+			}
+		case afterArgName:
+			hasAfter = strings.TrimSuffix(arg.Type, "!") == "String"
+		}
+	}
+	return hasFirst && hasAfter
+}
+
+// getPaginatedListType returns the list type that is being paginated, without any non-null modifier.
+// The result type must be a list, or a page type that contains a list.
+func getPaginatedListType(resultType string, typeDefs map[string]*TypeDefinition) (string, bool) {
+	t := strings.TrimSuffix(resultType, "!")
+	if strings.HasPrefix(t, "[") {
+		return t, true
+	}
+
+	typeDef, ok := typeDefs[t]
+	if !ok || len(typeDef.Fields) != 2 {
+		return "", false
+	}
+
+	var listType string
+	var hasPageInfo bool
+	for _, f := range typeDef.Fields {
+		switch f.Name {
+		case pageItemsFieldName:
+			listType = strings.TrimSuffix(f.Type, "!")
+		case pageInfoFieldName:
+			hasPageInfo = getBaseType(f.Type) == pageInfoTypeName
+		}
+	}
+
+	if !hasPageInfo || !strings.HasPrefix(listType, "[") {
+		return "", false
+	}
+
+	return listType, true
+}
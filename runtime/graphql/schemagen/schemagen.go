@@ -28,6 +28,7 @@ type GraphQLSchema struct {
 	Schema            string
 	FieldsToFunctions map[string]string
 	MapTypes          []string
+	ConnectionTypes   []string
 }
 
 func GetGraphQLSchema(ctx context.Context, md *metadata.Metadata) (*GraphQLSchema, error) {
@@ -40,6 +41,10 @@ func GetGraphQLSchema(ctx context.Context, md *metadata.Metadata) (*GraphQLSchem
 	}
 
 	lti := lang.TypeInfo()
+	if lang == languages.GoLang() {
+		lti = newGoInterfaceTypeInfo(lti, md.Types)
+	}
+
 	inputTypeDefs, errors := transformTypes(md.Types, lti, true)
 	resultTypeDefs, errs := transformTypes(md.Types, lti, false)
 	errors = append(errors, errs...)
@@ -50,10 +55,15 @@ func GetGraphQLSchema(ctx context.Context, md *metadata.Metadata) (*GraphQLSchem
 		return nil, fmt.Errorf("failed to generate schema: %+v", errors)
 	}
 
+	if jobsEnabled() {
+		addJobFields(root, resultTypeDefs)
+	}
+
 	allFields := root.AllFields()
 	scalarTypes := extractCustomScalarTypes(inputTypeDefs, resultTypeDefs)
-	inputTypes := filterTypes(utils.MapValues(inputTypeDefs), allFields, true)
 	resultTypes := filterTypes(utils.MapValues(resultTypeDefs), allFields, false)
+	methodFields := getMethodFields(resultTypes)
+	inputTypes := filterTypes(utils.MapValues(inputTypeDefs), slices.Concat(allFields, utils.MapValues(methodFields)), true)
 
 	buf := bytes.Buffer{}
 	writeSchema(&buf, root, scalarTypes, inputTypes, resultTypes)
@@ -65,15 +75,26 @@ func GetGraphQLSchema(ctx context.Context, md *metadata.Metadata) (*GraphQLSchem
 		}
 	}
 
+	connectionTypes := make([]string, 0)
+	for _, t := range resultTypeDefs {
+		if t.IsConnectionType {
+			connectionTypes = append(connectionTypes, t.Name)
+		}
+	}
+
 	fieldsToFunctions := make(map[string]string, len(allFields))
 	for _, f := range allFields {
 		fieldsToFunctions[f.Name] = f.Function
 	}
+	for name, f := range methodFields {
+		fieldsToFunctions[name] = f.Function
+	}
 
 	return &GraphQLSchema{
 		Schema:            buf.String(),
 		FieldsToFunctions: fieldsToFunctions,
 		MapTypes:          mapTypes,
+		ConnectionTypes:   connectionTypes,
 	}, nil
 }
 
@@ -97,6 +118,16 @@ func transformTypes(types metadata.TypeMap, lti langsupport.LanguageTypeInfo, fo
 			continue
 		}
 
+		// abstract types can only be used for results
+		if forInput && t.IsAbstract() {
+			continue
+		}
+
+		// file types are represented by the Upload scalar for input
+		if forInput && lti.IsFileType(t.Name) {
+			continue
+		}
+
 		name := lti.GetNameForType(t.Name)
 		if forInput {
 			if len(t.Fields) > 0 && !strings.HasSuffix(name, "Input") {
@@ -127,8 +158,29 @@ func transformTypes(types metadata.TypeMap, lti langsupport.LanguageTypeInfo, fo
 			typeDef.DocLines = t.Docs.Lines
 		}
 
+		if t.IsAbstract() {
+			typeDef.PossibleTypes = make([]string, len(t.Variants))
+			for i, v := range t.Variants {
+				typeDef.PossibleTypes[i] = lti.GetNameForType(lti.GetUnderlyingType(v))
+			}
+		}
+
 		typeDefs[name] = typeDef
 	}
+
+	// Abstract types that have fields are GraphQL interfaces, which their possible types must implement.
+	// Abstract types without fields are GraphQL unions, which have no such requirement.
+	for _, t := range typeDefs {
+		if len(t.PossibleTypes) == 0 || len(t.Fields) == 0 {
+			continue
+		}
+		for _, name := range t.PossibleTypes {
+			if pt, ok := typeDefs[name]; ok {
+				pt.Interfaces = append(pt.Interfaces, t.Name)
+			}
+		}
+	}
+
 	return typeDefs, errors
 }
 
@@ -141,10 +193,17 @@ type FieldDefinition struct {
 }
 
 type TypeDefinition struct {
-	Name      string
-	Fields    []*FieldDefinition
-	IsMapType bool
-	DocLines  []string
+	Name             string
+	Fields           []*FieldDefinition
+	IsMapType        bool
+	IsConnectionType bool
+	DocLines         []string
+	PossibleTypes    []string
+	Interfaces       []string
+}
+
+func (t *TypeDefinition) IsAbstract() bool {
+	return len(t.PossibleTypes) > 0
 }
 
 type ArgumentDefinition struct {
@@ -173,6 +232,13 @@ func transformFunctions(functions metadata.FunctionMap, inputTypeDefs, resultTyp
 	for _, name := range fnNames {
 		fn := functions[name]
 
+		if isMethod(fn) {
+			if err := transformMethod(fn, inputTypeDefs, resultTypeDefs, lti); err != nil {
+				errors = append(errors, &TransformError{fn, err})
+			}
+			continue
+		}
+
 		args, err := convertParameters(fn.Parameters, lti, inputTypeDefs)
 		if err != nil {
 			errors = append(errors, &TransformError{fn, err})
@@ -185,6 +251,14 @@ func transformFunctions(functions metadata.FunctionMap, inputTypeDefs, resultTyp
 			continue
 		}
 
+		// Paginated functions return a connection type, which wraps the original result.
+		if t, ok, err := getConnectionType(args, returnType, resultTypeDefs); err != nil {
+			errors = append(errors, &TransformError{fn, err})
+			continue
+		} else if ok {
+			returnType = t
+		}
+
 		fieldName := getFieldName(fn.Name)
 
 		field := &FieldDefinition{
@@ -259,7 +333,7 @@ func extractCustomScalarTypes(inputTypeDefs, resultTypeDefs map[string]*TypeDefi
 		}
 	}
 	for _, t := range resultTypeDefs {
-		if len(t.Fields) == 0 {
+		if len(t.Fields) == 0 && !t.IsAbstract() {
 			scalarTypes[t.Name] = true
 			delete(resultTypeDefs, t.Name)
 		}
@@ -278,6 +352,9 @@ func addUsedTypes(name string, types map[string]*TypeDefinition, usedTypes map[s
 		for _, f := range t.Fields {
 			addUsedTypes(f.Type, types, usedTypes)
 		}
+		for _, pt := range t.PossibleTypes {
+			addUsedTypes(pt, types, usedTypes)
+		}
 	}
 }
 
@@ -391,26 +468,29 @@ func writeSchema(buf *bytes.Buffer, root *RootObjects, scalarTypes []string, inp
 			buf.WriteString("\"\"\"\n")
 		}
 
-		buf.WriteString("type ")
+		if t.IsAbstract() && len(t.Fields) == 0 {
+			buf.WriteString("union ")
+			buf.WriteString(t.Name)
+			buf.WriteString(" = ")
+			buf.WriteString(strings.Join(t.PossibleTypes, " | "))
+			buf.WriteByte('\n')
+			continue
+		}
+
+		if t.IsAbstract() {
+			buf.WriteString("interface ")
+		} else {
+			buf.WriteString("type ")
+		}
 		buf.WriteString(t.Name)
+		if len(t.Interfaces) > 0 {
+			slices.Sort(t.Interfaces)
+			buf.WriteString(" implements ")
+			buf.WriteString(strings.Join(t.Interfaces, " & "))
+		}
 		buf.WriteString(" {\n")
 		for _, f := range t.Fields {
-
-			if len(f.DocLines) > 0 {
-				buf.WriteString("  \"\"\"\n")
-				for _, line := range f.DocLines {
-					buf.WriteString("  ")
-					buf.WriteString(line)
-					buf.WriteByte('\n')
-				}
-				buf.WriteString("  \"\"\"\n")
-			}
-
-			buf.WriteString("  ")
-			buf.WriteString(f.Name)
-			buf.WriteString(": ")
-			buf.WriteString(f.Type)
-			buf.WriteByte('\n')
+			writeField(buf, f)
 		}
 		buf.WriteString("}\n")
 	}
@@ -510,7 +590,7 @@ func convertResults(results []*metadata.Result, lti langsupport.LanguageTypeInfo
 func getTypeForFields(fields []*FieldDefinition, typeDefs map[string]*TypeDefinition) string {
 	// see if an existing type already matches
 	for _, t := range typeDefs {
-		if len(t.Fields) != len(fields) {
+		if len(t.Fields) != len(fields) || t.IsAbstract() {
 			continue
 		}
 
@@ -639,6 +719,12 @@ func convertType(typ string, lti langsupport.LanguageTypeInfo, typeDefs map[stri
 		return newScalar("Timestamp", typeDefs) + n, nil
 	}
 
+	// files are uploaded using the GraphQL multipart request spec
+	// see https://github.com/jaydenseric/graphql-multipart-request-spec
+	if forInput && lti.IsFileType(typ) {
+		return newScalar("Upload", typeDefs) + n, nil
+	}
+
 	// check for array types
 	if lti.IsListType(typ) {
 		elem := lti.GetListSubtype(typ)
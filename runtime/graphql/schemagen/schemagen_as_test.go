@@ -343,6 +343,140 @@ type StringStringPair {
 	require.Equal(t, expectedSchema, result.Schema)
 }
 
+func Test_GetGraphQLSchema_AssemblyScript_AbstractClasses(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-as"
+
+	md.FnExports.AddFunction("getAnimal").
+		WithParameter("name", "~lib/string/String").
+		WithResult("assembly/test/Animal")
+
+	md.FnExports.AddFunction("getPet").
+		WithResult("assembly/test/Pet | null")
+
+	md.Types.AddType("assembly/test/Animal").
+		WithField("name", "~lib/string/String").
+		WithVariant("assembly/test/Cat").
+		WithVariant("assembly/test/Dog")
+
+	md.Types.AddType("assembly/test/Pet").
+		WithVariant("assembly/test/Dog")
+
+	md.Types.AddType("assembly/test/Pet | null")
+
+	md.Types.AddType("assembly/test/Cat").
+		WithField("name", "~lib/string/String").
+		WithField("lives", "i32")
+
+	md.Types.AddType("assembly/test/Dog").
+		WithField("name", "~lib/string/String").
+		WithField("breed", "~lib/string/String")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  animal(name: String!): Animal!
+  pet: Pet
+}
+
+interface Animal {
+  name: String!
+}
+
+type Cat implements Animal {
+  name: String!
+  lives: Int!
+}
+
+type Dog implements Animal {
+  name: String!
+  breed: String!
+}
+
+union Pet = Dog
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+}
+
+func Test_GetGraphQLSchema_AssemblyScript_Methods(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-as"
+
+	md.FnExports.AddFunction("getProduct").
+		WithParameter("id", "~lib/string/String").
+		WithResult("assembly/test/Product | null")
+
+	md.FnExports.AddFunction("Product.reviews").
+		WithParameter("self", "assembly/test/Product").
+		WithParameter("limit", "i32", 10).
+		WithResult("~lib/array/Array<assembly/test/Review>")
+
+	md.FnExports.AddFunction("Product.rating").
+		WithParameter("self", "assembly/test/Product").
+		WithResult("f64")
+
+	md.FnExports.AddFunction("Unused.value").
+		WithParameter("self", "assembly/test/Unused").
+		WithResult("~lib/string/String")
+
+	md.Types.AddType("assembly/test/Product | null")
+	md.Types.AddType("~lib/array/Array<assembly/test/Review>")
+
+	md.Types.AddType("assembly/test/Product").
+		WithField("id", "~lib/string/String").
+		WithField("name", "~lib/string/String")
+
+	md.Types.AddType("assembly/test/Review").
+		WithField("stars", "i32").
+		WithField("comment", "~lib/string/String")
+
+	md.Types.AddType("assembly/test/Unused").
+		WithField("value", "~lib/string/String")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  product(id: String!): Product
+}
+
+type Product {
+  id: String!
+  name: String!
+  rating: Float!
+  reviews(limit: Int! = 10): [Review!]!
+}
+
+type Review {
+  stars: Int!
+  comment: String!
+}
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+	require.Equal(t, "Product.rating", result.FieldsToFunctions["Product.rating"])
+	require.Equal(t, "Product.reviews", result.FieldsToFunctions["Product.reviews"])
+	require.NotContains(t, result.FieldsToFunctions, "Unused.value")
+}
+
 func Test_ConvertType_AssemblyScript(t *testing.T) {
 
 	lti := languages.AssemblyScript().TypeInfo()
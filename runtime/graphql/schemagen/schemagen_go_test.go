@@ -16,6 +16,7 @@ import (
 
 	"github.com/hypermodeinc/modus/lib/manifest"
 	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/jobs"
 	"github.com/hypermodeinc/modus/runtime/languages"
 	"github.com/hypermodeinc/modus/runtime/manifestdata"
 	"github.com/hypermodeinc/modus/runtime/utils"
@@ -385,6 +386,326 @@ type StringStringPair {
 	require.Equal(t, expectedSchema, result.Schema)
 }
 
+func Test_GetGraphQLSchema_Go_Interfaces(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-go"
+
+	md.FnExports.AddFunction("getShape").
+		WithParameter("name", "string").
+		WithResult("testdata.Shape")
+
+	md.FnExports.AddFunction("getShapes").
+		WithResult("[]testdata.Shape")
+
+	md.Types.AddType("[]testdata.Shape")
+
+	md.Types.AddType("testdata.Shape").
+		WithVariant("testdata.Circle").
+		WithVariant("*testdata.Square").
+		WithDocs(metadata.Docs{
+			Lines: []string{"A shape is either a circle or a square."},
+		})
+
+	md.Types.AddType("testdata.Circle").
+		WithField("radius", "float64")
+
+	md.Types.AddType("*testdata.Square")
+
+	md.Types.AddType("testdata.Square").
+		WithField("side", "float64")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  shape(name: String!): Shape
+  shapes: [Shape]
+}
+
+type Circle {
+  radius: Float!
+}
+
+"""
+A shape is either a circle or a square.
+"""
+union Shape = Circle | Square
+
+type Square {
+  side: Float!
+}
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+}
+
+func Test_GetGraphQLSchema_Go_Files(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-go"
+
+	md.FnExports.AddFunction("addDocument").
+		WithParameter("collection", "string").
+		WithParameter("file", "github.com/hypermodeinc/modus/sdk/go/pkg/files.File").
+		WithResult("string")
+
+	md.FnExports.AddFunction("addImages").
+		WithParameter("files", "[]*github.com/hypermodeinc/modus/sdk/go/pkg/files.File").
+		WithResult("int")
+
+	md.FnExports.AddFunction("getLastFile").
+		WithResult("*github.com/hypermodeinc/modus/sdk/go/pkg/files.File")
+
+	md.Types.AddType("[]*github.com/hypermodeinc/modus/sdk/go/pkg/files.File")
+	md.Types.AddType("*github.com/hypermodeinc/modus/sdk/go/pkg/files.File")
+	md.Types.AddType("github.com/hypermodeinc/modus/sdk/go/pkg/files.File").
+		WithField("name", "string").
+		WithField("contentType", "string").
+		WithField("data", "[]byte")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  lastFile: File
+}
+
+type Mutation {
+  addDocument(collection: String!, file: Upload!): String!
+  addImages(files: [Upload]): Int!
+}
+
+scalar Upload
+
+type File {
+  name: String!
+  contentType: String!
+  data: String
+}
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+}
+
+func Test_GetGraphQLSchema_Go_Pagination(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-go"
+
+	md.FnExports.AddFunction("getProducts").
+		WithParameter("first", "int").
+		WithParameter("after", "string").
+		WithResult("[]testdata.Product")
+
+	md.FnExports.AddFunction("getProductPage").
+		WithParameter("category", "string").
+		WithParameter("first", "int").
+		WithParameter("after", "string").
+		WithResult("testdata.ProductPage")
+
+	md.FnExports.AddFunction("getTags").
+		WithParameter("first", "int").
+		WithParameter("after", "string").
+		WithResult("[]string")
+
+	md.FnExports.AddFunction("getAllProducts").
+		WithResult("[]testdata.Product")
+
+	md.Types.AddType("[]testdata.Product")
+	md.Types.AddType("[]string")
+
+	md.Types.AddType("testdata.Product").
+		WithField("id", "string").
+		WithField("name", "string")
+
+	md.Types.AddType("testdata.ProductPage").
+		WithField("items", "[]testdata.Product").
+		WithField("pageInfo", "github.com/hypermodeinc/modus/sdk/go/pkg/pagination.PageInfo")
+
+	md.Types.AddType("github.com/hypermodeinc/modus/sdk/go/pkg/pagination.PageInfo").
+		WithField("hasNextPage", "bool").
+		WithField("hasPreviousPage", "bool")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  allProducts: [Product!]
+  productPage(category: String!, first: Int!, after: String): ProductConnection!
+  products(first: Int!, after: String): ProductConnection!
+  tags(first: Int!, after: String): StringConnection!
+}
+
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+
+type Product {
+  id: String!
+  name: String!
+}
+
+type ProductConnection {
+  edges: [ProductEdge!]!
+  nodes: [Product!]!
+  pageInfo: PageInfo!
+}
+
+type ProductEdge {
+  cursor: String!
+  node: Product!
+}
+
+type StringConnection {
+  edges: [StringEdge!]!
+  nodes: [String!]!
+  pageInfo: PageInfo!
+}
+
+type StringEdge {
+  cursor: String!
+  node: String!
+}
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+	require.ElementsMatch(t, []string{"ProductConnection", "StringConnection"}, result.ConnectionTypes)
+}
+
+func Test_GetGraphQLSchema_Go_PaginationPageInfoConflict(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-go"
+
+	md.FnExports.AddFunction("getProducts").
+		WithParameter("first", "int").
+		WithParameter("after", "string").
+		WithResult("[]testdata.Product")
+
+	md.FnExports.AddFunction("getPageInfo").
+		WithResult("testdata.PageInfo")
+
+	md.Types.AddType("[]testdata.Product")
+
+	md.Types.AddType("testdata.Product").
+		WithField("id", "string").
+		WithField("name", "string")
+
+	md.Types.AddType("testdata.PageInfo").
+		WithField("title", "string").
+		WithField("pageNumber", "int")
+
+	_, err := GetGraphQLSchema(context.Background(), md)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the type name PageInfo is reserved for paginated functions")
+}
+
+func Test_GetGraphQLSchema_Go_Methods(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-go"
+
+	md.FnExports.AddFunction("getProduct").
+		WithParameter("id", "string").
+		WithResult("*testdata.Product")
+
+	md.FnExports.AddFunction("Product.reviews").
+		WithParameter("p", "*testdata.Product").
+		WithParameter("limit", "int").
+		WithParameter("filter", "*testdata.ReviewFilter").
+		WithResult("[]testdata.Review")
+
+	md.FnExports.AddFunction("Product.rating").
+		WithParameter("receiver", "testdata.Product").
+		WithResult("float64")
+
+	md.FnExports.AddFunction("Unused.value").
+		WithParameter("receiver", "testdata.Unused").
+		WithResult("string")
+
+	md.Types.AddType("*testdata.Product")
+	md.Types.AddType("*testdata.ReviewFilter")
+	md.Types.AddType("[]testdata.Review")
+
+	md.Types.AddType("testdata.Product").
+		WithField("id", "string").
+		WithField("name", "string")
+
+	md.Types.AddType("testdata.Review").
+		WithField("stars", "int").
+		WithField("comment", "string")
+
+	md.Types.AddType("testdata.ReviewFilter").
+		WithField("minStars", "int")
+
+	md.Types.AddType("testdata.Unused").
+		WithField("value", "string")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  product(id: String!): Product
+}
+
+input ReviewFilterInput {
+  minStars: Int!
+}
+
+type Product {
+  id: String!
+  name: String!
+  rating: Float!
+  reviews(limit: Int!, filter: ReviewFilterInput): [Review!]
+}
+
+type Review {
+  stars: Int!
+  comment: String!
+}
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+	require.Equal(t, "Product.rating", result.FieldsToFunctions["Product.rating"])
+	require.Equal(t, "Product.reviews", result.FieldsToFunctions["Product.reviews"])
+	require.NotContains(t, result.FieldsToFunctions, "Unused.value")
+}
+
 func Test_ConvertType_Go(t *testing.T) {
 
 	lti := languages.GoLang().TypeInfo()
@@ -600,3 +921,68 @@ func Test_ConvertType_Go(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetGraphQLSchema_Go_Jobs(t *testing.T) {
+
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	jobsEnabled = func() bool { return true }
+	defer func() { jobsEnabled = jobs.IsEnabled }()
+
+	md := metadata.NewPluginMetadata()
+	md.SDK = "modus-sdk-go"
+
+	md.FnExports.AddFunction("sayHello").
+		WithParameter("name", "string").
+		WithResult("string")
+
+	result, err := GetGraphQLSchema(context.Background(), md)
+
+	t.Log(result.Schema)
+
+	expectedSchema := `
+# Modus GraphQL Schema (auto-generated)
+
+type Query {
+  """
+  Gets the status and result of a background job.
+  """
+  job(id: ID!): Job
+  sayHello(name: String!): String!
+}
+
+type Mutation {
+  """
+  Enqueues a call to a function, to be run in the background.
+  The arguments are given as a JSON array of values, in order.
+  """
+  enqueueJob(function: String!, args: String, priority: Int, maxAttempts: Int): Job!
+}
+
+scalar Timestamp
+
+type Job {
+  id: ID!
+  function: String!
+  priority: Int!
+  status: String!
+  attempts: Int!
+  maxAttempts: Int!
+  """
+  The result of the function, as JSON.
+  """
+  result: String
+  error: String
+  executionId: String
+  createdAt: Timestamp!
+  updatedAt: Timestamp!
+  runAt: Timestamp!
+  finishedAt: Timestamp
+}
+`[1:]
+
+	require.Nil(t, err)
+	require.Equal(t, expectedSchema, result.Schema)
+	require.Equal(t, jobs.GetJobFunction, result.FieldsToFunctions["job"])
+	require.Equal(t, jobs.EnqueueJobFunction, result.FieldsToFunctions["enqueueJob"])
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package graphql
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/graphql/datasource"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/tidwall/sjson"
+	gql "github.com/wundergraph/graphql-go-tools/execution/graphql"
+)
+
+// The maximum amount of multipart data held in memory while parsing.  Anything larger is buffered to temporary files.
+const multipartMaxMemory = 32 << 20
+
+func isMultipartRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// unmarshalMultipartRequest reads a GraphQL request that includes uploaded files.
+// The uploaded files are returned, and the request variables are updated to reference them by placeholder.
+// See https://github.com/jaydenseric/graphql-multipart-request-spec
+func unmarshalMultipartRequest(w http.ResponseWriter, r *http.Request, request *gql.Request) (datasource.Uploads, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, config.MaxUploadSize)
+	if err := r.ParseMultipartForm(multipartMaxMemory); err != nil {
+		return nil, err
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	operations := r.MultipartForm.Value["operations"]
+	if len(operations) != 1 {
+		return nil, errors.New("the multipart request must have exactly one operations field")
+	}
+
+	ops := bytes.TrimSpace([]byte(operations[0]))
+	if len(ops) > 0 && ops[0] == '[' {
+		return nil, errors.New("batched operations are not supported")
+	}
+
+	request.SetHeader(r.Header)
+	if err := gql.UnmarshalRequest(bytes.NewReader(ops), request); err != nil {
+		return nil, err
+	}
+
+	var fileMap map[string][]string
+	if m := r.MultipartForm.Value["map"]; len(m) == 1 {
+		if err := utils.JsonDeserialize([]byte(m[0]), &fileMap); err != nil {
+			return nil, fmt.Errorf("invalid map field: %w", err)
+		}
+	} else {
+		return nil, errors.New("the multipart request must have exactly one map field")
+	}
+
+	uploads := make(datasource.Uploads, len(fileMap))
+	for key, paths := range fileMap {
+		headers := r.MultipartForm.File[key]
+		if len(headers) != 1 {
+			return nil, fmt.Errorf("missing file for map entry %s", key)
+		}
+
+		upload, err := readUpload(headers[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file for map entry %s: %w", key, err)
+		}
+
+		placeholder := uploads.Add(upload)
+		for _, path := range paths {
+			p, ok := strings.CutPrefix(path, "variables.")
+			if !ok {
+				return nil, fmt.Errorf("unsupported path %s for map entry %s", path, key)
+			}
+
+			vars, err := sjson.SetBytes(request.Variables, p, placeholder)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set variable %s: %w", p, err)
+			}
+			request.Variables = vars
+		}
+	}
+
+	return uploads, nil
+}
+
+func readUpload(header *multipart.FileHeader) (*datasource.Upload, error) {
+	f, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	return &datasource.Upload{
+		Name:        header.Filename,
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfunctions
+
+import (
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/crypto"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func init() {
+	const module_name = "modus_crypto"
+
+	registerHostFunction(module_name, "hash", crypto.Hash,
+		withErrorMessage("Error computing hash."),
+		withMessageDetail(func(algorithm, data string) string {
+			return fmt.Sprintf("Algorithm: %s", algorithm)
+		}))
+
+	registerHostFunction(module_name, "hmac", crypto.HMAC,
+		withErrorMessage("Error computing HMAC."),
+		withMessageDetail(func(algorithm, keyName, data string) string {
+			return fmt.Sprintf("Algorithm: %s, Key: %s", algorithm, keyName)
+		}))
+
+	registerHostFunction(module_name, "verifyHmac", CryptoVerifyHMAC,
+		withErrorMessage("Error verifying HMAC."),
+		withMessageDetail(func(algorithm, keyName, data, mac string) string {
+			return fmt.Sprintf("Algorithm: %s, Key: %s", algorithm, keyName)
+		}))
+
+	registerHostFunction(module_name, "encryptAesGcm", crypto.EncryptAESGCM,
+		withErrorMessage("Error encrypting data."),
+		withMessageDetail(func(keyName, plaintext, associatedData string) string {
+			return fmt.Sprintf("Key: %s", keyName)
+		}))
+
+	registerHostFunction(module_name, "decryptAesGcm", crypto.DecryptAESGCM,
+		withErrorMessage("Error decrypting data."),
+		withMessageDetail(func(keyName, ciphertext, associatedData string) string {
+			return fmt.Sprintf("Key: %s", keyName)
+		}))
+
+	registerHostFunction(module_name, "sign", crypto.Sign,
+		withErrorMessage("Error signing data."),
+		withMessageDetail(func(algorithm, keyName, data string) string {
+			return fmt.Sprintf("Algorithm: %s, Key: %s", algorithm, keyName)
+		}))
+
+	registerHostFunction(module_name, "verify", CryptoVerify,
+		withErrorMessage("Error verifying signature."),
+		withMessageDetail(func(algorithm, keyName, data, signature string) string {
+			return fmt.Sprintf("Algorithm: %s, Key: %s", algorithm, keyName)
+		}))
+
+	registerHostFunction(module_name, "createJwt", CryptoCreateJWT,
+		withErrorMessage("Error creating JWT."),
+		withMessageDetail(func(algorithm, keyName, claimsJson string) string {
+			return fmt.Sprintf("Algorithm: %s, Key: %s", algorithm, keyName)
+		}))
+
+	registerHostFunction(module_name, "verifyJwt", CryptoVerifyJWT,
+		withErrorMessage("Error verifying JWT."),
+		withMessageDetail(func(keyName, token string) string {
+			return fmt.Sprintf("Key: %s", keyName)
+		}))
+}
+
+// CryptoVerifyHMAC returns true if the hex-encoded HMAC matches the data, as JSON.
+func CryptoVerifyHMAC(algorithm, keyName, data, mac string) (string, error) {
+	return toJson(crypto.VerifyHMAC(algorithm, keyName, data, mac))
+}
+
+// CryptoVerify returns true if the base64-encoded signature of the data is valid, as JSON.
+func CryptoVerify(algorithm, keyName, data, signature string) (string, error) {
+	return toJson(crypto.Verify(algorithm, keyName, data, signature))
+}
+
+// CryptoCreateJWT returns a signed JWT with the claims given as a JSON object.
+func CryptoCreateJWT(algorithm, keyName, claimsJson string) (string, error) {
+	claims := make(map[string]any)
+	if claimsJson != "" {
+		if err := utils.JsonDeserialize([]byte(claimsJson), &claims); err != nil {
+			return "", fmt.Errorf("failed to deserialize JWT claims: %w", err)
+		}
+	}
+
+	return crypto.CreateJWT(algorithm, keyName, claims)
+}
+
+// CryptoVerifyJWT returns the outcome of verifying a JWT as JSON, including its claims if it is valid.
+func CryptoVerifyJWT(keyName, token string) (string, error) {
+	return toJson(crypto.VerifyJWT(keyName, token))
+}
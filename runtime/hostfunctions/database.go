@@ -10,6 +10,7 @@
 package hostfunctions
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hypermodeinc/modus/runtime/sqlclient"
@@ -26,4 +27,85 @@ func init() {
 		withMessageDetail(func(hostName, statement string) string {
 			return fmt.Sprintf("Host: %s Query: %s", hostName, statement)
 		}))
+
+	registerHostFunction(module_name, "copyRows", sqlclient.CopyRows,
+		withStartingMessage("Starting database copy."),
+		withCompletedMessage("Completed database copy."),
+		withCancelledMessage("Cancelled database copy."),
+		withErrorMessage("Error copying rows to database."),
+		withMessageDetail(func(hostName, tableName string) string {
+			return fmt.Sprintf("Host: %s Table: %s", hostName, tableName)
+		}))
+
+	registerHostFunction(module_name, "beginTransaction", sqlclient.BeginTransaction,
+		withErrorMessage("Error beginning database transaction."),
+		withMessageDetail(func(hostName, dbType string) string {
+			return fmt.Sprintf("Host: %s", hostName)
+		}))
+
+	registerHostFunction(module_name, "executeQueryInTransaction", sqlclient.ExecuteQueryInTransaction,
+		withStartingMessage("Starting database query."),
+		withCompletedMessage("Completed database query."),
+		withCancelledMessage("Cancelled database query."),
+		withErrorMessage("Error querying database."),
+		withMessageDetail(func(transactionId, statement string) string {
+			return fmt.Sprintf("Transaction: %s Query: %s", transactionId, statement)
+		}))
+
+	registerHostFunction(module_name, "commitTransaction", DatabaseCommitTransaction,
+		withErrorMessage("Error committing database transaction."),
+		withMessageDetail(func(transactionId string) string {
+			return fmt.Sprintf("Transaction: %s", transactionId)
+		}))
+
+	registerHostFunction(module_name, "rollbackTransaction", DatabaseRollbackTransaction,
+		withErrorMessage("Error rolling back database transaction."),
+		withMessageDetail(func(transactionId string) string {
+			return fmt.Sprintf("Transaction: %s", transactionId)
+		}))
+
+	registerHostFunction(module_name, "openCursor", sqlclient.OpenCursor,
+		withStartingMessage("Starting database query."),
+		withCompletedMessage("Completed database query."),
+		withCancelledMessage("Cancelled database query."),
+		withErrorMessage("Error querying database."),
+		withMessageDetail(func(hostName, dbType, statement string) string {
+			return fmt.Sprintf("Host: %s Query: %s", hostName, statement)
+		}))
+
+	registerHostFunction(module_name, "fetchCursor", sqlclient.FetchCursor,
+		withErrorMessage("Error fetching rows from database cursor."),
+		withMessageDetail(func(cursorId string) string {
+			return fmt.Sprintf("Cursor: %s", cursorId)
+		}))
+
+	registerHostFunction(module_name, "closeCursor", DatabaseCloseCursor,
+		withErrorMessage("Error closing database cursor."),
+		withMessageDetail(func(cursorId string) string {
+			return fmt.Sprintf("Cursor: %s", cursorId)
+		}))
+}
+
+// DatabaseCommitTransaction commits the transaction, and returns true as JSON.
+func DatabaseCommitTransaction(ctx context.Context, transactionId string) (string, error) {
+	if err := sqlclient.CommitTransaction(ctx, transactionId); err != nil {
+		return "", err
+	}
+	return "true", nil
+}
+
+// DatabaseRollbackTransaction rolls back the transaction, and returns true as JSON.
+func DatabaseRollbackTransaction(ctx context.Context, transactionId string) (string, error) {
+	if err := sqlclient.RollbackTransaction(ctx, transactionId); err != nil {
+		return "", err
+	}
+	return "true", nil
+}
+
+// DatabaseCloseCursor closes the cursor, and returns true as JSON.
+func DatabaseCloseCursor(ctx context.Context, cursorId string) (string, error) {
+	if err := sqlclient.CloseCursor(ctx, cursorId); err != nil {
+		return "", err
+	}
+	return "true", nil
 }
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfunctions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+func init() {
+	const module_name = "modus_functions"
+
+	registerHostFunction(module_name, "callFunction", CallFunction,
+		withErrorMessage("Error calling function."),
+		withMessageDetail(func(fnName, argsJson string) string {
+			return fmt.Sprintf("Function: %s", fnName)
+		}))
+}
+
+// CallFunction calls another exported function by name, in its own module instance.
+// The arguments are passed as a JSON array of positional values, and the result is returned as JSON.
+// Nested calls are limited to the maximum call depth set in the manifest, to prevent unbounded recursion.
+func CallFunction(ctx context.Context, fnName string, argsJson string) (string, error) {
+	if err := wasmhost.CheckCallDepth(ctx, fnName); err != nil {
+		return "", err
+	}
+
+	var args []any
+	if argsJson != "" {
+		if err := utils.JsonDeserialize([]byte(argsJson), &args); err != nil {
+			return "", fmt.Errorf("failed to deserialize arguments for function %s: %w", fnName, err)
+		}
+	}
+
+	info, err := wasmhost.CallFunction(ctx, fnName, args...)
+	if err != nil {
+		return "", fmt.Errorf("error calling function %s: %w", fnName, err)
+	}
+
+	result, err := utils.JsonSerialize(info.Result())
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize result of function %s: %w", fnName, err)
+	}
+
+	return string(result), nil
+}
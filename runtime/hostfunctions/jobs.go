@@ -0,0 +1,74 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfunctions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/jobs"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func init() {
+	const module_name = "modus_jobs"
+
+	registerHostFunction(module_name, "enqueueJob", EnqueueJob,
+		withErrorMessage("Error enqueuing job."),
+		withMessageDetail(func(fnName, argsJson, optionsJson string) string {
+			return fmt.Sprintf("Function: %s", fnName)
+		}))
+
+	registerHostFunction(module_name, "getJob", GetJob,
+		withErrorMessage("Error getting job."),
+		withMessageDetail(func(id string) string {
+			return fmt.Sprintf("Job: %s", id)
+		}))
+}
+
+// EnqueueJob adds a job to the queue to call the named function in the background, and returns the job ID.
+// The arguments are passed as a JSON array of positional values, and the options as a JSON object.
+func EnqueueJob(ctx context.Context, fnName, argsJson, optionsJson string) (string, error) {
+	var args []any
+	if argsJson != "" {
+		if err := utils.JsonDeserialize([]byte(argsJson), &args); err != nil {
+			return "", fmt.Errorf("failed to deserialize job arguments: %w", err)
+		}
+	}
+
+	var opts jobs.EnqueueOptions
+	if optionsJson != "" {
+		if err := utils.JsonDeserialize([]byte(optionsJson), &opts); err != nil {
+			return "", fmt.Errorf("failed to deserialize job options: %w", err)
+		}
+	}
+
+	job, err := jobs.Enqueue(ctx, fnName, args, &opts)
+	if err != nil {
+		return "", err
+	}
+
+	return job.Id, nil
+}
+
+// GetJob returns the status and result of a job as JSON, or null if there is no such job.
+func GetJob(ctx context.Context, id string) (string, error) {
+	job, err := jobs.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := utils.JsonSerialize(job)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfunctions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/kv"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func init() {
+	const module_name = "modus_kv"
+
+	registerHostFunction(module_name, "get", KVGet,
+		withErrorMessage("Error getting key-value entry."),
+		withMessageDetail(func(namespace, key string) string {
+			return fmt.Sprintf("Namespace: %s, Key: %s", namespace, key)
+		}))
+
+	registerHostFunction(module_name, "set", KVSet,
+		withErrorMessage("Error setting key-value entry."),
+		withMessageDetail(func(namespace, key, value string, ttlSeconds int32) string {
+			return fmt.Sprintf("Namespace: %s, Key: %s", namespace, key)
+		}))
+
+	registerHostFunction(module_name, "compareAndSwap", KVCompareAndSwap,
+		withErrorMessage("Error swapping key-value entry."),
+		withMessageDetail(func(namespace, key string, version int64, value string, ttlSeconds int32) string {
+			return fmt.Sprintf("Namespace: %s, Key: %s, Version: %d", namespace, key, version)
+		}))
+
+	registerHostFunction(module_name, "delete", KVDelete,
+		withErrorMessage("Error deleting key-value entry."),
+		withMessageDetail(func(namespace, key string) string {
+			return fmt.Sprintf("Namespace: %s, Key: %s", namespace, key)
+		}))
+
+	registerHostFunction(module_name, "scan", KVScan,
+		withErrorMessage("Error scanning key-value entries."),
+		withMessageDetail(func(namespace, prefix string, limit int32) string {
+			return fmt.Sprintf("Namespace: %s, Prefix: %s", namespace, prefix)
+		}))
+}
+
+// KVGet returns the entry for the given key as JSON, or null if there is no such entry.
+func KVGet(ctx context.Context, namespace, key string) (string, error) {
+	return toJson(kv.Get(ctx, namespace, key))
+}
+
+// KVSet sets the value for the given key, and returns the updated entry as JSON.
+func KVSet(ctx context.Context, namespace, key, value string, ttlSeconds int32) (string, error) {
+	return toJson(kv.Set(ctx, namespace, key, value, ttlSeconds))
+}
+
+// KVCompareAndSwap sets the value for the given key if its version matches, and returns the updated entry as JSON,
+// or null if the version did not match.
+func KVCompareAndSwap(ctx context.Context, namespace, key string, version int64, value string, ttlSeconds int32) (string, error) {
+	return toJson(kv.CompareAndSwap(ctx, namespace, key, version, value, ttlSeconds))
+}
+
+// KVDelete deletes the entry for the given key, and returns true if the entry existed, as JSON.
+func KVDelete(ctx context.Context, namespace, key string) (string, error) {
+	return toJson(kv.Delete(ctx, namespace, key))
+}
+
+// KVScan returns the entries whose keys start with the given prefix as a JSON array.
+func KVScan(ctx context.Context, namespace, prefix string, limit int32) (string, error) {
+	return toJson(kv.Scan(ctx, namespace, prefix, limit))
+}
+
+func toJson[T any](v T, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := utils.JsonSerialize(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
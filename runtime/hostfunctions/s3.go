@@ -0,0 +1,78 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfunctions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/s3client"
+)
+
+func init() {
+	const module_name = "modus_s3_client"
+
+	registerHostFunction(module_name, "listObjects", S3ListObjects,
+		withStartingMessage("Listing S3 objects."),
+		withCompletedMessage("Completed listing S3 objects."),
+		withCancelledMessage("Cancelled listing S3 objects."),
+		withErrorMessage("Error listing S3 objects."),
+		withMessageDetail(func(connection, prefix, startAfter string, limit int32) string {
+			return fmt.Sprintf("Connection: %s, Prefix: %s", connection, prefix)
+		}))
+
+	registerHostFunction(module_name, "getObject", s3client.GetObject,
+		withStartingMessage("Getting S3 object."),
+		withCompletedMessage("Completed getting S3 object."),
+		withCancelledMessage("Cancelled getting S3 object."),
+		withErrorMessage("Error getting S3 object."),
+		withMessageDetail(func(connection, key string) string {
+			return fmt.Sprintf("Connection: %s, Key: %s", connection, key)
+		}))
+
+	registerHostFunction(module_name, "putObject", S3PutObject,
+		withStartingMessage("Putting S3 object."),
+		withCompletedMessage("Completed putting S3 object."),
+		withCancelledMessage("Cancelled putting S3 object."),
+		withErrorMessage("Error putting S3 object."),
+		withMessageDetail(func(connection, key string, content []byte, contentType string) string {
+			return fmt.Sprintf("Connection: %s, Key: %s, Size: %d", connection, key, len(content))
+		}))
+
+	registerHostFunction(module_name, "deleteObject", S3DeleteObject,
+		withErrorMessage("Error deleting S3 object."),
+		withMessageDetail(func(connection, key string) string {
+			return fmt.Sprintf("Connection: %s, Key: %s", connection, key)
+		}))
+
+	registerHostFunction(module_name, "presignUrl", s3client.PresignURL,
+		withErrorMessage("Error creating presigned URL."),
+		withMessageDetail(func(connection, method, key string, expiresInSeconds int32) string {
+			return fmt.Sprintf("Connection: %s, Method: %s, Key: %s", connection, method, key)
+		}))
+}
+
+// S3ListObjects returns the objects whose keys start with the given prefix as a JSON array.
+func S3ListObjects(ctx context.Context, connection, prefix, startAfter string, limit int32) (string, error) {
+	return toJson(s3client.ListObjects(ctx, connection, prefix, startAfter, limit))
+}
+
+// S3PutObject writes the content to the object with the given key, and returns the object info as JSON.
+func S3PutObject(ctx context.Context, connection, key string, content []byte, contentType string) (string, error) {
+	return toJson(s3client.PutObject(ctx, connection, key, content, contentType))
+}
+
+// S3DeleteObject deletes the object with the given key, and returns true as JSON.
+func S3DeleteObject(ctx context.Context, connection, key string) (string, error) {
+	if err := s3client.DeleteObject(ctx, connection, key); err != nil {
+		return "", err
+	}
+	return "true", nil
+}
@@ -21,6 +21,7 @@ import (
 	"syscall"
 
 	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/admin"
 	"github.com/hypermodeinc/modus/runtime/app"
 	"github.com/hypermodeinc/modus/runtime/config"
 	"github.com/hypermodeinc/modus/runtime/explorer"
@@ -132,6 +133,7 @@ func GetMainHandler(options ...func(map[string]http.Handler)) http.Handler {
 	defaultRoutes := map[string]http.Handler{
 		"/health":  healthHandler,
 		"/metrics": metrics.MetricsHandler,
+		"/admin/":  admin.AdminHandler,
 	}
 
 	if config.IsDevEnvironment() {
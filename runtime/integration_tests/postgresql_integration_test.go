@@ -116,7 +116,7 @@ func TestMain(m *testing.M) {
 	ctx := context.Background()
 
 	// setup runtime services
-	services.Start(ctx)
+	services.Start(ctx, services.ServerMode)
 	defer services.Stop(ctx)
 
 	// start HTTP server
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package invoke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+const pluginLoadTimeout = 30 * time.Second
+const pluginPollInterval = 100 * time.Millisecond
+
+// Output is written after the function is called.
+type Output struct {
+	ExecutionId string             `json:"executionId,omitempty"`
+	Result      any                `json:"result"`
+	Logs        []utils.LogMessage `json:"logs"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// Run calls a function of the loaded plugins, and writes its result, logs and execution ID to w as JSON.
+// The arguments are given as JSON, either as an array of positional arguments, or as an object of named arguments.
+// An error is returned if the function can't be called or fails.
+func Run(ctx context.Context, w io.Writer, fnName string, argsJson string) error {
+	host := wasmhost.GetWasmHost(ctx)
+	if err := waitForFunction(ctx, host, fnName); err != nil {
+		return err
+	}
+
+	execInfo, err := callFunction(ctx, host, fnName, argsJson)
+	if execInfo == nil && err != nil {
+		return err
+	}
+
+	output := Output{Error: errorMessage(err)}
+	if execInfo != nil {
+		output.ExecutionId = execInfo.ExecutionId()
+		output.Result = execInfo.Result()
+		output.Logs = append(execInfo.Messages(), utils.TransformConsoleOutput(execInfo.Buffers())...)
+	}
+
+	bytes, e := utils.JsonSerialize(output)
+	if e != nil {
+		return fmt.Errorf("failed to serialize output: %w", e)
+	}
+	fmt.Fprintln(w, string(bytes))
+
+	return err
+}
+
+func callFunction(ctx context.Context, host wasmhost.WasmHost, fnName string, argsJson string) (wasmhost.ExecutionInfo, error) {
+	if argsJson == "" {
+		return host.CallFunctionByName(ctx, fnName)
+	}
+
+	var args any
+	if err := utils.JsonDeserialize([]byte(argsJson), &args); err != nil {
+		return nil, fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	switch args := args.(type) {
+	case []any:
+		return host.CallFunctionByName(ctx, fnName, args...)
+	case map[string]any:
+		fnInfo, err := host.GetFunctionInfo(fnName)
+		if err != nil {
+			return nil, err
+		}
+		return host.CallFunction(ctx, fnInfo, args)
+	default:
+		return nil, errors.New("arguments must be a JSON array or object")
+	}
+}
+
+// waitForFunction waits for the plugin that exports the function to be loaded.
+func waitForFunction(ctx context.Context, host wasmhost.WasmHost, fnName string) error {
+	ctx, cancel := context.WithTimeout(ctx, pluginLoadTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pluginPollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := host.GetFunctionInfo(fnName)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("function %s was not loaded: %w", fnName, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
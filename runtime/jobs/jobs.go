@@ -0,0 +1,236 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/db"
+	"github.com/hypermodeinc/modus/runtime/functions"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+
+	"github.com/google/uuid"
+)
+
+// The names used for the GraphQL fields that are provided by the runtime for working with jobs.
+const (
+	EnqueueJobFunction = "modus.enqueueJob"
+	GetJobFunction     = "modus.getJob"
+)
+
+const defaultMaxAttempts = 3
+const maxMaxAttempts = 100
+
+var errJobsNotAvailable = errors.New("jobs are not available, because the runtime database is not configured")
+
+var enabled atomic.Bool
+
+var globalQueue = &queue{
+	notify: make(chan struct{}, 1),
+	quit:   make(chan struct{}),
+}
+
+type queue struct {
+	notify  chan struct{}
+	quit    chan struct{}
+	wg      sync.WaitGroup
+	mutex   sync.Mutex
+	stopped bool
+}
+
+type Job struct {
+	Id          string     `json:"id"`
+	Function    string     `json:"function"`
+	Priority    int        `json:"priority"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"maxAttempts"`
+	Result      *string    `json:"result"`
+	Error       *string    `json:"error"`
+	ExecutionId *string    `json:"executionId"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	RunAt       time.Time  `json:"runAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+}
+
+type EnqueueOptions struct {
+	// Jobs with a higher priority are run before jobs with a lower priority.  The default is 0.
+	Priority int `json:"priority"`
+
+	// The maximum number of times the job is attempted before it is moved to the dead state.
+	// The default is 3.
+	MaxAttempts int `json:"maxAttempts"`
+}
+
+// Initialize enables jobs to be enqueued, if the runtime database is configured.
+// The jobs are not run until the workers are started.
+func Initialize(ctx context.Context) {
+	if db.IsConfigured(ctx) {
+		enabled.Store(true)
+	}
+}
+
+// StartWorkers starts the workers that run jobs, if jobs are enabled.
+// The workers are started once the functions of the plugins are first registered, so that jobs aren't claimed
+// before the functions they call can be found.
+func StartWorkers(ctx context.Context) {
+	if !enabled.Load() {
+		return
+	}
+
+	var once sync.Once
+	functions.RegisterFunctionsLoadedCallback(func(context.Context) {
+		once.Do(func() { startWorkers(ctx) })
+	})
+}
+
+func startWorkers(ctx context.Context) {
+	globalQueue.mutex.Lock()
+	defer globalQueue.mutex.Unlock()
+
+	// The runtime may be shut down before the plugins are loaded.
+	if globalQueue.stopped {
+		return
+	}
+
+	for i := 0; i < config.JobWorkers; i++ {
+		globalQueue.wg.Add(1)
+		go func() {
+			defer globalQueue.wg.Done()
+			globalQueue.worker(ctx)
+		}()
+	}
+}
+
+// Shutdown stops the workers, returning any jobs in progress to the queue.
+func Shutdown(ctx context.Context) {
+	if !enabled.Load() {
+		return
+	}
+
+	globalQueue.mutex.Lock()
+	globalQueue.stopped = true
+	close(globalQueue.quit)
+	globalQueue.mutex.Unlock()
+
+	globalQueue.wg.Wait()
+}
+
+// IsEnabled reports whether jobs can be used.
+func IsEnabled() bool {
+	return enabled.Load()
+}
+
+// Enqueue adds a job to the queue, to call the given function with the given arguments in the background.
+func Enqueue(ctx context.Context, fnName string, args []any, opts *EnqueueOptions) (*Job, error) {
+	if !IsEnabled() {
+		return nil, errJobsNotAvailable
+	}
+
+	if _, err := wasmhost.GetWasmHost(ctx).GetFunctionInfo(fnName); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &EnqueueOptions{}
+	}
+
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultMaxAttempts
+	} else if maxAttempts < 0 || maxAttempts > maxMaxAttempts {
+		return nil, fmt.Errorf("maxAttempts must be between 1 and %d", maxMaxAttempts)
+	}
+
+	if args == nil {
+		args = []any{}
+	}
+	argsJson, err := utils.JsonSerialize(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize job arguments: %w", err)
+	}
+
+	job := &db.Job{
+		Id:          utils.GenerateUUIDv7(),
+		Function:    fnName,
+		Args:        argsJson,
+		Priority:    opts.Priority,
+		Status:      db.JobStatusPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       time.Now().UTC(),
+	}
+
+	if err := db.InsertJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	logger.Info(ctx).
+		Str("job_id", job.Id).
+		Str("job_function", fnName).
+		Bool("user_visible", true).
+		Msg("Enqueued job.")
+
+	// Wake up a worker on this instance, rather than waiting for the next poll.
+	select {
+	case globalQueue.notify <- struct{}{}:
+	default:
+	}
+
+	return newJob(job), nil
+}
+
+// Get returns the job with the given ID, or nil if there is no such job.
+func Get(ctx context.Context, id string) (*Job, error) {
+	if !IsEnabled() {
+		return nil, errJobsNotAvailable
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+
+	job, err := db.GetJob(ctx, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	return newJob(job), nil
+}
+
+func newJob(j *db.Job) *Job {
+	job := &Job{
+		Id:          j.Id,
+		Function:    j.Function,
+		Priority:    j.Priority,
+		Status:      j.Status,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		Error:       j.Error,
+		ExecutionId: j.ExecutionId,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		RunAt:       j.RunAt,
+		FinishedAt:  j.FinishedAt,
+	}
+	if j.Result != nil {
+		result := string(j.Result)
+		job.Result = &result
+	}
+	return job
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/db"
+	"github.com/hypermodeinc/modus/runtime/functions"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+const pollInterval = 2 * time.Second
+
+// A claimed job is locked for this long, and the lease is renewed periodically while the job runs.
+// A job whose lease expires is assumed to have been abandoned by an instance of the runtime
+// that stopped unexpectedly, and will be run again.
+const leaseDuration = 2 * time.Minute
+const leaseRenewInterval = leaseDuration / 4
+
+// renewJobLease is a variable so that it can be replaced in tests.
+var renewJobLease = db.RenewJobLease
+
+const minRetryDelay = 5 * time.Second
+const maxRetryDelay = 1 * time.Hour
+
+func (q *queue) worker(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Cancel any job in progress when shutting down, so that it can be returned to the queue.
+	go func() {
+		select {
+		case <-q.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-q.quit:
+			return
+		case <-q.notify:
+		case <-timer.C:
+		}
+
+		// Keep running jobs until there are none ready.
+		for q.runNextJob(ctx) {
+		}
+
+		timer.Reset(pollInterval)
+	}
+}
+
+// runNextJob claims and runs the next job that is ready, returning false if there was none.
+func (q *queue) runNextJob(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	job, err := db.ClaimJob(ctx, leaseDuration)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Err(ctx, err).Msg("Failed to get next job from the queue.")
+		}
+		return false
+	} else if job == nil {
+		return false
+	}
+
+	runJob(ctx, job)
+	return true
+}
+
+func runJob(ctx context.Context, job *db.Job) {
+	// Database updates must be made even while the runtime is shutting down.
+	dbCtx := context.WithoutCancel(ctx)
+
+	// A job that was abandoned on its last attempt is not attempted again.
+	if job.Attempts > job.MaxAttempts {
+		failJob(dbCtx, job, nil, errors.New("the job was abandoned while running"))
+		return
+	}
+
+	logger.Info(ctx).
+		Str("job_id", job.Id).
+		Str("job_function", job.Function).
+		Int("attempt", job.Attempts).
+		Bool("user_visible", true).
+		Msg("Running job.")
+
+	var args []any
+	if err := utils.JsonDeserialize(job.Args, &args); err != nil {
+		failJob(dbCtx, job, nil, err)
+		return
+	}
+
+	// Keep the lease while the job runs, and stop the job if the lease is lost.
+	jobCtx, cancelJob := context.WithCancelCause(ctx)
+	defer cancelJob(nil)
+	leaseCtx, stopLease := context.WithCancel(jobCtx)
+	go keepLease(leaseCtx, job, leaseRenewInterval, cancelJob)
+
+	start := time.Now()
+	execInfo, err := wasmhost.CallFunction(jobCtx, job.Function, args...)
+	duration := time.Since(start)
+	stopLease()
+
+	if errors.Is(context.Cause(jobCtx), db.ErrJobLeaseLost) {
+		logLeaseLost(ctx, job)
+		return
+	}
+
+	var executionId *string
+	if execInfo != nil {
+		id := execInfo.ExecutionId()
+		executionId = &id
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// The runtime is shutting down, so let another instance pick up the job.
+			releaseJob(ctx, job, nil)
+			return
+		}
+
+		if errors.Is(err, functions.ErrFunctionNotRegistered) {
+			// The plugin that exports the function may not be loaded yet, such as while a new build is deployed,
+			// so the job is tried again later, without counting the attempt.
+			retryAt := time.Now().UTC().Add(minRetryDelay)
+			logger.Warn(ctx).Err(err).
+				Str("job_id", job.Id).
+				Str("job_function", job.Function).
+				Time("retry_at", retryAt).
+				Bool("user_visible", true).
+				Msg("Job function is not registered.  The job will be tried again later.")
+			releaseJob(ctx, job, &retryAt)
+			return
+		}
+
+		failJob(dbCtx, job, executionId, err)
+		return
+	}
+
+	result, err := utils.JsonSerialize(execInfo.Result())
+	if err != nil {
+		failJob(dbCtx, job, executionId, err)
+		return
+	}
+
+	if err := db.CompleteJob(dbCtx, job.Id, job.Attempts, executionId, result); errors.Is(err, db.ErrJobLeaseLost) {
+		logLeaseLost(ctx, job)
+		return
+	} else if err != nil {
+		logger.Err(ctx, err).Str("job_id", job.Id).Msg("Failed to record job result.")
+		return
+	}
+
+	metrics.JobsNum.WithLabelValues(job.Function, db.JobStatusSucceeded).Inc()
+
+	logger.Info(ctx).
+		Str("job_id", job.Id).
+		Str("job_function", job.Function).
+		Dur("duration_ms", duration).
+		Bool("user_visible", true).
+		Msg("Job completed.")
+}
+
+// releaseJob returns a job to the queue without counting the attempt.
+func releaseJob(ctx context.Context, job *db.Job, runAt *time.Time) {
+	dbCtx := context.WithoutCancel(ctx)
+	if err := db.ReleaseJob(dbCtx, job.Id, job.Attempts, runAt); errors.Is(err, db.ErrJobLeaseLost) {
+		logLeaseLost(ctx, job)
+	} else if err != nil {
+		logger.Err(ctx, err).Str("job_id", job.Id).Msg("Failed to return job to the queue.")
+	}
+}
+
+func failJob(ctx context.Context, job *db.Job, executionId *string, jobErr error) {
+	var retryAt *time.Time
+	status := db.JobStatusDead
+	if job.Attempts < job.MaxAttempts {
+		t := time.Now().UTC().Add(getRetryDelay(job.Attempts))
+		retryAt = &t
+		status = "retrying"
+	}
+
+	if err := db.FailJob(ctx, job.Id, job.Attempts, executionId, jobErr.Error(), retryAt); errors.Is(err, db.ErrJobLeaseLost) {
+		logLeaseLost(ctx, job)
+		return
+	} else if err != nil {
+		logger.Err(ctx, err).Str("job_id", job.Id).Msg("Failed to record job failure.")
+		return
+	}
+
+	metrics.JobsNum.WithLabelValues(job.Function, status).Inc()
+
+	l := logger.Warn(ctx)
+	if retryAt == nil {
+		l = logger.Error(ctx)
+	}
+	l = l.Err(jobErr).
+		Str("job_id", job.Id).
+		Str("job_function", job.Function).
+		Int("attempt", job.Attempts).
+		Bool("user_visible", true)
+
+	if retryAt != nil {
+		l.Time("retry_at", *retryAt).Msg("Job failed, and will be retried.")
+	} else {
+		l.Msg("Job failed, and will not be retried.")
+	}
+}
+
+// keepLease renews the lease of a running job at each interval, until the context is done.
+// If the lease was lost, such as when it expired and the job was claimed by another worker,
+// the job is canceled so that it doesn't keep running alongside the other attempt.
+func keepLease(ctx context.Context, job *db.Job, interval time.Duration, cancelJob context.CancelCauseFunc) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := renewJobLease(ctx, job.Id, job.Attempts, leaseDuration)
+		if errors.Is(err, db.ErrJobLeaseLost) {
+			cancelJob(err)
+			return
+		} else if err != nil && ctx.Err() == nil {
+			// The lease is still held until it expires, so keep trying at the next interval.
+			logger.Warn(ctx).Err(err).Str("job_id", job.Id).Msg("Failed to renew the lease of a running job.")
+		}
+	}
+}
+
+func logLeaseLost(ctx context.Context, job *db.Job) {
+	logger.Warn(ctx).
+		Str("job_id", job.Id).
+		Str("job_function", job.Function).
+		Int("attempt", job.Attempts).
+		Bool("user_visible", true).
+		Msg("Job attempt was stopped, because its lease was lost.  The job may have been claimed by another worker.")
+}
+
+// getRetryDelay returns the delay before the next attempt, doubling with each attempt.
+func getRetryDelay(attempts int) time.Duration {
+	delay := float64(minRetryDelay) * math.Pow(2, float64(attempts-1))
+	if delay > float64(maxRetryDelay) {
+		return maxRetryDelay
+	}
+	return time.Duration(delay)
+}
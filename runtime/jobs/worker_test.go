@@ -0,0 +1,82 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/db"
+)
+
+func TestKeepLease_CancelsJobWhenLeaseIsLost(t *testing.T) {
+	var renewals atomic.Int32
+	renewJobLease = func(ctx context.Context, id string, attempt int, lease time.Duration) error {
+		if id != "job1" || attempt != 2 || lease != leaseDuration {
+			t.Errorf("unexpected lease renewal: %s, %d, %v", id, attempt, lease)
+		}
+		if renewals.Add(1) < 3 {
+			return nil
+		}
+		return db.ErrJobLeaseLost
+	}
+	defer func() { renewJobLease = db.RenewJobLease }()
+
+	jobCtx, cancelJob := context.WithCancelCause(context.Background())
+	defer cancelJob(nil)
+
+	job := &db.Job{Id: "job1", Attempts: 2}
+	go keepLease(jobCtx, job, time.Millisecond, cancelJob)
+
+	select {
+	case <-jobCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the job to be canceled when its lease was lost")
+	}
+
+	if !errors.Is(context.Cause(jobCtx), db.ErrJobLeaseLost) {
+		t.Errorf("expected the job to be canceled because its lease was lost, got %v", context.Cause(jobCtx))
+	}
+	if n := renewals.Load(); n != 3 {
+		t.Errorf("expected 3 lease renewals, got %d", n)
+	}
+}
+
+func TestKeepLease_KeepsRenewingAfterErrors(t *testing.T) {
+	var renewals atomic.Int32
+	renewJobLease = func(ctx context.Context, id string, attempt int, lease time.Duration) error {
+		renewals.Add(1)
+		return errors.New("connection refused")
+	}
+	defer func() { renewJobLease = db.RenewJobLease }()
+
+	jobCtx, cancelJob := context.WithCancelCause(context.Background())
+	defer cancelJob(nil)
+	leaseCtx, stopLease := context.WithCancel(jobCtx)
+
+	done := make(chan struct{})
+	go func() {
+		keepLease(leaseCtx, &db.Job{Id: "job1"}, time.Millisecond, cancelJob)
+		close(done)
+	}()
+
+	for renewals.Load() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	stopLease()
+	<-done
+
+	if jobCtx.Err() != nil {
+		t.Errorf("expected the job not to be canceled, got %v", context.Cause(jobCtx))
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/db"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+)
+
+const defaultScanLimit = 100
+const maxScanLimit = 1000
+
+const cleanupInterval = 1 * time.Minute
+
+var globalStore store = newMemoryStore()
+
+var quit = make(chan struct{})
+var done = make(chan struct{})
+
+type Entry struct {
+	Key       string     `json:"key"`
+	Value     string     `json:"value"`
+	Version   int64      `json:"version"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// store is the interface for a backend that holds the entries.
+// Entries are scoped to an app, and to a namespace within the app.
+type store interface {
+	get(ctx context.Context, app, namespace, key string) (*Entry, error)
+	set(ctx context.Context, app, namespace, key, value string, expiresAt *time.Time) (*Entry, error)
+	compareAndSwap(ctx context.Context, app, namespace, key string, version int64, value string, expiresAt *time.Time) (*Entry, error)
+	delete(ctx context.Context, app, namespace, key string) (bool, error)
+	scan(ctx context.Context, app, namespace, prefix string, limit int) ([]*Entry, error)
+	deleteExpired(ctx context.Context) (int64, error)
+}
+
+// Initialize selects the backend for the store.  The runtime database is used if it is configured.
+// Otherwise, entries are kept in memory, which is suitable only for development.
+func Initialize(ctx context.Context) {
+	if db.IsConfigured(ctx) {
+		globalStore = &postgresStore{}
+	} else {
+		logger.Warn(ctx).Msg("The runtime database is not configured.  Key-value entries will be kept in memory, and will be lost when the runtime stops.")
+	}
+
+	go cleanupWorker(ctx)
+}
+
+func Shutdown(ctx context.Context) {
+	close(quit)
+	<-done
+}
+
+func cleanupWorker(ctx context.Context) {
+	defer close(done)
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+			n, err := globalStore.deleteExpired(ctx)
+			if err != nil {
+				logger.Err(ctx, err).Msg("Failed to delete expired key-value entries.")
+			} else if n > 0 {
+				logger.Debug(ctx).Int64("count", n).Msg("Deleted expired key-value entries.")
+			}
+		}
+	}
+}
+
+// Get returns the entry for the given key, or nil if there is no such entry.
+func Get(ctx context.Context, namespace, key string) (*Entry, error) {
+	app, err := getApp(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return globalStore.get(ctx, app, namespace, key)
+}
+
+// Set sets the value for the given key, and returns the updated entry.
+// If ttlSeconds is greater than zero, the entry expires after that many seconds.
+func Set(ctx context.Context, namespace, key, value string, ttlSeconds int32) (*Entry, error) {
+	app, err := getApp(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := getExpiration(ttlSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	return globalStore.set(ctx, app, namespace, key, value, expiresAt)
+}
+
+// CompareAndSwap sets the value for the given key, only if the current version of the entry matches the expected version.
+// A version of zero means that there must not be an existing entry.
+// It returns the updated entry, or nil if the version did not match.
+func CompareAndSwap(ctx context.Context, namespace, key string, version int64, value string, ttlSeconds int32) (*Entry, error) {
+	app, err := getApp(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if version < 0 {
+		return nil, errors.New("version cannot be negative")
+	}
+
+	expiresAt, err := getExpiration(ttlSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	return globalStore.compareAndSwap(ctx, app, namespace, key, version, value, expiresAt)
+}
+
+// Delete deletes the entry for the given key, returning true if the entry existed.
+func Delete(ctx context.Context, namespace, key string) (bool, error) {
+	app, err := getApp(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return globalStore.delete(ctx, app, namespace, key)
+}
+
+// Scan returns the entries whose keys start with the given prefix, in key order.
+// If limit is zero, a default limit is used.
+func Scan(ctx context.Context, namespace, prefix string, limit int32) ([]*Entry, error) {
+	p, ok := plugins.GetPluginFromContext(ctx)
+	if !ok {
+		return nil, errors.New("no plugin found in context")
+	}
+
+	if limit < 0 || limit > maxScanLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxScanLimit)
+	} else if limit == 0 {
+		limit = defaultScanLimit
+	}
+
+	return globalStore.scan(ctx, p.Name(), namespace, prefix, int(limit))
+}
+
+// getApp returns the name of the app that entries are scoped to, which is the name of the calling plugin.
+func getApp(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", errors.New("key cannot be empty")
+	}
+
+	p, ok := plugins.GetPluginFromContext(ctx)
+	if !ok {
+		return "", errors.New("no plugin found in context")
+	}
+
+	return p.Name(), nil
+}
+
+func getExpiration(ttlSeconds int32) (*time.Time, error) {
+	if ttlSeconds < 0 {
+		return nil, errors.New("ttl cannot be negative")
+	} else if ttlSeconds == 0 {
+		return nil, nil
+	}
+
+	t := time.Now().UTC().Add(time.Duration(ttlSeconds) * time.Second)
+	return &t, nil
+}
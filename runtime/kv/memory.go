@@ -0,0 +1,165 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryStore keeps entries in memory, for use in development when there is no runtime database.
+type memoryStore struct {
+	entries map[scope]map[string]*Entry
+	mu      sync.RWMutex
+}
+
+type scope struct {
+	app       string
+	namespace string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		entries: make(map[scope]map[string]*Entry),
+	}
+}
+
+func isExpired(e *Entry, now time.Time) bool {
+	return e.ExpiresAt != nil && !e.ExpiresAt.After(now)
+}
+
+func (s *memoryStore) get(ctx context.Context, app, namespace, key string) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.entries[scope{app, namespace}][key]
+	if !ok || isExpired(e, time.Now()) {
+		return nil, nil
+	}
+
+	entry := *e
+	return &entry, nil
+}
+
+func (s *memoryStore) set(ctx context.Context, app, namespace, key, value string, expiresAt *time.Time) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.put(scope{app, namespace}, key, value, expiresAt), nil
+}
+
+func (s *memoryStore) compareAndSwap(ctx context.Context, app, namespace, key string, version int64, value string, expiresAt *time.Time) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc := scope{app, namespace}
+
+	var current int64
+	if e, ok := s.entries[sc][key]; ok && !isExpired(e, time.Now()) {
+		current = e.Version
+	}
+
+	if current != version {
+		return nil, nil
+	}
+
+	return s.put(sc, key, value, expiresAt), nil
+}
+
+// put sets the entry, incrementing its version.  The caller must hold the write lock.
+func (s *memoryStore) put(sc scope, key, value string, expiresAt *time.Time) *Entry {
+	m, ok := s.entries[sc]
+	if !ok {
+		m = make(map[string]*Entry)
+		s.entries[sc] = m
+	}
+
+	var version int64 = 1
+	if e, ok := m[key]; ok {
+		version = e.Version + 1
+	}
+
+	e := &Entry{
+		Key:       key,
+		Value:     value,
+		Version:   version,
+		ExpiresAt: expiresAt,
+	}
+	m[key] = e
+
+	entry := *e
+	return &entry
+}
+
+func (s *memoryStore) delete(ctx context.Context, app, namespace, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc := scope{app, namespace}
+	e, ok := s.entries[sc][key]
+	if !ok {
+		return false, nil
+	}
+
+	delete(s.entries[sc], key)
+	if len(s.entries[sc]) == 0 {
+		delete(s.entries, sc)
+	}
+
+	return !isExpired(e, time.Now()), nil
+}
+
+func (s *memoryStore) scan(ctx context.Context, app, namespace, prefix string, limit int) ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	entries := make([]*Entry, 0)
+	for key, e := range s.entries[scope{app, namespace}] {
+		if strings.HasPrefix(key, prefix) && !isExpired(e, now) {
+			entry := *e
+			entries = append(entries, &entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func (s *memoryStore) deleteExpired(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var n int64
+	for sc, m := range s.entries {
+		for key, e := range m {
+			if isExpired(e, now) {
+				delete(m, key)
+				n++
+			}
+		}
+		if len(m) == 0 {
+			delete(s.entries, sc)
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_SetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore()
+
+	e, err := s.set(ctx, "app", "", "a", "1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), e.Version)
+
+	e, err = s.set(ctx, "app", "", "a", "2", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), e.Version)
+
+	e, err = s.get(ctx, "app", "", "a")
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	assert.Equal(t, "2", e.Value)
+
+	// Entries are scoped to the app and namespace.
+	e, err = s.get(ctx, "app", "other", "a")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	e, err = s.get(ctx, "other", "", "a")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	existed, err := s.delete(ctx, "app", "", "a")
+	require.NoError(t, err)
+	assert.True(t, existed)
+
+	existed, err = s.delete(ctx, "app", "", "a")
+	require.NoError(t, err)
+	assert.False(t, existed)
+
+	e, err = s.get(ctx, "app", "", "a")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+}
+
+func TestMemoryStore_CompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore()
+
+	e, err := s.compareAndSwap(ctx, "app", "", "a", 0, "1", nil)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	assert.Equal(t, int64(1), e.Version)
+
+	// The entry already exists, so version zero doesn't match.
+	e, err = s.compareAndSwap(ctx, "app", "", "a", 0, "2", nil)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	e, err = s.compareAndSwap(ctx, "app", "", "a", 1, "2", nil)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	assert.Equal(t, int64(2), e.Version)
+	assert.Equal(t, "2", e.Value)
+
+	e, err = s.compareAndSwap(ctx, "app", "", "a", 1, "3", nil)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+}
+
+func TestMemoryStore_Expiration(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore()
+
+	past := time.Now().Add(-time.Second)
+	_, err := s.set(ctx, "app", "", "a", "1", &past)
+	require.NoError(t, err)
+
+	e, err := s.get(ctx, "app", "", "a")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	// An expired entry is treated as missing by compare-and-swap.
+	e, err = s.compareAndSwap(ctx, "app", "", "a", 0, "2", nil)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+
+	_, err = s.set(ctx, "app", "", "b", "1", &past)
+	require.NoError(t, err)
+
+	n, err := s.deleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestMemoryStore_Scan(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore()
+
+	for _, key := range []string{"user:3", "user:1", "session:1", "user:2"} {
+		_, err := s.set(ctx, "app", "", key, key, nil)
+		require.NoError(t, err)
+	}
+
+	entries, err := s.scan(ctx, "app", "", "user:", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "user:1", entries[0].Key)
+	assert.Equal(t, "user:2", entries[1].Key)
+	assert.Equal(t, "user:3", entries[2].Key)
+
+	entries, err = s.scan(ctx, "app", "", "user:", 2)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = s.scan(ctx, "app", "", "", 10)
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
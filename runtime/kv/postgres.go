@@ -0,0 +1,60 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/db"
+)
+
+// postgresStore keeps entries in the runtime database, so they are shared by all instances of the runtime.
+type postgresStore struct{}
+
+func (s *postgresStore) get(ctx context.Context, app, namespace, key string) (*Entry, error) {
+	return toEntry(db.GetKV(ctx, app, namespace, key))
+}
+
+func (s *postgresStore) set(ctx context.Context, app, namespace, key, value string, expiresAt *time.Time) (*Entry, error) {
+	return toEntry(db.SetKV(ctx, app, namespace, key, value, expiresAt))
+}
+
+func (s *postgresStore) compareAndSwap(ctx context.Context, app, namespace, key string, version int64, value string, expiresAt *time.Time) (*Entry, error) {
+	return toEntry(db.CompareAndSwapKV(ctx, app, namespace, key, version, value, expiresAt))
+}
+
+func (s *postgresStore) delete(ctx context.Context, app, namespace, key string) (bool, error) {
+	return db.DeleteKV(ctx, app, namespace, key)
+}
+
+func (s *postgresStore) scan(ctx context.Context, app, namespace, prefix string, limit int) ([]*Entry, error) {
+	dbEntries, err := db.ScanKV(ctx, app, namespace, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, len(dbEntries))
+	for i, e := range dbEntries {
+		entries[i] = (*Entry)(e)
+	}
+	return entries, nil
+}
+
+func (s *postgresStore) deleteExpired(ctx context.Context) (int64, error) {
+	return db.DeleteExpiredKV(ctx)
+}
+
+func toEntry(e *db.KVEntry, err error) (*Entry, error) {
+	if err != nil || e == nil {
+		return nil, err
+	}
+	return (*Entry)(e), nil
+}
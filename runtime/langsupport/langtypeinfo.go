@@ -29,6 +29,7 @@ type LanguageTypeInfo interface {
 
 	IsBooleanType(typ string) bool
 	IsByteSequenceType(typ string) bool
+	IsFileType(typ string) bool
 	IsFloatType(typ string) bool
 	IsIntegerType(typ string) bool
 	IsListType(typ string) bool
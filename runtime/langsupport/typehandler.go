@@ -22,3 +22,7 @@ type TypeHandler interface {
 	Decode(ctx context.Context, wa WasmAdapter, vals []uint64) (any, error)
 	Encode(ctx context.Context, wa WasmAdapter, obj any) ([]uint64, utils.Cleaner, error)
 }
+
+// TypeNameKey is the key used to convey the name of the concrete type of an object,
+// when the object was read through an abstract type such as an interface or abstract class.
+const TypeNameKey = "__typename"
@@ -34,6 +34,7 @@ type TypeInfo interface {
 	ObjectFieldTypes() []TypeInfo
 	ObjectFieldOffsets() []uint32
 
+	IsAbstract() bool
 	IsBoolean() bool
 	IsByteSequence() bool
 	IsFloat() bool
@@ -142,6 +143,10 @@ func GetTypeInfo(ctx context.Context, lti LanguageTypeInfo, typeName string, typ
 			return nil, err
 		}
 
+		if def.IsAbstract() {
+			flags |= tfAbstract
+		}
+
 		offset := uint32(0)
 		maxAlignment := uint32(0)
 		info.fieldTypes = make([]TypeInfo, len(def.Fields))
@@ -217,8 +222,9 @@ func GetTypeInfo(ctx context.Context, lti LanguageTypeInfo, typeName string, typ
 type typeFlags uint32
 
 const (
-	_         typeFlags = 0
-	tfBoolean typeFlags = 1 << (iota - 1)
+	_          typeFlags = 0
+	tfAbstract typeFlags = 1 << (iota - 1)
+	tfBoolean
 	tfByteSequence
 	tfFloat
 	tfInteger
@@ -255,6 +261,7 @@ func (h *typeInfo) Alignment() uint32           { return h.alignment }
 func (h *typeInfo) DataSize() uint32            { return h.dataSize }
 func (h *typeInfo) EncodingLength() uint32      { return h.encodingLength }
 
+func (h *typeInfo) IsAbstract() bool      { return h.flags&tfAbstract != 0 }
 func (h *typeInfo) IsBoolean() bool       { return h.flags&tfBoolean != 0 }
 func (h *typeInfo) IsByteSequence() bool  { return h.flags&tfByteSequence != 0 }
 func (h *typeInfo) IsFloat() bool         { return h.flags&tfFloat != 0 }
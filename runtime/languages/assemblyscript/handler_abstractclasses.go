@@ -0,0 +1,99 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package assemblyscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func (p *planner) NewAbstractClassHandler(ctx context.Context, ti langsupport.TypeInfo) (managedTypeHandler, error) {
+
+	handler := &abstractClassHandler{
+		typeHandler: *NewTypeHandler(ti),
+	}
+
+	typeDef, err := p.metadata.GetTypeDefinition(ti.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	handler.variants = make(map[uint32]*abstractClassVariant, len(typeDef.Variants))
+	for _, variantType := range typeDef.Variants {
+		variantDef, err := p.metadata.GetTypeDefinition(variantType)
+		if err != nil {
+			return nil, err
+		}
+
+		variantInfo, err := GetTypeInfo(ctx, variantType, p.typeCache)
+		if err != nil {
+			return nil, err
+		}
+
+		classHandler, err := p.NewClassHandler(ctx, variantInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		handler.variants[variantDef.Id] = &abstractClassVariant{
+			typeName: _langTypeInfo.GetNameForType(variantType),
+			handler:  classHandler,
+		}
+	}
+
+	return handler, nil
+}
+
+type abstractClassHandler struct {
+	typeHandler
+	variants map[uint32]*abstractClassVariant
+}
+
+type abstractClassVariant struct {
+	typeName string
+	handler  managedTypeHandler
+}
+
+func (h *abstractClassHandler) Read(ctx context.Context, wa langsupport.WasmAdapter, offset uint32) (any, error) {
+	if offset == 0 {
+		return nil, nil
+	}
+
+	// The runtime id of the concrete class is stored in the object header, 8 bytes before the object data.
+	id, ok := wa.Memory().ReadUint32Le(offset - 8)
+	if !ok {
+		return nil, errors.New("failed to read class id from object header")
+	}
+
+	variant, ok := h.variants[id]
+	if !ok {
+		return nil, fmt.Errorf("unexpected class id %d for abstract class %s", id, h.typeInfo.Name())
+	}
+
+	data, err := variant.handler.Read(ctx, wa, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the concrete type name, so that it can be reported to the caller.
+	if m, ok := data.(map[string]any); ok {
+		m[langsupport.TypeNameKey] = variant.typeName
+	}
+
+	return data, nil
+}
+
+func (h *abstractClassHandler) Write(ctx context.Context, wa langsupport.WasmAdapter, offset uint32, obj any) (utils.Cleaner, error) {
+	return nil, fmt.Errorf("writing values of abstract class %s is not supported", h.typeInfo.Name())
+}
@@ -62,6 +62,9 @@ func (p *planner) NewManagedObjectHandler(ctx context.Context, ti langsupport.Ty
 		case reflect.Map:
 			if ti.IsMap() {
 				return p.NewMapHandler(ctx, ti)
+			} else if ti.IsAbstract() {
+				// This is an abstract class, whose values are one of its concrete subclasses.
+				return p.NewAbstractClassHandler(ctx, ti)
 			} else {
 				// This is a class that is being passed as a map.
 				return p.NewClassHandler(ctx, ti)
@@ -192,6 +192,11 @@ func (lti *langTypeInfo) IsTimestampType(typ string) bool {
 	}
 }
 
+func (lti *langTypeInfo) IsFileType(typ string) bool {
+	typ = lti.GetUnderlyingType(typ)
+	return typ == "~lib/@hypermode/modus-sdk-as/assembly/files/File"
+}
+
 func (lti *langTypeInfo) GetSizeOfType(ctx context.Context, typ string) (uint32, error) {
 	switch typ {
 	case "u64", "i64", "f64":
@@ -31,6 +31,7 @@ func NewWasmAdapter(mod wasm.Module) langsupport.WasmAdapter {
 		fnUnpin:     mod.ExportedFunction("__unpin"),
 		fnReadMap:   mod.ExportedFunction("__read_map"),
 		fnWriteMap:  mod.ExportedFunction("__write_map"),
+		fnTypeId:    mod.ExportedFunction("__type_id"),
 	}
 }
 
@@ -44,6 +45,7 @@ type wasmAdapter struct {
 	fnUnpin     wasm.Function
 	fnReadMap   wasm.Function
 	fnWriteMap  wasm.Function
+	fnTypeId    wasm.Function
 }
 
 func (*wasmAdapter) TypeInfo() langsupport.LanguageTypeInfo {
@@ -106,6 +108,19 @@ func (wa *wasmAdapter) newWasmObject(ctx context.Context, id uint32) (uint32, ut
 	return ptr, cln, nil
 }
 
+func (wa *wasmAdapter) getConcreteTypeId(ctx context.Context, ptr uint32) (uint32, error) {
+	if wa.fnTypeId == nil {
+		return 0, errors.New("the module does not export a __type_id function")
+	}
+
+	res, err := wa.fnTypeId.Call(ctx, uint64(ptr))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the concrete type of the interface value at %d: %w", ptr, err)
+	}
+
+	return uint32(res[0]), nil
+}
+
 func (wa *wasmAdapter) makeWasmObject(ctx context.Context, id, size uint32) (uint32, utils.Cleaner, error) {
 	res, err := wa.fnMake.Call(ctx, uint64(id), uint64(size))
 	if err != nil {
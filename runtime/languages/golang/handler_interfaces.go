@@ -0,0 +1,130 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func (p *planner) NewInterfaceHandler(ctx context.Context, ti langsupport.TypeInfo) (langsupport.TypeHandler, error) {
+	handler := &interfaceHandler{
+		typeHandler: *NewTypeHandler(ti),
+	}
+	p.AddHandler(handler)
+
+	typeDef, err := p.metadata.GetTypeDefinition(ti.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	handler.variants = make(map[uint32]*interfaceVariant, len(typeDef.Variants))
+	for _, variantType := range typeDef.Variants {
+		structType := _langTypeInfo.GetUnderlyingType(variantType)
+		structDef, err := p.metadata.GetTypeDefinition(structType)
+		if err != nil {
+			return nil, err
+		}
+
+		structHandler, err := p.GetHandler(ctx, structType)
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := _langTypeInfo.GetSizeOfType(ctx, structType)
+		if err != nil {
+			return nil, err
+		}
+
+		handler.variants[structDef.Id] = &interfaceVariant{
+			typeName: _langTypeInfo.GetNameForType(structType),
+			handler:  structHandler,
+
+			// TinyGo stores values that fit within a pointer directly in the interface.
+			// Everything else (including pointers) is referenced by the pointer.
+			indirect: _langTypeInfo.IsPointerType(variantType) || size > 4,
+		}
+	}
+
+	return handler, nil
+}
+
+type interfaceHandler struct {
+	typeHandler
+	variants map[uint32]*interfaceVariant
+}
+
+type interfaceVariant struct {
+	typeName string
+	handler  langsupport.TypeHandler
+	indirect bool
+}
+
+func (h *interfaceHandler) Read(ctx context.Context, wa langsupport.WasmAdapter, offset uint32) (any, error) {
+	typeCode, ok := wa.Memory().ReadUint32Le(offset)
+	if !ok {
+		return nil, errors.New("failed to read interface type code from memory")
+	}
+	if typeCode == 0 {
+		// nil interface
+		return nil, nil
+	}
+
+	id, err := wa.(*wasmAdapter).getConcreteTypeId(ctx, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, ok := h.variants[id]
+	if !ok {
+		return nil, fmt.Errorf("unexpected concrete type id %d for interface %s", id, h.typeInfo.Name())
+	}
+
+	dataOffset := offset + 4
+	if variant.indirect {
+		ptr, ok := wa.Memory().ReadUint32Le(dataOffset)
+		if !ok {
+			return nil, errors.New("failed to read interface value pointer from memory")
+		}
+		if ptr == 0 {
+			// nil pointer stored in a non-nil interface
+			return nil, nil
+		}
+		dataOffset = ptr
+	}
+
+	data, err := variant.handler.Read(ctx, wa, dataOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the concrete type name, so that it can be reported to the caller.
+	if m, ok := data.(map[string]any); ok {
+		m[langsupport.TypeNameKey] = variant.typeName
+	}
+
+	return data, nil
+}
+
+func (h *interfaceHandler) Write(ctx context.Context, wa langsupport.WasmAdapter, offset uint32, obj any) (utils.Cleaner, error) {
+	return nil, fmt.Errorf("writing values of interface type %s is not supported", h.typeInfo.Name())
+}
+
+func (h *interfaceHandler) Decode(ctx context.Context, wa langsupport.WasmAdapter, vals []uint64) (any, error) {
+	return nil, fmt.Errorf("decoding values of interface type %s is not supported", h.typeInfo.Name())
+}
+
+func (h *interfaceHandler) Encode(ctx context.Context, wa langsupport.WasmAdapter, obj any) ([]uint64, utils.Cleaner, error) {
+	return nil, nil, fmt.Errorf("encoding values of interface type %s is not supported", h.typeInfo.Name())
+}
@@ -90,6 +90,8 @@ func (p *planner) GetHandler(ctx context.Context, typeName string) (langsupport.
 		return p.NewMapHandler(ctx, ti)
 	} else if ti.IsTimestamp() {
 		return p.NewTimeHandler(ti)
+	} else if ti.IsAbstract() {
+		return p.NewInterfaceHandler(ctx, ti)
 	} else if ti.IsObject() {
 		return p.NewStructHandler(ctx, ti)
 	}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package golang_test
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/langsupport"
+	"github.com/hypermodeinc/modus/runtime/languages/golang"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// typeIdModule is a minimal WASM module that exports its memory, and a __type_id function that reads the
+// concrete type id from the first word of the interface value, in place of TinyGo's type code.
+var typeIdModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+	0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f, // type: (i32) -> i32
+	0x03, 0x02, 0x01, 0x00, // function: type 0
+	0x05, 0x03, 0x01, 0x00, 0x01, // memory: 1 page
+	0x07, 0x16, 0x02, // exports:
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, // memory
+	0x09, '_', '_', 't', 'y', 'p', 'e', '_', 'i', 'd', 0x00, 0x00, // __type_id
+	0x0a, 0x09, 0x01, 0x07, 0x00, 0x20, 0x00, 0x28, 0x02, 0x00, 0x0b, // code: local.get 0, i32.load, end
+}
+
+const (
+	circleTypeId = 10
+	squareTypeId = 11
+	otherTypeId  = 99
+)
+
+func TestInterfaceHandler(t *testing.T) {
+	md := metadata.NewPluginMetadata()
+	md.Types.AddType("testdata.Shape").
+		WithVariant("testdata.Circle").
+		WithVariant("*testdata.Square")
+	md.Types.AddType("testdata.Circle").
+		WithId(circleTypeId).
+		WithField("radius", "float64")
+	md.Types.AddType("*testdata.Square")
+	md.Types.AddType("testdata.Square").
+		WithId(squareTypeId).
+		WithField("side", "float64")
+
+	ctx := context.WithValue(context.Background(), utils.MetadataContextKey, md)
+
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx)
+	mod, err := rt.Instantiate(ctx, typeIdModule)
+	if err != nil {
+		t.Fatalf("failed to instantiate module: %v", err)
+	}
+
+	wa := golang.NewWasmAdapter(mod)
+	handler, err := golang.NewPlanner(md).GetHandler(ctx, "testdata.Shape")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mem := mod.Memory()
+	writeInterface := func(offset, typeId, ptr uint32) {
+		mem.WriteUint32Le(offset, typeId)
+		mem.WriteUint32Le(offset+4, ptr)
+	}
+	writeFloat := func(offset uint32, f float64) {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(f))
+		mem.Write(offset, b)
+	}
+
+	// A struct that doesn't fit in a pointer is referenced by the interface.
+	writeFloat(1000, 1.5)
+	writeInterface(100, circleTypeId, 1000)
+	assertInterfaceValue(t, ctx, handler, wa, 100, map[string]any{"radius": 1.5, langsupport.TypeNameKey: "Circle"})
+
+	// A pointer is stored in the interface.
+	writeFloat(1008, 2.5)
+	writeInterface(200, squareTypeId, 1008)
+	assertInterfaceValue(t, ctx, handler, wa, 200, map[string]any{"side": 2.5, langsupport.TypeNameKey: "Square"})
+
+	// A nil interface, and a nil pointer held by an interface, are both read as nil.
+	writeInterface(300, 0, 0)
+	assertInterfaceValue(t, ctx, handler, wa, 300, nil)
+	writeInterface(400, squareTypeId, 0)
+	assertInterfaceValue(t, ctx, handler, wa, 400, nil)
+
+	// A concrete type that isn't a variant of the interface is an error.
+	writeInterface(500, otherTypeId, 1000)
+	if _, err := handler.Read(ctx, wa, 500); err == nil {
+		t.Error("expected an error reading an interface with an unknown concrete type")
+	}
+}
+
+func assertInterfaceValue(t *testing.T, ctx context.Context, handler langsupport.TypeHandler, wa langsupport.WasmAdapter, offset uint32, expected map[string]any) {
+	t.Helper()
+
+	actual, err := handler.Read(ctx, wa, offset)
+	if err != nil {
+		t.Fatalf("unexpected error reading interface at %d: %v", offset, err)
+	}
+
+	if expected == nil {
+		if actual != nil {
+			t.Errorf("expected nil at %d, got %v", offset, actual)
+		}
+		return
+	}
+
+	m, ok := actual.(map[string]any)
+	if !ok || len(m) != len(expected) {
+		t.Fatalf("expected %v at %d, got %v", expected, offset, actual)
+	}
+	for k, v := range expected {
+		if m[k] != v {
+			t.Errorf("expected %s = %v at %d, got %v", k, v, offset, m[k])
+		}
+	}
+}
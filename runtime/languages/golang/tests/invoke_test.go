@@ -0,0 +1,70 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package golang_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hypermodeinc/modus/runtime/invoke"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func invokeFunction(t *testing.T, fnName, argsJson string) (*invoke.Output, error) {
+	ctx := context.WithValue(fixture.Context, utils.WasmHostContextKey, fixture.WasmHost)
+	fixture.WasmHost.GetFunctionRegistry().RegisterExports(ctx, fixture.Plugin)
+
+	var buf bytes.Buffer
+	err := invoke.Run(ctx, &buf, fnName, argsJson)
+	if buf.Len() == 0 {
+		return nil, err
+	}
+
+	var output invoke.Output
+	if e := json.Unmarshal(buf.Bytes(), &output); e != nil {
+		t.Fatal(e)
+	}
+	return &output, err
+}
+
+func TestInvoke_positionalArgs(t *testing.T) {
+	output, err := invokeFunction(t, "add", "[1, 2]")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output.ExecutionId == "" {
+		t.Error("expected an execution id")
+	}
+	if r, ok := output.Result.(float64); !ok || r != 3 {
+		t.Errorf("expected result 3, got %v", output.Result)
+	}
+}
+
+func TestInvoke_namedArgs(t *testing.T) {
+	output, err := invokeFunction(t, "add", `{"a": 1, "b": 2}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r, ok := output.Result.(float64); !ok || r != 3 {
+		t.Errorf("expected result 3, got %v", output.Result)
+	}
+}
+
+func TestInvoke_invalidArgs(t *testing.T) {
+	for _, args := range []string{"[1]", "1", "{"} {
+		if _, err := invokeFunction(t, "add", args); err == nil {
+			t.Errorf("expected an error for arguments %s", args)
+		}
+	}
+}
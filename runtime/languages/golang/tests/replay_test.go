@@ -0,0 +1,125 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package golang_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/functions"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+func recordFunction(t *testing.T, fnName string, paramValues ...any) *wasmhost.Recording {
+	dir := t.TempDir()
+	config.RecordDir = dir
+	defer func() { config.RecordDir = "" }()
+
+	if _, err := fixture.CallFunction(t, fnName, paramValues...); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("expected 1 recording, got %d", len(files))
+	}
+
+	rec, err := wasmhost.ReadRecording(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func replayFunction(t *testing.T, rec *wasmhost.Recording) (any, error) {
+	fnInfo, ok := functions.NewFunctionInfo(rec.Function, fixture.Plugin, false)
+	if !ok {
+		t.Fatalf("no function registered named %s", rec.Function)
+	}
+
+	ctx := wasmhost.WithReplay(fixture.Context, rec)
+	execInfo, err := fixture.WasmHost.CallFunction(ctx, fnInfo, rec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	return execInfo.Result(), nil
+}
+
+func TestReplay_add(t *testing.T) {
+	rec := recordFunction(t, "add", 1, 2)
+
+	if len(rec.Calls) != 1 {
+		t.Fatalf("expected 1 recorded call, got %d", len(rec.Calls))
+	}
+	if rec.Calls[0].HostFunction != "test.add" {
+		t.Errorf("expected call to test.add, got %s", rec.Calls[0].HostFunction)
+	}
+	if string(rec.Result) != "3" {
+		t.Errorf("expected recorded result 3, got %s", rec.Result)
+	}
+
+	// The replayed execution must use the recorded result of the host function, not call it.
+	rec.Calls[0].Results[0] = json.RawMessage("42")
+
+	result, err := replayFunction(t, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := result.(int); !ok || r != 42 {
+		t.Errorf("expected replayed result 42, got %v", result)
+	}
+}
+
+func TestReplay_echo3(t *testing.T) {
+	rec := recordFunction(t, "echo3", "hello")
+	rec.Calls[0].Results[0] = json.RawMessage(`"recorded"`)
+
+	result, err := replayFunction(t, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := result.(string); !ok || r != "recorded" {
+		t.Errorf("expected replayed result %q, got %v", "recorded", result)
+	}
+}
+
+func TestReplay_diverged(t *testing.T) {
+	rec := recordFunction(t, "add", 1, 2)
+	rec.Calls[0].HostFunction = "test.echo1"
+
+	fnInfo, _ := functions.NewFunctionInfo(rec.Function, fixture.Plugin, false)
+	ctx := wasmhost.WithReplay(fixture.Context, rec)
+	if _, err := fixture.WasmHost.CallFunction(ctx, fnInfo, rec.Parameters); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wasmhost.CheckReplay(ctx); err == nil {
+		t.Error("expected an error when the replay diverges from the recording")
+	}
+}
+
+func TestReplay_unusedCalls(t *testing.T) {
+	rec := recordFunction(t, "add", 1, 2)
+	rec.Calls = append(rec.Calls, rec.Calls[0])
+
+	fnInfo, _ := functions.NewFunctionInfo(rec.Function, fixture.Plugin, false)
+	ctx := wasmhost.WithReplay(fixture.Context, rec)
+	if _, err := fixture.WasmHost.CallFunction(ctx, fnInfo, rec.Parameters); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wasmhost.CheckReplay(ctx); err == nil {
+		t.Error("expected an error when recorded calls are not replayed")
+	}
+}
@@ -202,6 +202,10 @@ func (lti *langTypeInfo) IsTimestampType(typ string) bool {
 	return typ == "time.Time"
 }
 
+func (lti *langTypeInfo) IsFileType(typ string) bool {
+	return typ == "github.com/hypermodeinc/modus/sdk/go/pkg/files.File"
+}
+
 func (lti *langTypeInfo) GetSizeOfType(ctx context.Context, typ string) (uint32, error) {
 	switch typ {
 	case "int8", "uint8", "bool", "byte":
@@ -275,6 +279,10 @@ func (lti *langTypeInfo) getSizeOfStruct(ctx context.Context, typ string) (uint3
 	if err != nil {
 		return 0, err
 	}
+	if def.IsAbstract() {
+		// interface is a 4 byte type code and a 4 byte pointer
+		return 8, nil
+	}
 	if len(def.Fields) == 0 {
 		return 0, nil
 	}
@@ -340,6 +348,10 @@ func (lti *langTypeInfo) getAlignmentOfStruct(ctx context.Context, typ string) (
 	if err != nil {
 		return 0, err
 	}
+	if def.IsAbstract() {
+		// interfaces align to the pointer size
+		return 4, nil
+	}
 
 	max := uint32(1)
 	for _, field := range def.Fields {
@@ -398,6 +410,9 @@ func (lti *langTypeInfo) getEncodingLengthOfStruct(ctx context.Context, typ stri
 	if err != nil {
 		return 0, err
 	}
+	if def.IsAbstract() {
+		return 2, nil
+	}
 
 	total := uint32(0)
 	for _, field := range def.Fields {
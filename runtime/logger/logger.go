@@ -56,6 +56,7 @@ func Initialize() *zerolog.Logger {
 				"detail",
 				"function",
 				"execution_id",
+				"parent_execution_id",
 				"duration_ms",
 			}
 		} else {
@@ -11,6 +11,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"os"
 
 	"github.com/hypermodeinc/modus/runtime/app"
 	"github.com/hypermodeinc/modus/runtime/config"
@@ -36,8 +38,12 @@ func main() {
 		Str("environment", config.GetEnvironmentName()).
 		Msg("Starting Modus Runtime.")
 
-	err := envfiles.LoadEnvFiles(ctx)
+	cmd, err := getCommand(flag.Args())
 	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid command.")
+	}
+
+	if err := envfiles.LoadEnvFiles(ctx); err != nil {
 		log.Warn().Err(err).Msg("Failed to load environment files.")
 	}
 
@@ -46,8 +52,28 @@ func main() {
 	utils.InitSentry(rootSourcePath)
 	defer utils.FlushSentryEvents()
 
-	// Start the background services
-	ctx = services.Start(ctx)
+	// Start the background services.
+	// When running a command, the background workers are not started, so that only the command runs functions.
+	mode := services.ServerMode
+	if cmd != nil {
+		mode = services.CommandMode
+	}
+	ctx = services.Start(ctx, mode)
+
+	// Run the command instead of the HTTP server, if one was given.
+	if cmd != nil {
+		err := cmd(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Command failed.")
+		}
+		services.Stop(ctx)
+		if err != nil {
+			utils.FlushSentryEvents()
+			os.Exit(1)
+		}
+		return
+	}
+
 	defer services.Stop(ctx)
 
 	// Set local mode in development
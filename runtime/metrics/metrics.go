@@ -99,6 +99,98 @@ var (
 		[]string{"function_name"},
 	)
 
+	// FunctionErrorsNum is a counter for function executions that failed or reported errors, by function.
+	// # of series = # of functions
+	FunctionErrorsNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_function_errors_num",
+			Help: "Number of function executions that failed or reported errors",
+		},
+		[]string{"function_name"},
+	)
+
+	// FunctionLimitsExceededNum is a counter for function executions that were terminated or refused for exceeding a limit,
+	// by function and by the limit that was exceeded (memory, time or call depth).
+	// # of series = # of functions x 3
+	FunctionLimitsExceededNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_function_limits_exceeded_num",
+			Help: "Number of function executions terminated or refused for exceeding a memory, time or call depth limit",
+		},
+		[]string{"function_name", "limit"},
+	)
+
+	// GraphQLOperationsNum is a counter for GraphQL operations, by operation name, operation type and outcome.
+	// Only operation names that are listed in the manifest are reported.  Others are reported as "other".
+	// # of series = # of operations x 5
+	GraphQLOperationsNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_graphql_operations_num",
+			Help: "Number of GraphQL operations",
+		},
+		[]string{"operation_name", "operation_type", "outcome"},
+	)
+	// GraphQLOperationDurationSeconds is a histogram of latencies for GraphQL operations.
+	// # of series = # of operations x 5 x 8
+	GraphQLOperationDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "runtime_graphql_operation_duration_seconds",
+			Help:    "A histogram of latencies for GraphQL operations",
+			Buckets: []float64{.01, .05, .1, .25, .5, 1, 5, 10},
+		},
+		[]string{"operation_name", "operation_type", "outcome"},
+	)
+
+	// ModulePoolRequestsNum is a counter for module instances requested from a plugin's pool,
+	// by whether a pre-instantiated module was available (hit) or not (miss).
+	// # of series = # of plugins x 2
+	ModulePoolRequestsNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_module_pool_requests_num",
+			Help: "Number of module instances requested from the module pool",
+		},
+		[]string{"plugin", "result"},
+	)
+	// ModulePoolIdleNum is a gauge of pre-instantiated module instances that are ready for use.
+	// # of series = # of plugins
+	ModulePoolIdleNum = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "runtime_module_pool_idle_num",
+			Help: "A gauge of pre-instantiated module instances ready for use",
+		},
+		[]string{"plugin"},
+	)
+
+	// ScheduledRunsNum is a counter for runs of scheduled functions, by schedule and outcome (success, error or skipped).
+	// # of series = # of schedules x 3
+	ScheduledRunsNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_scheduled_runs_num",
+			Help: "Number of runs of scheduled functions",
+		},
+		[]string{"schedule", "outcome"},
+	)
+
+	// TriggeredRunsNum is a counter for runs of functions triggered by database notifications, by trigger and outcome (success or error).
+	// # of series = # of triggers x 2
+	TriggeredRunsNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_triggered_runs_num",
+			Help: "Number of runs of functions triggered by database notifications",
+		},
+		[]string{"trigger", "outcome"},
+	)
+
+	// JobsNum is a counter for attempts of background jobs, by function and outcome (succeeded, retrying or dead).
+	// # of series = # of functions x 3
+	JobsNum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "runtime_jobs_num",
+			Help: "Number of attempts of background jobs",
+		},
+		[]string{"function_name", "outcome"},
+	)
+
 	DroppedInferencesNum = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "runtime_dropped_inferences_num",
@@ -116,6 +208,15 @@ func init() {
 		FunctionExecutionsNum,
 		FunctionExecutionDurationMilliseconds,
 		FunctionExecutionDurationMillisecondsSummary,
+		FunctionErrorsNum,
+		FunctionLimitsExceededNum,
+		GraphQLOperationsNum,
+		GraphQLOperationDurationSeconds,
+		ModulePoolRequestsNum,
+		ModulePoolIdleNum,
+		ScheduledRunsNum,
+		TriggeredRunsNum,
+		JobsNum,
 		DroppedInferencesNum,
 	)
 }
@@ -14,11 +14,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hypermodeinc/modus/runtime/httpserver"
 	"github.com/hypermodeinc/modus/runtime/metrics"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/expfmt"
 )
 
@@ -72,6 +74,26 @@ func TestRuntimeMetrics(t *testing.T) {
 	ensureValidMetrics(t, s, 1)
 }
 
+func TestGraphQLOperationMetrics(t *testing.T) {
+
+	handler := httpserver.GetMainHandler(httpserver.WithDefaultGraphQLHandler())
+	s := httptest.NewServer(handler)
+	defer s.Close()
+
+	parseErrors := metrics.GraphQLOperationsNum.WithLabelValues("", "", "parse_error")
+	before := testutil.ToFloat64(parseErrors)
+
+	resp, err := http.Post(s.URL+graphqlEndpoint, "application/json", strings.NewReader("{"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if after := testutil.ToFloat64(parseErrors); after != before+1 {
+		t.Fatalf("expected [%v] for runtime_graphql_operations_num with parse_error outcome, got: %v", before+1, after)
+	}
+}
+
 func BenchmarkSummary(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -71,6 +71,11 @@ func initKeys(ctx context.Context) {
 }
 
 func Shutdown() {
+	// Nothing to stop if the HTTP server was never started, such as when running a command.
+	if globalAuthKeys == nil {
+		return
+	}
+
 	close(globalAuthKeys.quit)
 	<-globalAuthKeys.done
 }
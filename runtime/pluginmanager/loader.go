@@ -47,9 +47,7 @@ func monitorPlugins(ctx context.Context) {
 	}
 	sm.Changed = func(errors []error) {
 		if len(errors) == 0 {
-			plugins := globalPluginRegistry.GetAll()
-			registry := wasmhost.GetWasmHost(ctx).GetFunctionRegistry()
-			registry.RegisterAllFunctions(ctx, plugins...)
+			registerFunctions(ctx)
 		}
 	}
 	sm.Start(ctx)
@@ -92,12 +90,17 @@ func loadPlugin(ctx context.Context, filename string) error {
 	// Note, this may update the ID if a plugin with the same BuildID is in the db already.
 	db.WritePluginInfo(ctx, plugin)
 
-	// Register the plugin.
-	globalPluginRegistry.AddOrUpdate(plugin)
+	// Register the plugin.  The build it replaces, if any, is released once its in-flight executions finish.
+	activated := deployPlugin(ctx, plugin)
 
 	// Log the details of the loaded plugin.
 	logPluginLoaded(ctx, plugin)
 
+	// A canary build doesn't change the active build, so there's nothing more to do until it is promoted.
+	if !activated {
+		return nil
+	}
+
 	// Trigger the plugin loaded event.
 	err = triggerPluginLoaded(ctx, md)
 
@@ -162,6 +165,18 @@ func unloadPlugin(ctx context.Context, filename string) error {
 		Str("build_id", p.BuildId()).
 		Msg("Unloading plugin.")
 
+	globalRollout.mutex.Lock()
+	defer globalRollout.mutex.Unlock()
+
+	// Any other builds of the plugin are unloaded with it.  Each build is released once its in-flight executions finish.
 	globalPluginRegistry.Remove(p)
-	return p.Module.Close(ctx)
+	retirePlugin(ctx, p, true)
+	if canary := globalPluginRegistry.RemoveCanary(p.Name()); canary != nil {
+		retirePlugin(ctx, canary, true)
+	}
+	if previous := globalPluginRegistry.RemovePrevious(p.Name()); previous != nil {
+		retirePlugin(ctx, previous, true)
+	}
+
+	return nil
 }
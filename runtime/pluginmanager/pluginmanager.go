@@ -19,6 +19,7 @@ import (
 )
 
 func Initialize(ctx context.Context) {
+	globalRollout.ctx = ctx
 	configureLogger()
 	monitorPlugins(ctx)
 }
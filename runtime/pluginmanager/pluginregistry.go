@@ -20,31 +20,40 @@ import (
 
 // thread-safe globalPluginRegistry of all plugins that are loaded
 var globalPluginRegistry = &pluginRegistry{
-	idRevIndex: make(map[*plugins.Plugin]string),
-	idIndex:    make(map[string]*plugins.Plugin),
-	nameIndex:  make(map[string]*plugins.Plugin),
-	fileIndex:  make(map[string]*plugins.Plugin),
+	idRevIndex:    make(map[*plugins.Plugin]string),
+	idIndex:       make(map[string]*plugins.Plugin),
+	nameIndex:     make(map[string]*plugins.Plugin),
+	fileIndex:     make(map[string]*plugins.Plugin),
+	canaryIndex:   make(map[string]*plugins.Plugin),
+	previousIndex: make(map[string]*plugins.Plugin),
 }
 
 func GetRegisteredPlugins() []*plugins.Plugin {
 	return globalPluginRegistry.GetAll()
 }
 
+// The registry indexes the active build of each plugin.  A plugin can also have a canary build,
+// which is a newer build that receives a percentage of calls, and a previous build, which is kept
+// so that the plugin can be rolled back.  Those builds are indexed only by name.
 type pluginRegistry struct {
-	idRevIndex map[*plugins.Plugin]string
-	idIndex    map[string]*plugins.Plugin
-	nameIndex  map[string]*plugins.Plugin
-	fileIndex  map[string]*plugins.Plugin
-	mutex      sync.RWMutex
+	idRevIndex    map[*plugins.Plugin]string
+	idIndex       map[string]*plugins.Plugin
+	nameIndex     map[string]*plugins.Plugin
+	fileIndex     map[string]*plugins.Plugin
+	canaryIndex   map[string]*plugins.Plugin
+	previousIndex map[string]*plugins.Plugin
+	mutex         sync.RWMutex
 }
 
-func (pr *pluginRegistry) AddOrUpdate(plugin *plugins.Plugin) {
+// AddOrUpdate sets the active build of a plugin, and returns the build it replaced, if any.
+func (pr *pluginRegistry) AddOrUpdate(plugin *plugins.Plugin) *plugins.Plugin {
 	pr.mutex.Lock()
 	defer pr.mutex.Unlock()
 
 	// only one plugin per name is allowed
 	name := plugin.Name()
-	if existing, found := pr.nameIndex[name]; found {
+	existing, found := pr.nameIndex[name]
+	if found {
 		delete(pr.idRevIndex, existing)
 		delete(pr.idIndex, existing.Id)
 		delete(pr.nameIndex, name)
@@ -55,6 +64,8 @@ func (pr *pluginRegistry) AddOrUpdate(plugin *plugins.Plugin) {
 	pr.idIndex[plugin.Id] = plugin
 	pr.nameIndex[name] = plugin
 	pr.fileIndex[plugin.FileName] = plugin
+
+	return existing
 }
 
 func (pr *pluginRegistry) Remove(plugin *plugins.Plugin) {
@@ -126,3 +137,70 @@ func (pr *pluginRegistry) GetByFile(filename string) *plugins.Plugin {
 
 	return nil
 }
+
+// SetCanary sets the canary build of a plugin, and returns the canary build it replaced, if any.
+func (pr *pluginRegistry) SetCanary(plugin *plugins.Plugin) *plugins.Plugin {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	name := plugin.Name()
+	existing := pr.canaryIndex[name]
+	pr.canaryIndex[name] = plugin
+	return existing
+}
+
+// RemoveCanary removes the canary build of a plugin, and returns it, if any.
+func (pr *pluginRegistry) RemoveCanary(name string) *plugins.Plugin {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	existing := pr.canaryIndex[name]
+	delete(pr.canaryIndex, name)
+	return existing
+}
+
+func (pr *pluginRegistry) GetCanary(name string) *plugins.Plugin {
+	pr.mutex.RLock()
+	defer pr.mutex.RUnlock()
+
+	return pr.canaryIndex[name]
+}
+
+func (pr *pluginRegistry) GetCanaries() []*plugins.Plugin {
+	pr.mutex.RLock()
+	defer pr.mutex.RUnlock()
+
+	result := utils.MapValues(pr.canaryIndex)
+	slices.SortFunc(result, func(a, b *plugins.Plugin) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	return result
+}
+
+// SetPrevious sets the previous build of a plugin, and returns the previous build it replaced, if any.
+func (pr *pluginRegistry) SetPrevious(plugin *plugins.Plugin) *plugins.Plugin {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	name := plugin.Name()
+	existing := pr.previousIndex[name]
+	pr.previousIndex[name] = plugin
+	return existing
+}
+
+// RemovePrevious removes the previous build of a plugin, and returns it, if any.
+func (pr *pluginRegistry) RemovePrevious(name string) *plugins.Plugin {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	existing := pr.previousIndex[name]
+	delete(pr.previousIndex, name)
+	return existing
+}
+
+func (pr *pluginRegistry) GetPrevious(name string) *plugins.Plugin {
+	pr.mutex.RLock()
+	defer pr.mutex.RUnlock()
+
+	return pr.previousIndex[name]
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pluginmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+// PluginBuilds describes the builds of a plugin that are loaded.
+type PluginBuilds struct {
+	Name     string `json:"name"`
+	Active   string `json:"active"`
+	Canary   string `json:"canary,omitempty"`
+	Previous string `json:"previous,omitempty"`
+}
+
+var globalRollout = &rollout{}
+
+// rollout coordinates changes to the builds of plugins, which can come from the storage monitor
+// or from the admin API.
+type rollout struct {
+	ctx   context.Context
+	mutex sync.Mutex
+
+	// retiring holds the builds that are retired once the functions are registered without them.
+	retiring      []retirement
+	retiringMutex sync.Mutex
+}
+
+type retirement struct {
+	plugin      *plugins.Plugin
+	closeModule bool
+}
+
+// deployPlugin makes a newly loaded build of a plugin available.  If another build of the plugin is active
+// and canary rollouts are enabled, the new build becomes the canary, and false is returned.
+// Otherwise the new build becomes active immediately, and true is returned.
+func deployPlugin(ctx context.Context, plugin *plugins.Plugin) bool {
+	globalRollout.mutex.Lock()
+	defer globalRollout.mutex.Unlock()
+
+	canary := config.CanaryPercent > 0 && config.CanaryPercent < 100 &&
+		globalPluginRegistry.GetByName(plugin.Name()) != nil
+
+	if canary {
+		if replaced := globalPluginRegistry.SetCanary(plugin); replaced != nil {
+			retirePlugin(ctx, replaced, true)
+		}
+		logger.Info(ctx).
+			Str("plugin", plugin.Name()).
+			Str("build_id", plugin.BuildId()).
+			Int("percent", config.CanaryPercent).
+			Msg("Deployed plugin build as a canary.")
+		return false
+	}
+
+	if replaced := globalPluginRegistry.RemoveCanary(plugin.Name()); replaced != nil {
+		retirePlugin(ctx, replaced, true)
+	}
+	activatePlugin(ctx, plugin)
+	return true
+}
+
+// activatePlugin makes a build of a plugin active.  The build it replaces is kept as the previous build,
+// so that the plugin can be rolled back to it.
+func activatePlugin(ctx context.Context, plugin *plugins.Plugin) {
+	replaced := globalPluginRegistry.AddOrUpdate(plugin)
+	if replaced == nil {
+		return
+	}
+
+	if discarded := globalPluginRegistry.SetPrevious(replaced); discarded != nil {
+		retirePlugin(ctx, discarded, true)
+	}
+	retirePlugin(ctx, replaced, false)
+}
+
+// PromotePlugin makes the canary build of a plugin active, so that it receives all calls.
+func PromotePlugin(name string) error {
+	ctx := globalRollout.ctx
+
+	globalRollout.mutex.Lock()
+	defer globalRollout.mutex.Unlock()
+
+	plugin := globalPluginRegistry.RemoveCanary(name)
+	if plugin == nil {
+		return fmt.Errorf("plugin %s has no canary build", name)
+	}
+
+	activatePlugin(ctx, plugin)
+
+	logger.Info(ctx).
+		Str("plugin", name).
+		Str("build_id", plugin.BuildId()).
+		Msg("Promoted canary plugin build.")
+
+	return applyRollout(ctx, plugin)
+}
+
+// RollbackPlugin discards the canary build of a plugin, if it has one.  Otherwise it makes the previous build
+// of the plugin active again, and discards the build that was active.
+func RollbackPlugin(name string) error {
+	ctx := globalRollout.ctx
+
+	globalRollout.mutex.Lock()
+	defer globalRollout.mutex.Unlock()
+
+	if canary := globalPluginRegistry.RemoveCanary(name); canary != nil {
+		logger.Info(ctx).
+			Str("plugin", name).
+			Str("build_id", canary.BuildId()).
+			Msg("Rolled back canary plugin build.")
+
+		retirePlugin(ctx, canary, true)
+		registerFunctions(ctx)
+		return nil
+	}
+
+	previous := globalPluginRegistry.RemovePrevious(name)
+	if previous == nil {
+		return fmt.Errorf("plugin %s has no previous build to roll back to", name)
+	}
+
+	cancelRetirement(previous)
+	wasmhost.GetWasmHost(ctx).RestorePlugin(ctx, previous)
+	if replaced := globalPluginRegistry.AddOrUpdate(previous); replaced != nil {
+		retirePlugin(ctx, replaced, true)
+	}
+
+	logger.Info(ctx).
+		Str("plugin", name).
+		Str("build_id", previous.BuildId()).
+		Msg("Rolled back to previous plugin build.")
+
+	return applyRollout(ctx, previous)
+}
+
+// GetPluginBuilds returns the builds of each plugin that are loaded.
+func GetPluginBuilds() []PluginBuilds {
+	active := globalPluginRegistry.GetAll()
+	results := make([]PluginBuilds, len(active))
+	for i, p := range active {
+		name := p.Name()
+		results[i] = PluginBuilds{Name: name, Active: p.BuildId()}
+		if canary := globalPluginRegistry.GetCanary(name); canary != nil {
+			results[i].Canary = canary.BuildId()
+		}
+		if previous := globalPluginRegistry.GetPrevious(name); previous != nil {
+			results[i].Previous = previous.BuildId()
+		}
+	}
+	return results
+}
+
+// applyRollout updates the functions after the active build of a plugin has changed.
+func applyRollout(ctx context.Context, plugin *plugins.Plugin) error {
+	registerFunctions(ctx)
+	return triggerPluginLoaded(ctx, plugin.Metadata)
+}
+
+// registerFunctions registers the functions of the active builds of all plugins, and of any canary builds.
+// The builds that were replaced are then retired, since calls can no longer look up their functions.
+func registerFunctions(ctx context.Context) {
+	registry := wasmhost.GetWasmHost(ctx).GetFunctionRegistry()
+	registry.RegisterAllFunctions(ctx, globalPluginRegistry.GetAll()...)
+	registry.RegisterCanaryFunctions(ctx, config.CanaryPercent, globalPluginRegistry.GetCanaries()...)
+
+	globalRollout.retiringMutex.Lock()
+	retiring := globalRollout.retiring
+	globalRollout.retiring = nil
+	globalRollout.retiringMutex.Unlock()
+
+	for _, r := range retiring {
+		startRetirement(ctx, r.plugin, r.closeModule)
+	}
+}
+
+// retirePlugin releases a build of a plugin that no longer receives calls, once its functions are no longer
+// registered and the calls that are using it have ended.  Its compiled module is also closed, unless the build
+// is kept for a rollback.
+func retirePlugin(ctx context.Context, plugin *plugins.Plugin, closeModule bool) {
+	globalRollout.retiringMutex.Lock()
+	defer globalRollout.retiringMutex.Unlock()
+	globalRollout.retiring = append(globalRollout.retiring, retirement{plugin, closeModule})
+}
+
+// cancelRetirement keeps a build from being retired, when it is made active again before it was retired.
+func cancelRetirement(plugin *plugins.Plugin) {
+	globalRollout.retiringMutex.Lock()
+	defer globalRollout.retiringMutex.Unlock()
+	globalRollout.retiring = slices.DeleteFunc(globalRollout.retiring, func(r retirement) bool {
+		return r.plugin == plugin
+	})
+}
+
+func startRetirement(ctx context.Context, plugin *plugins.Plugin, closeModule bool) {
+	go func() {
+		if err := wasmhost.RetirePlugin(ctx, plugin); err != nil {
+			return
+		}
+
+		wasmhost.GetWasmHost(ctx).ReleasePlugin(ctx, plugin)
+		if !closeModule {
+			return
+		}
+
+		if err := plugin.Module.Close(ctx); err != nil {
+			logger.Err(ctx, err).
+				Str("plugin", plugin.Name()).
+				Str("build_id", plugin.BuildId()).
+				Msg("Failed to close plugin module.")
+			return
+		}
+
+		logger.Info(ctx).
+			Str("plugin", plugin.Name()).
+			Str("build_id", plugin.BuildId()).
+			Msg("Released plugin build.")
+	}()
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pluginmanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWasmFile = "testdata.wasm"
+
+var testWasmPath = filepath.Join("..", "languages", "golang", "testdata", "build", testWasmFile)
+
+func setupRollout(t *testing.T, canaryPercent int) context.Context {
+	ctx := context.Background()
+	host := wasmhost.NewWasmHost(ctx)
+	ctx = context.WithValue(ctx, utils.WasmHostContextKey, host)
+
+	globalPluginRegistry = &pluginRegistry{
+		idRevIndex:    make(map[*plugins.Plugin]string),
+		idIndex:       make(map[string]*plugins.Plugin),
+		nameIndex:     make(map[string]*plugins.Plugin),
+		fileIndex:     make(map[string]*plugins.Plugin),
+		canaryIndex:   make(map[string]*plugins.Plugin),
+		previousIndex: make(map[string]*plugins.Plugin),
+	}
+	globalRollout.ctx = ctx
+
+	config.CanaryPercent = canaryPercent
+	t.Cleanup(func() {
+		config.CanaryPercent = 0
+		host.Close(ctx)
+	})
+
+	return ctx
+}
+
+func newTestPlugin(t *testing.T, ctx context.Context, buildId string) *plugins.Plugin {
+	content, err := os.ReadFile(testWasmPath)
+	require.NoError(t, err)
+
+	cm, err := wasmhost.GetWasmHost(ctx).CompileModule(ctx, content)
+	require.NoError(t, err)
+
+	md, err := metadata.GetMetadataFromWasm(content)
+	require.NoError(t, err)
+	md.BuildId = buildId
+
+	plugin, err := plugins.NewPlugin(ctx, cm, testWasmFile, md)
+	require.NoError(t, err)
+	return plugin
+}
+
+func getBuilds(t *testing.T) PluginBuilds {
+	builds := GetPluginBuilds()
+	require.Len(t, builds, 1)
+	return builds[0]
+}
+
+func TestRollout_Rollback(t *testing.T) {
+	ctx := setupRollout(t, 0)
+
+	p1 := newTestPlugin(t, ctx, "build1")
+	p2 := newTestPlugin(t, ctx, "build2")
+	name := p1.Name()
+
+	assert.True(t, deployPlugin(ctx, p1))
+	assert.True(t, deployPlugin(ctx, p2))
+	registerFunctions(ctx)
+
+	assert.Equal(t, PluginBuilds{Name: name, Active: "build2", Previous: "build1"}, getBuilds(t))
+
+	fnInfo, err := wasmhost.GetWasmHost(ctx).GetFunctionInfo("add")
+	require.NoError(t, err)
+	assert.Same(t, p2, fnInfo.Plugin())
+
+	require.NoError(t, RollbackPlugin(name))
+	assert.Equal(t, PluginBuilds{Name: name, Active: "build1"}, getBuilds(t))
+
+	fnInfo, err = wasmhost.GetWasmHost(ctx).GetFunctionInfo("add")
+	require.NoError(t, err)
+	assert.Same(t, p1, fnInfo.Plugin())
+
+	assert.Error(t, RollbackPlugin(name))
+	assert.Error(t, PromotePlugin(name))
+}
+
+func TestRollout_Canary(t *testing.T) {
+	ctx := setupRollout(t, 50)
+
+	p1 := newTestPlugin(t, ctx, "build1")
+	p2 := newTestPlugin(t, ctx, "build2")
+	name := p1.Name()
+
+	// The first build of a plugin is always active.
+	assert.True(t, deployPlugin(ctx, p1))
+	assert.False(t, deployPlugin(ctx, p2))
+	registerFunctions(ctx)
+
+	assert.Equal(t, PluginBuilds{Name: name, Active: "build1", Canary: "build2"}, getBuilds(t))
+
+	counts := make(map[*plugins.Plugin]int)
+	for range 1000 {
+		fnInfo, err := wasmhost.GetWasmHost(ctx).GetFunctionInfo("add")
+		require.NoError(t, err)
+		counts[fnInfo.Plugin()]++
+	}
+	assert.InDelta(t, 500, counts[p1], 100)
+	assert.InDelta(t, 500, counts[p2], 100)
+
+	require.NoError(t, PromotePlugin(name))
+	assert.Equal(t, PluginBuilds{Name: name, Active: "build2", Previous: "build1"}, getBuilds(t))
+
+	fnInfo, err := wasmhost.GetWasmHost(ctx).GetFunctionInfo("add")
+	require.NoError(t, err)
+	assert.Same(t, p2, fnInfo.Plugin())
+
+	// Rolling back a canary discards it, and keeps the active build.
+	p3 := newTestPlugin(t, ctx, "build3")
+	assert.False(t, deployPlugin(ctx, p3))
+	require.NoError(t, RollbackPlugin(name))
+	assert.Equal(t, PluginBuilds{Name: name, Active: "build2", Previous: "build1"}, getBuilds(t))
+}
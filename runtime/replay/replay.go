@@ -0,0 +1,124 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/functions"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+const pluginLoadTimeout = 30 * time.Second
+const pluginPollInterval = 100 * time.Millisecond
+
+// Run replays a recorded function execution, using the recorded results of its host function calls
+// instead of calling the host functions.  The plugin build that made the recording must be loaded.
+// The result of the replayed execution is written to stdout.
+func Run(ctx context.Context, path string) error {
+	rec, err := wasmhost.ReadRecording(path)
+	if err != nil {
+		return err
+	}
+
+	host := wasmhost.GetWasmHost(ctx)
+	fnInfo, err := waitForFunction(ctx, host, rec)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx).
+		Str("recorded_execution_id", rec.ExecutionId).
+		Str("function", rec.Function).
+		Int("host_calls", len(rec.Calls)).
+		Msg("Replaying function execution.")
+
+	ctx = wasmhost.WithReplay(ctx, rec)
+	execInfo, err := host.CallFunction(ctx, fnInfo, rec.Parameters)
+
+	// A replay that diverged from the recording doesn't reproduce the recorded execution.
+	if replayErr := wasmhost.CheckReplay(ctx); replayErr != nil {
+		return replayErr
+	}
+
+	if err != nil {
+		if err.Error() == rec.Error {
+			logger.Info(ctx).Msg("The replayed execution failed with the same error as the recording.")
+		} else {
+			logger.Warn(ctx).Str("recorded_error", rec.Error).Msg("The replayed execution failed differently than the recording.")
+		}
+		return err
+	}
+
+	result, err := utils.JsonSerialize(execInfo.Result())
+	if err != nil {
+		return fmt.Errorf("failed to serialize result: %w", err)
+	}
+
+	if rec.Error != "" {
+		logger.Warn(ctx).Str("recorded_error", rec.Error).Msg("The replayed execution succeeded, but the recording failed.")
+	} else if equal, err := jsonEqual(result, rec.Result); err != nil {
+		return err
+	} else if equal {
+		logger.Info(ctx).Msg("The replayed result matches the recording.")
+	} else {
+		logger.Warn(ctx).RawJSON("recorded_result", rec.Result).Msg("The replayed result differs from the recording.")
+	}
+
+	fmt.Println(string(result))
+	return nil
+}
+
+// waitForFunction waits for the plugin build that made the recording to be loaded, and returns the recorded function.
+func waitForFunction(ctx context.Context, host wasmhost.WasmHost, rec *wasmhost.Recording) (functions.FunctionInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, pluginLoadTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pluginPollInterval)
+	defer ticker.Stop()
+
+	for {
+		fnInfo, err := host.GetFunctionInfo(rec.Function)
+		if err == nil && fnInfo.Plugin().BuildId() == rec.PluginBuildId {
+			return fnInfo, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return nil, fmt.Errorf("function %s was not loaded: %w", rec.Function, err)
+			}
+			return nil, fmt.Errorf("the recording was made with build %s of plugin %s, but build %s is loaded",
+				rec.PluginBuildId, rec.PluginName, fnInfo.Plugin().BuildId())
+		case <-ticker.C:
+		}
+	}
+}
+
+func jsonEqual(a, b []byte) (bool, error) {
+	if len(b) == 0 {
+		b = []byte("null")
+	}
+
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
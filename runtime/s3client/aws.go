@@ -0,0 +1,181 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	hmAws "github.com/hypermodeinc/modus/runtime/aws"
+	"github.com/hypermodeinc/modus/runtime/secrets"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// awsBucket holds objects in an S3 bucket, or in a bucket of an S3-compatible service.
+type awsBucket struct {
+	client        *s3.Client
+	presignClient *s3.PresignClient
+	bucket        string
+}
+
+func newAwsBucket(ctx context.Context, connection manifest.S3ConnectionInfo) (*awsBucket, error) {
+	cfg, err := hmAws.LoadAwsConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if connection.Region != "" {
+		cfg.Region = connection.Region
+	}
+
+	if connection.AccessKeyId != "" {
+		accessKeyId, err := secrets.ApplySecretsToString(ctx, connection, connection.AccessKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		secretAccessKey, err := secrets.ApplySecretsToString(ctx, connection, connection.SecretAccessKey)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Credentials = credentials.NewStaticCredentialsProvider(accessKeyId, secretAccessKey, "")
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if connection.Endpoint != "" {
+			// S3-compatible services generally don't support virtual-hosted-style addressing.
+			o.BaseEndpoint = aws.String(connection.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &awsBucket{
+		client:        client,
+		presignClient: s3.NewPresignClient(client),
+		bucket:        connection.Bucket,
+	}, nil
+}
+
+func (b *awsBucket) list(ctx context.Context, prefix, startAfter string, limit int) ([]*ObjectInfo, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  &b.bucket,
+		Prefix:  &prefix,
+		MaxKeys: aws.Int32(int32(limit)),
+	}
+	if startAfter != "" {
+		input.StartAfter = &startAfter
+	}
+
+	result, err := b.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in S3 bucket: %w", err)
+	}
+
+	objects := make([]*ObjectInfo, len(result.Contents))
+	for i, obj := range result.Contents {
+		objects[i] = &ObjectInfo{
+			Key:          aws.ToString(obj.Key),
+			Size:         aws.ToInt64(obj.Size),
+			ETag:         aws.ToString(obj.ETag),
+			LastModified: obj.LastModified,
+		}
+	}
+
+	return objects, nil
+}
+
+func (b *awsBucket) get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &b.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, errObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get object %s from S3: %w", key, err)
+	}
+
+	defer obj.Body.Close()
+	content, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contents of object %s from S3: %w", key, err)
+	}
+
+	return content, nil
+}
+
+func (b *awsBucket) put(ctx context.Context, key string, content []byte, contentType string) (*ObjectInfo, error) {
+	input := &s3.PutObjectInput{
+		Bucket:        &b.bucket,
+		Key:           &key,
+		Body:          bytes.NewReader(content),
+		ContentLength: aws.Int64(int64(len(content))),
+	}
+	if contentType != "" {
+		input.ContentType = &contentType
+	}
+
+	result, err := b.client.PutObject(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put object %s to S3: %w", key, err)
+	}
+
+	return &ObjectInfo{
+		Key:  key,
+		Size: int64(len(content)),
+		ETag: aws.ToString(result.ETag),
+	}, nil
+}
+
+func (b *awsBucket) delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &b.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s from S3: %w", key, err)
+	}
+	return nil
+}
+
+func (b *awsBucket) presign(ctx context.Context, method, key string, expires time.Duration) (string, error) {
+	var req *v4.PresignedHTTPRequest
+	var err error
+	switch method {
+	case "GET":
+		req, err = b.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: &b.bucket,
+			Key:    &key,
+		}, s3.WithPresignExpires(expires))
+	case "PUT":
+		req, err = b.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: &b.bucket,
+			Key:    &key,
+		}, s3.WithPresignExpires(expires))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s URL for object %s: %w", method, key, err)
+	}
+
+	return req.URL, nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// localBucket holds objects as files in a local directory, for use in development.
+// Each object key is a slash-separated path, relative to the root directory.
+type localBucket struct {
+	root string
+}
+
+func (b *localBucket) getPath(key string) (string, error) {
+	if !fs.ValidPath(key) || strings.HasSuffix(key, "/") {
+		return "", fmt.Errorf("object key %s is not valid for a local directory", key)
+	}
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
+
+func (b *localBucket) list(ctx context.Context, prefix, startAfter string, limit int) ([]*ObjectInfo, error) {
+	objects := make([]*ObjectInfo, 0)
+	err := filepath.WalkDir(b.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == b.root {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, p)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) || key <= startAfter {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		modTime := fi.ModTime().UTC()
+		objects = append(objects, &ObjectInfo{
+			Key:          key,
+			Size:         fi.Size(),
+			LastModified: &modTime,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in local directory: %w", err)
+	}
+
+	// The directory is walked in lexical order of each path element, which can differ from the order of the full keys.
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	if len(objects) > limit {
+		objects = objects[:limit]
+	}
+	return objects, nil
+}
+
+func (b *localBucket) get(ctx context.Context, key string) ([]byte, error) {
+	p, err := b.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errObjectNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read object %s from local directory: %w", key, err)
+	}
+
+	return content, nil
+}
+
+func (b *localBucket) put(ctx context.Context, key string, content []byte, contentType string) (*ObjectInfo, error) {
+	p, err := b.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for object %s: %w", key, err)
+	}
+
+	if err := os.WriteFile(p, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write object %s to local directory: %w", key, err)
+	}
+
+	modTime := time.Now().UTC()
+	return &ObjectInfo{
+		Key:          key,
+		Size:         int64(len(content)),
+		LastModified: &modTime,
+	}, nil
+}
+
+func (b *localBucket) delete(ctx context.Context, key string) error {
+	p, err := b.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object %s from local directory: %w", key, err)
+	}
+	return nil
+}
+
+// presign returns a file URL for the object, since there is no server for the local directory.
+// The URL is only usable on the machine that is running the runtime.
+func (b *localBucket) presign(ctx context.Context, method, key string, expires time.Duration) (string, error) {
+	p, err := b.getPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}
+	return u.String(), nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3client
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+var s3r = newS3Registry()
+
+type s3Registry struct {
+	cache *xsync.MapOf[string, *s3Connection]
+}
+
+type s3Connection struct {
+	bucket bucket
+	prefix string
+}
+
+func newS3Registry() *s3Registry {
+	return &s3Registry{
+		cache: xsync.NewMapOf[string, *s3Connection](),
+	}
+}
+
+func (sr *s3Registry) clear() {
+	sr.cache.Clear()
+}
+
+func (sr *s3Registry) getConnection(ctx context.Context, name string) (*s3Connection, error) {
+	var creationErr error
+	conn, _ := sr.cache.LoadOrCompute(name, func() *s3Connection {
+		conn, err := createConnection(ctx, name)
+		if err != nil {
+			creationErr = err
+			return nil
+		}
+		return conn
+	})
+
+	if creationErr != nil {
+		sr.cache.Delete(name)
+		return nil, creationErr
+	}
+
+	return conn, nil
+}
+
+func createConnection(ctx context.Context, name string) (*s3Connection, error) {
+	man := manifestdata.GetManifest()
+	info, ok := man.Connections[name]
+	if !ok {
+		return nil, fmt.Errorf("S3 connection [%s] not found", name)
+	}
+
+	if info.ConnectionType() != manifest.ConnectionTypeS3 {
+		return nil, fmt.Errorf("[%s] is not an S3 connection", name)
+	}
+
+	connection := info.(manifest.S3ConnectionInfo)
+
+	// In development, a local directory can be used in place of the bucket.
+	if connection.LocalDir != "" && config.IsDevEnvironment() {
+		dir := connection.LocalDir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(config.AppPath, dir)
+		}
+
+		logger.Info(ctx).
+			Str("connection", name).
+			Str("path", dir).
+			Msg("Using local directory for S3 connection.")
+
+		return &s3Connection{
+			bucket: &localBucket{root: dir},
+			prefix: connection.Prefix,
+		}, nil
+	}
+
+	if connection.Bucket == "" {
+		if connection.LocalDir != "" {
+			return nil, fmt.Errorf("[%s] has no bucket, and its local directory can only be used in development", name)
+		}
+		return nil, fmt.Errorf("[%s] has empty required fields: %v", name, []string{"Bucket"})
+	}
+
+	b, err := newAwsBucket(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Connection{
+		bucket: b,
+		prefix: connection.Prefix,
+	}, nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+)
+
+const defaultListLimit = 1000
+const maxListLimit = 1000
+
+const defaultPresignExpiration = 15 * time.Minute
+const maxPresignExpiration = 7 * 24 * time.Hour
+
+const maxKeyLength = 1024
+
+var errObjectNotFound = errors.New("object not found")
+
+type ObjectInfo struct {
+	Key          string     `json:"key"`
+	Size         int64      `json:"size"`
+	ETag         string     `json:"etag,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+// bucket is the interface for a backend that holds the objects of a connection.
+// Keys passed to the backend already include the prefix of the connection.
+type bucket interface {
+	list(ctx context.Context, prefix, startAfter string, limit int) ([]*ObjectInfo, error)
+	get(ctx context.Context, key string) ([]byte, error)
+	put(ctx context.Context, key string, content []byte, contentType string) (*ObjectInfo, error)
+	delete(ctx context.Context, key string) error
+	presign(ctx context.Context, method, key string, expires time.Duration) (string, error)
+}
+
+func Initialize() {
+	manifestdata.RegisterManifestLoadedCallback(func(ctx context.Context) error {
+		s3r.clear()
+		return nil
+	})
+}
+
+// ListObjects returns the objects whose keys start with the given prefix, in key order.
+// If startAfter is provided, only objects whose keys sort after it are returned, which can be used to page through the results.
+func ListObjects(ctx context.Context, connection, prefix, startAfter string, limit int32) ([]*ObjectInfo, error) {
+	conn, err := s3r.getConnection(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int(limit)
+	if n <= 0 {
+		n = defaultListLimit
+	} else if n > maxListLimit {
+		return nil, fmt.Errorf("limit must not exceed %d", maxListLimit)
+	}
+
+	if startAfter != "" {
+		startAfter = conn.prefix + startAfter
+	}
+
+	objects, err := conn.bucket.list(ctx, conn.prefix+prefix, startAfter, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		obj.Key = strings.TrimPrefix(obj.Key, conn.prefix)
+	}
+	return objects, nil
+}
+
+// GetObject returns the content of the object with the given key.
+func GetObject(ctx context.Context, connection, key string) ([]byte, error) {
+	conn, err := s3r.getConnection(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	content, err := conn.bucket.get(ctx, conn.prefix+key)
+	if errors.Is(err, errObjectNotFound) {
+		return nil, fmt.Errorf("object [%s] not found in connection [%s]", key, connection)
+	}
+	return content, err
+}
+
+// PutObject writes the content to the object with the given key, replacing any existing object.
+func PutObject(ctx context.Context, connection, key string, content []byte, contentType string) (*ObjectInfo, error) {
+	conn, err := s3r.getConnection(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	info, err := conn.bucket.put(ctx, conn.prefix+key, content, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Key = key
+	return info, nil
+}
+
+// DeleteObject deletes the object with the given key.  It is not an error if the object does not exist.
+func DeleteObject(ctx context.Context, connection, key string) error {
+	conn, err := s3r.getConnection(ctx, connection)
+	if err != nil {
+		return err
+	}
+
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	return conn.bucket.delete(ctx, conn.prefix+key)
+}
+
+// PresignURL returns a URL that can be used without credentials to get (GET) or put (PUT) the object with the given key,
+// until it expires.  If expiresInSeconds is zero or less, the URL expires after 15 minutes.
+func PresignURL(ctx context.Context, connection, method, key string, expiresInSeconds int32) (string, error) {
+	conn, err := s3r.getConnection(ctx, connection)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	method = strings.ToUpper(method)
+	if method != "GET" && method != "PUT" {
+		return "", fmt.Errorf("unsupported method for presigned URL: %s", method)
+	}
+
+	expires := defaultPresignExpiration
+	if expiresInSeconds > 0 {
+		expires = time.Duration(expiresInSeconds) * time.Second
+		if expires > maxPresignExpiration {
+			return "", fmt.Errorf("presigned URLs must not expire later than %s", maxPresignExpiration)
+		}
+	}
+
+	return conn.bucket.presign(ctx, method, conn.prefix+key, expires)
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("object key must not be empty")
+	}
+	if len(key) > maxKeyLength {
+		return fmt.Errorf("object key must not be longer than %d bytes", maxKeyLength)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useLocalConnection(t *testing.T, name, prefix string) string {
+	root := t.TempDir()
+	s3r.cache.Store(name, &s3Connection{
+		bucket: &localBucket{root: root},
+		prefix: prefix,
+	})
+	t.Cleanup(s3r.clear)
+	return root
+}
+
+func TestLocalObjects(t *testing.T) {
+	ctx := context.Background()
+	root := useLocalConnection(t, "documents", "uploads/")
+
+	info, err := PutObject(ctx, "documents", "reports/2024.pdf", []byte("%PDF-1.7"), "application/pdf")
+	require.NoError(t, err)
+	assert.Equal(t, "reports/2024.pdf", info.Key)
+	assert.Equal(t, int64(8), info.Size)
+
+	// The prefix of the connection is applied to the stored keys.
+	_, err = os.Stat(filepath.Join(root, "uploads", "reports", "2024.pdf"))
+	require.NoError(t, err)
+
+	content, err := GetObject(ctx, "documents", "reports/2024.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("%PDF-1.7"), content)
+
+	_, err = GetObject(ctx, "documents", "reports/missing.pdf")
+	assert.ErrorContains(t, err, "not found")
+
+	require.NoError(t, DeleteObject(ctx, "documents", "reports/2024.pdf"))
+	require.NoError(t, DeleteObject(ctx, "documents", "reports/2024.pdf"))
+
+	_, err = GetObject(ctx, "documents", "reports/2024.pdf")
+	assert.Error(t, err)
+}
+
+func TestLocalListObjects(t *testing.T) {
+	ctx := context.Background()
+	useLocalConnection(t, "documents", "")
+
+	for _, key := range []string{"a/b.txt", "a-b.txt", "a/c.txt", "b.txt"} {
+		_, err := PutObject(ctx, "documents", key, []byte(key), "")
+		require.NoError(t, err)
+	}
+
+	objects, err := ListObjects(ctx, "documents", "a", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a-b.txt", "a/b.txt", "a/c.txt"}, getKeys(objects))
+	assert.NotNil(t, objects[0].LastModified)
+
+	objects, err = ListObjects(ctx, "documents", "", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a-b.txt", "a/b.txt"}, getKeys(objects))
+
+	objects, err = ListObjects(ctx, "documents", "", "a/b.txt", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/c.txt", "b.txt"}, getKeys(objects))
+
+	_, err = ListObjects(ctx, "documents", "", "", maxListLimit+1)
+	assert.Error(t, err)
+}
+
+func TestLocalInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	root := useLocalConnection(t, "documents", "")
+
+	for _, key := range []string{"", "../outside.txt", "/etc/passwd", "a/../../outside.txt", "dir/"} {
+		_, err := PutObject(ctx, "documents", key, []byte("x"), "")
+		assert.Error(t, err, key)
+	}
+
+	_, err := os.Stat(filepath.Join(filepath.Dir(root), "outside.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLocalPresignURL(t *testing.T) {
+	ctx := context.Background()
+	useLocalConnection(t, "documents", "uploads/")
+
+	u, err := PresignURL(ctx, "documents", "get", "report.pdf", 0)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(u, "file://"), u)
+	assert.True(t, strings.HasSuffix(u, "/uploads/report.pdf"), u)
+
+	_, err = PresignURL(ctx, "documents", "DELETE", "report.pdf", 0)
+	assert.Error(t, err)
+
+	_, err = PresignURL(ctx, "documents", "GET", "report.pdf", 8*24*60*60)
+	assert.Error(t, err)
+}
+
+func getKeys(objects []*ObjectInfo) []string {
+	keys := make([]string, len(objects))
+	for i, obj := range objects {
+		keys[i] = obj.Key
+	}
+	return keys
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/db"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+	"github.com/hypermodeinc/modus/runtime/utils"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+	outcomeSkipped = "skipped"
+)
+
+var globalScheduler = &scheduler{
+	jobs: make(map[string]*job),
+}
+
+type scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   map[string]*job
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+}
+
+type job struct {
+	info     manifest.ScheduleInfo
+	schedule *cronSchedule
+	quit     chan struct{}
+	running  atomic.Bool
+}
+
+// Initialize starts running the functions scheduled in the manifest, and updates the schedules whenever the manifest changes.
+func Initialize(ctx context.Context) {
+	globalScheduler.ctx, globalScheduler.cancel = context.WithCancel(ctx)
+	manifestdata.RegisterManifestLoadedCallback(globalScheduler.update)
+}
+
+// Shutdown stops all schedules, and waits for any runs in progress to end.
+func Shutdown(ctx context.Context) {
+	s := globalScheduler
+	if s.cancel == nil {
+		return
+	}
+
+	s.mu.Lock()
+	for name, j := range s.jobs {
+		close(j.quit)
+		delete(s.jobs, name)
+	}
+	s.mu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *scheduler) update(ctx context.Context) error {
+	schedules := manifestdata.GetManifest().Schedules
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Stop any schedules that were removed or changed.
+	for name, j := range s.jobs {
+		if info, ok := schedules[name]; !ok || !reflect.DeepEqual(info, j.info) {
+			close(j.quit)
+			delete(s.jobs, name)
+			logger.Info(ctx).Str("schedule", name).Msg("Stopped schedule.")
+		}
+	}
+
+	// Start any schedules that were added or changed.
+	for name, info := range schedules {
+		if _, ok := s.jobs[name]; ok {
+			continue
+		}
+
+		schedule, err := parseCronExpression(info.Cron)
+		if err != nil {
+			logger.Error(ctx).Err(err).
+				Str("schedule", name).
+				Str("function", info.Function).
+				Bool("user_visible", true).
+				Msg("Invalid schedule in manifest.  The function will not be run.")
+			continue
+		}
+
+		j := &job{
+			info:     info,
+			schedule: schedule,
+			quit:     make(chan struct{}),
+		}
+		s.jobs[name] = j
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.worker(j)
+		}()
+
+		logger.Info(ctx).
+			Str("schedule", name).
+			Str("function", info.Function).
+			Str("cron", info.Cron).
+			Msg("Started schedule.")
+	}
+
+	return nil
+}
+
+func (s *scheduler) worker(j *job) {
+	ctx := s.ctx
+	for {
+		now := time.Now().UTC()
+		next := j.schedule.next(now)
+		if next.IsZero() {
+			logger.Warn(ctx).Str("schedule", j.info.Name).Msg("Schedule has no future runs.")
+			return
+		}
+
+		// Jitter spreads out the load when many schedules fire at the same time.
+		delay := next.Sub(now)
+		if j.info.JitterSeconds > 0 {
+			delay += rand.N(time.Duration(j.info.JitterSeconds) * time.Second)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-j.quit:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Run in the background, so that a long run doesn't delay the next one from being scheduled.
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			j.run(ctx, next)
+		}()
+	}
+}
+
+func (j *job) run(ctx context.Context, scheduledAt time.Time) {
+	name := j.info.Name
+	fnName := j.info.Function
+
+	// Don't let runs of the same schedule overlap on this instance.
+	if !j.running.CompareAndSwap(false, true) {
+		logger.Warn(ctx).
+			Str("schedule", name).
+			Str("function", fnName).
+			Bool("user_visible", true).
+			Msg("Skipped scheduled run, because the previous run is still in progress.")
+		metrics.ScheduledRunsNum.WithLabelValues(name, outcomeSkipped).Inc()
+		return
+	}
+	defer j.running.Store(false)
+
+	// Take a lock on the schedule through the runtime database, so that only one instance of the runtime runs it,
+	// and runs don't overlap across instances.  Without a database, there is nothing to coordinate with,
+	// so the function is just run here.
+	useDb := true
+	if lock, err := db.TryLockSchedule(ctx, name); err != nil {
+		if !db.IsNotConfigured(err) {
+			logger.Err(ctx, err).Str("schedule", name).Msg("Failed to lock schedule.  Skipping scheduled run.")
+			metrics.ScheduledRunsNum.WithLabelValues(name, outcomeSkipped).Inc()
+			return
+		}
+		useDb = false
+	} else if lock == nil {
+		logger.Debug(ctx).Str("schedule", name).Msg("Schedule is locked by another run.  Skipping scheduled run.")
+		metrics.ScheduledRunsNum.WithLabelValues(name, outcomeSkipped).Inc()
+		return
+	} else {
+		defer lock.Release(context.WithoutCancel(ctx))
+	}
+
+	host := wasmhost.GetWasmHost(ctx)
+
+	var pluginId *string
+	if fnInfo, err := host.GetFunctionInfo(fnName); err == nil {
+		pluginId = &fnInfo.Plugin().Id
+	}
+
+	runId := utils.GenerateUUIDv7()
+	start := time.Now()
+
+	if useDb {
+		ok, err := db.StartScheduleRun(ctx, runId, name, fnName, pluginId, scheduledAt, start)
+		if err != nil {
+			logger.Err(ctx, err).Str("schedule", name).Msg("Failed to record scheduled run.  Skipping scheduled run.")
+			metrics.ScheduledRunsNum.WithLabelValues(name, outcomeSkipped).Inc()
+			return
+		} else if !ok {
+			logger.Debug(ctx).Str("schedule", name).Msg("Scheduled run was already completed by another instance.")
+			return
+		}
+	}
+
+	logger.Info(ctx).
+		Str("schedule", name).
+		Str("function", fnName).
+		Time("scheduled_at", scheduledAt).
+		Bool("user_visible", true).
+		Msg("Starting scheduled run.")
+
+	execInfo, err := host.CallFunctionByName(ctx, fnName, j.info.Args...)
+	duration := time.Since(start)
+
+	var executionId *string
+	if execInfo != nil {
+		id := execInfo.ExecutionId()
+		executionId = &id
+	}
+
+	status := "succeeded"
+	outcome := outcomeSuccess
+	var errMsg *string
+	if err != nil {
+		status = "failed"
+		outcome = outcomeError
+		msg := err.Error()
+		errMsg = &msg
+
+		logger.Error(ctx).Err(err).
+			Str("schedule", name).
+			Str("function", fnName).
+			Dur("duration_ms", duration).
+			Bool("user_visible", true).
+			Msg("Scheduled run failed.")
+	} else {
+		logger.Info(ctx).
+			Str("schedule", name).
+			Str("function", fnName).
+			Dur("duration_ms", duration).
+			Bool("user_visible", true).
+			Msg("Scheduled run completed.")
+	}
+
+	metrics.ScheduledRunsNum.WithLabelValues(name, outcome).Inc()
+
+	if useDb {
+		// Record the outcome even if the runtime is shutting down.
+		if err := db.FinishScheduleRun(context.WithoutCancel(ctx), runId, status, executionId, errMsg, duration); err != nil {
+			logger.Err(ctx, err).Str("schedule", name).Msg("Failed to record outcome of scheduled run.")
+		}
+	}
+}
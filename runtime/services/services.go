@@ -12,6 +12,7 @@ package services
 import (
 	"context"
 
+	"github.com/hypermodeinc/modus/runtime/assets"
 	"github.com/hypermodeinc/modus/runtime/aws"
 	"github.com/hypermodeinc/modus/runtime/collections"
 	"github.com/hypermodeinc/modus/runtime/db"
@@ -19,20 +20,44 @@ import (
 	"github.com/hypermodeinc/modus/runtime/envfiles"
 	"github.com/hypermodeinc/modus/runtime/graphql"
 	"github.com/hypermodeinc/modus/runtime/hostfunctions"
+	"github.com/hypermodeinc/modus/runtime/jobs"
+	"github.com/hypermodeinc/modus/runtime/kv"
 	"github.com/hypermodeinc/modus/runtime/logger"
 	"github.com/hypermodeinc/modus/runtime/manifestdata"
 	"github.com/hypermodeinc/modus/runtime/middleware"
 	"github.com/hypermodeinc/modus/runtime/neo4jclient"
 	"github.com/hypermodeinc/modus/runtime/pluginmanager"
+	"github.com/hypermodeinc/modus/runtime/s3client"
+	"github.com/hypermodeinc/modus/runtime/scheduler"
 	"github.com/hypermodeinc/modus/runtime/secrets"
 	"github.com/hypermodeinc/modus/runtime/sqlclient"
 	"github.com/hypermodeinc/modus/runtime/storage"
+	"github.com/hypermodeinc/modus/runtime/triggers"
 	"github.com/hypermodeinc/modus/runtime/utils"
 	"github.com/hypermodeinc/modus/runtime/wasmhost"
 )
 
+// Mode determines which services are started.
+type Mode int
+
+const (
+	// ServerMode starts all services, for a runtime that serves requests.
+	ServerMode Mode = iota
+
+	// CommandMode starts the services needed to run a single command, such as replaying or invoking a function.
+	// The background workers are not started, so scheduled and triggered functions and queued jobs are not run.
+	CommandMode
+)
+
+// backgroundWorkers are the services that run functions in the background.  They are only started in server mode.
+var backgroundWorkers = []func(context.Context){
+	scheduler.Initialize, // must be before the manifest is loaded
+	triggers.Initialize,  // must be before the manifest is loaded
+	jobs.StartWorkers,    // must be before the plugins are loaded
+}
+
 // Starts any services that need to be started when the runtime starts.
-func Start(ctx context.Context) context.Context {
+func Start(ctx context.Context, mode Mode) context.Context {
 
 	// Note, we cannot start a Sentry transaction here, or it will also be used for the background services, post-initiation.
 
@@ -50,11 +75,16 @@ func Start(ctx context.Context) context.Context {
 	sqlclient.Initialize()
 	dgraphclient.Initialize()
 	neo4jclient.Initialize()
+	s3client.Initialize()
 	aws.Initialize(ctx)
 	secrets.Initialize(ctx)
 	storage.Initialize(ctx)
 	db.Initialize(ctx)
 	collections.Initialize(ctx)
+	kv.Initialize(ctx)
+	assets.Initialize(ctx) // must be before the manifest is loaded
+	jobs.Initialize(ctx)   // must be before the plugins are loaded
+	startBackgroundWorkers(ctx, mode)
 	manifestdata.MonitorManifestFile(ctx)
 	envfiles.MonitorEnvFiles(ctx)
 	pluginmanager.Initialize(ctx)
@@ -63,10 +93,23 @@ func Start(ctx context.Context) context.Context {
 	return ctx
 }
 
+func startBackgroundWorkers(ctx context.Context, mode Mode) {
+	if mode != ServerMode {
+		return
+	}
+	for _, start := range backgroundWorkers {
+		start(ctx)
+	}
+}
+
 // Stops any services that need to be stopped when the runtime stops.
 func Stop(ctx context.Context) {
 
-	// Stop the wasm host first
+	// Stop running scheduled and triggered functions and jobs, then stop the wasm host.
+	// These are safe to call in command mode, when the background workers were not started.
+	scheduler.Shutdown(ctx)
+	triggers.Shutdown(ctx)
+	jobs.Shutdown(ctx)
 	wasmhost.GetWasmHost(ctx).Close(ctx)
 
 	// Stop the rest of the background services.
@@ -75,8 +118,9 @@ func Stop(ctx context.Context) {
 	// Unlike start, these should each block until they are fully stopped.
 
 	collections.Shutdown(ctx)
+	kv.Shutdown(ctx)
 	middleware.Shutdown()
-	sqlclient.ShutdownPGPools()
+	sqlclient.ShutdownPools()
 	dgraphclient.ShutdownConns()
 	neo4jclient.CloseDrivers(ctx)
 	logger.Close()
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStartBackgroundWorkers(t *testing.T) {
+	original := backgroundWorkers
+	defer func() { backgroundWorkers = original }()
+
+	started := 0
+	start := func(context.Context) { started++ }
+	backgroundWorkers = []func(context.Context){start, start, start}
+
+	startBackgroundWorkers(context.Background(), CommandMode)
+	if started != 0 {
+		t.Errorf("expected no background workers to be started in command mode, but %d were started", started)
+	}
+
+	startBackgroundWorkers(context.Background(), ServerMode)
+	if started != 3 {
+		t.Errorf("expected all 3 background workers to be started in server mode, but %d were started", started)
+	}
+}
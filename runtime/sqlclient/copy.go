@@ -0,0 +1,97 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CopyRows inserts the rows into the table of a postgresql connection with the COPY protocol,
+// and returns the number of rows inserted.  All of the rows are inserted by a single statement,
+// so either all of them are inserted, or none of them are.
+//
+// The rows are a JSON array, in which each row is either an array of values in the order of the columns,
+// or an object whose fields are named by the columns.  A column that is missing from an object is set to null.
+// The size of the JSON is bounded by the maxQueryBytes limit of the function, so large sets of rows must be
+// copied in batches.
+func CopyRows(ctx context.Context, connectionName, tableName, columnsJson, rowsJson string) (*HostCopyResponse, error) {
+	if maxBytes := getQueryLimits(ctx).maxBytes; len(rowsJson) > maxBytes {
+		return nil, fmt.Errorf("the rows to copy exceed the limit of %d bytes; copy the rows in smaller batches, or increase the maxQueryBytes limit in the manifest", maxBytes)
+	}
+
+	var columns []string
+	if err := utils.JsonDeserialize([]byte(columnsJson), &columns); err != nil {
+		return nil, fmt.Errorf("error deserializing columns: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("at least one column is required to copy rows")
+	}
+
+	var rows []any
+	if err := utils.JsonDeserialize([]byte(rowsJson), &rows); err != nil {
+		return nil, fmt.Errorf("error deserializing rows: %w", err)
+	}
+
+	ds, err := getDataSource(ctx, connectionName, "postgresql")
+	if err != nil {
+		return nil, err
+	}
+
+	pgds, ok := ds.(*postgresqlDS)
+	if !ok {
+		return nil, fmt.Errorf("[%s] is not a postgresql connection", connectionName)
+	}
+
+	// The table name can be qualified by a schema name, as in "schema.table".
+	table := pgx.Identifier(strings.Split(tableName, "."))
+
+	src := pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+		values, err := getRowValues(rows[i], columns)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		return values, nil
+	})
+
+	count, err := pgds.pool.CopyFrom(ctx, table, columns, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HostCopyResponse{RowsAffected: uint64(count)}, nil
+}
+
+// getRowValues returns the values of the row, in the order of the columns.
+func getRowValues(row any, columns []string) ([]any, error) {
+	var values []any
+	switch r := row.(type) {
+	case []any:
+		if len(r) != len(columns) {
+			return nil, fmt.Errorf("expected %d values, but received %d", len(columns), len(r))
+		}
+		values = r
+	case map[string]any:
+		values = make([]any, len(columns))
+		for i, col := range columns {
+			values[i] = r[col]
+		}
+	default:
+		return nil, errors.New("expected an array of values or an object")
+	}
+
+	return convertParams(values)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRowValues(t *testing.T) {
+	columns := []string{"id", "name", "tags"}
+
+	values, err := getRowValues([]any{json.Number("1"), "Alice", []any{"a", "b"}}, columns)
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(1), "Alice", `["a","b"]`}, values)
+
+	values, err = getRowValues(map[string]any{"name": "Bob", "id": json.Number("2"), "extra": true}, columns)
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(2), "Bob", nil}, values)
+
+	_, err = getRowValues([]any{json.Number("3"), "Charlie"}, columns)
+	assert.Error(t, err)
+
+	_, err = getRowValues("Dave", columns)
+	assert.Error(t, err)
+}
+
+func TestCopyRowsLimit(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Limits: manifest.LimitsInfo{
+			ExecutionLimits: manifest.ExecutionLimits{MaxQueryBytes: 20},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	_, err := CopyRows(context.Background(), "neon", "users", `["id","name"]`, `[[1,"Alice"],[2,"Bob"],[3,"Charlie"]]`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceed the limit of 20 bytes")
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/puzpuzpuz/xsync/v3"
+	"github.com/rs/xid"
+)
+
+// openCursors holds the cursors that were opened by function executions, by cursor id.
+var openCursors = xsync.NewMapOf[string, *cursor]()
+
+// A cursor reads the rows of a query in pages, as they are received from the database.
+// Each cursor has a transaction of its own, which holds a connection until the cursor is closed.
+type cursor struct {
+	tx          transaction
+	rows        rowReader
+	executionId string
+
+	// pending is the encoded row that didn't fit in the previous page.
+	pending []byte
+
+	// done is set when all of the rows have been read, or reading them failed.
+	done bool
+	err  error
+
+	// stop unregisters the close that happens when the execution ends.
+	stop func() bool
+
+	mu sync.Mutex
+}
+
+// OpenCursor runs the query, and returns the id of a cursor that is used to fetch its rows in pages.
+// The cursor can only be used by the function execution that opened it, and it is closed
+// if it hasn't been closed by the time the execution ends.
+func OpenCursor(ctx context.Context, connectionName, dbType, statement, paramsJson string) (string, error) {
+	executionId, ok := ctx.Value(utils.ExecutionIdContextKey).(string)
+	if !ok {
+		return "", errors.New("cursors can only be used from within a function execution")
+	}
+
+	params, err := parseParams(paramsJson)
+	if err != nil {
+		return "", err
+	}
+
+	ds, err := getDataSource(ctx, connectionName, dbType)
+	if err != nil {
+		return "", err
+	}
+
+	var tx transaction
+	if cs, ok := ds.(cursorSource); ok {
+		tx, err = cs.beginCursor(ctx)
+	} else {
+		tx, err = ds.begin(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := tx.query(ctx, statement, params)
+	if err != nil {
+		if err := tx.rollback(ctx); err != nil {
+			logger.Warn(ctx).Err(err).Msg("Error rolling back transaction.")
+		}
+		return "", err
+	}
+
+	id := xid.New().String()
+	c := &cursor{tx: tx, rows: rows, executionId: executionId}
+	openCursors.Store(id, c)
+
+	// The context of a host function call is done when the function execution ends.
+	c.stop = context.AfterFunc(ctx, func() {
+		if c, ok := openCursors.LoadAndDelete(id); ok {
+			c.close(context.WithoutCancel(ctx), false)
+		}
+	})
+
+	return id, nil
+}
+
+// FetchCursor returns the next page of rows from the cursor, with up to maxRows rows.
+// The size of the page is also bounded by the query limits of the function.
+// An empty page is returned when there are no more rows.
+func FetchCursor(ctx context.Context, cursorId string, maxRows int32) (*HostQueryResponse, error) {
+	c, ok := openCursors.Load(cursorId)
+	if !ok || c.executionId != ctx.Value(utils.ExecutionIdContextKey) {
+		return nil, fmt.Errorf("cursor [%s] not found", cursorId)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.done {
+		return newHostQueryResponse(&dbResponse{ResultJson: []byte("[]")})
+	}
+
+	limits := getQueryLimits(ctx)
+	n := limits.maxRows
+	if maxRows > 0 && int(maxRows) < n {
+		n = int(maxRows)
+	}
+
+	result, count, pending, done, err := encodeRows(c.rows, c.pending, n, limits.maxBytes)
+	if err != nil || done {
+		if _, closeErr := c.rows.close(); err == nil {
+			err = closeErr
+		}
+		c.done = true
+	}
+	if err != nil {
+		c.err = err
+		return nil, err
+	}
+	c.pending = pending
+
+	return newHostQueryResponse(&dbResponse{ResultJson: result, RowsAffected: uint32(count)})
+}
+
+// CloseCursor closes the cursor, and ends its transaction.
+func CloseCursor(ctx context.Context, cursorId string) error {
+	c, ok := openCursors.Load(cursorId)
+	if !ok || c.executionId != ctx.Value(utils.ExecutionIdContextKey) {
+		return fmt.Errorf("cursor [%s] not found", cursorId)
+	}
+	if _, ok := openCursors.LoadAndDelete(cursorId); !ok {
+		return fmt.Errorf("cursor [%s] not found", cursorId)
+	}
+
+	c.stop()
+	return c.close(ctx, true)
+}
+
+// close releases the rows, and ends the transaction.  The transaction is committed only if it was requested,
+// and reading the rows didn't fail, so that a statement that modifies data has no effect unless its cursor is closed.
+func (c *cursor) close(ctx context.Context, commit bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.done {
+		if _, err := c.rows.close(); err != nil && c.err == nil {
+			c.err = err
+		}
+		c.done = true
+	}
+
+	if commit && c.err == nil {
+		return c.tx.commit(ctx)
+	}
+
+	if err := c.tx.rollback(ctx); err != nil {
+		logger.Warn(ctx).Err(err).Msg("Error rolling back transaction.")
+	}
+	return nil
+}
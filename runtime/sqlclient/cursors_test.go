@@ -0,0 +1,141 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursors(t *testing.T) {
+	config.AppPath = t.TempDir()
+	defer func() { config.AppPath = "" }()
+
+	manifestdata.SetManifest(&manifest.Manifest{
+		Connections: map[string]manifest.ConnectionInfo{
+			"scratch": manifest.SqliteConnectionInfo{Name: "scratch", Type: manifest.ConnectionTypeSqlite, Path: "scratch.db"},
+		},
+		Limits: manifest.LimitsInfo{
+			ExecutionLimits: manifest.ExecutionLimits{MaxQueryRows: 5, MaxQueryBytes: 100},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+	defer ShutdownPools()
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), utils.ExecutionIdContextKey, "execution1"))
+	defer cancel()
+
+	_, err := ExecuteQuery(ctx, "scratch", "sqlite", "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)", "[]")
+	require.NoError(t, err)
+	_, err = ExecuteQuery(ctx, "scratch", "sqlite", "INSERT INTO items (name) SELECT 'item' FROM (SELECT 1 UNION SELECT 2 UNION SELECT 3 UNION SELECT 4 UNION SELECT 5 UNION SELECT 6 UNION SELECT 7)", "[]")
+	require.NoError(t, err)
+
+	// A query that returns more rows than the limit fails, and suggests using a cursor.
+	_, err = ExecuteQuery(ctx, "scratch", "sqlite", "SELECT id FROM items", "[]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than the limit of 5 rows")
+
+	_, err = ExecuteQuery(ctx, "scratch", "sqlite", "SELECT id, name FROM items WHERE id <= 5", "[]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the limit of 100 bytes")
+
+	cursorId, err := OpenCursor(ctx, "scratch", "sqlite", "SELECT id FROM items WHERE id > ? ORDER BY id", "[0]")
+	require.NoError(t, err)
+
+	// Pages are bounded by the requested number of rows, and by the limits.
+	var pages []string
+	for {
+		response, err := FetchCursor(ctx, cursorId, 3)
+		require.NoError(t, err)
+		if *response.ResultJson == "[]" {
+			break
+		}
+		pages = append(pages, *response.ResultJson)
+	}
+	assert.Equal(t, []string{`[{"id":1},{"id":2},{"id":3}]`, `[{"id":4},{"id":5},{"id":6}]`, `[{"id":7}]`}, pages)
+	require.NoError(t, CloseCursor(ctx, cursorId))
+	assert.Error(t, CloseCursor(ctx, cursorId))
+
+	cursorId, err = OpenCursor(ctx, "scratch", "sqlite", "SELECT id, name FROM items ORDER BY id", "[]")
+	require.NoError(t, err)
+
+	response, err := FetchCursor(ctx, cursorId, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"id":1,"name":"item"},{"id":2,"name":"item"},{"id":3,"name":"item"},{"id":4,"name":"item"}]`, *response.ResultJson)
+	assert.Equal(t, uint32(4), response.RowsAffected)
+
+	response, err = FetchCursor(ctx, cursorId, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"id":5,"name":"item"},{"id":6,"name":"item"},{"id":7,"name":"item"}]`, *response.ResultJson)
+
+	// A cursor can't be used by another execution.
+	otherCtx := context.WithValue(context.Background(), utils.ExecutionIdContextKey, "execution2")
+	_, err = FetchCursor(otherCtx, cursorId, 0)
+	assert.Error(t, err)
+
+	// A cursor is closed when the execution ends, which releases its connection.
+	cancel()
+	assert.Eventually(t, func() bool { return openCursors.Size() == 0 }, 5*time.Second, time.Millisecond)
+	_, err = ExecuteQuery(otherCtx, "scratch", "sqlite", "SELECT COUNT(*) FROM items", "[]")
+	require.NoError(t, err)
+}
+
+func TestSqliteCursors(t *testing.T) {
+	config.AppPath = t.TempDir()
+	defer func() { config.AppPath = "" }()
+
+	manifestdata.SetManifest(&manifest.Manifest{
+		Connections: map[string]manifest.ConnectionInfo{
+			"scratch": manifest.SqliteConnectionInfo{Name: "scratch", Type: manifest.ConnectionTypeSqlite, Path: manifest.SqliteMemoryPath},
+			"file":    manifest.SqliteConnectionInfo{Name: "file", Type: manifest.ConnectionTypeSqlite, Path: "file.db"},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+	defer ShutdownPools()
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), utils.ExecutionIdContextKey, "execution1"))
+	defer cancel()
+
+	// A cursor can't hold the only connection to an in-memory database.
+	_, err := OpenCursor(ctx, "scratch", "sqlite", "SELECT 1", "[]")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in-memory SQLite database")
+
+	_, err = ExecuteQuery(ctx, "file", "sqlite", "CREATE TABLE items (id INTEGER PRIMARY KEY)", "[]")
+	require.NoError(t, err)
+	_, err = ExecuteQuery(ctx, "file", "sqlite", "INSERT INTO items (id) VALUES (1), (2), (3)", "[]")
+	require.NoError(t, err)
+
+	cursorId, err := OpenCursor(ctx, "file", "sqlite", "SELECT id FROM items ORDER BY id", "[]")
+	require.NoError(t, err)
+	response, err := FetchCursor(ctx, cursorId, 1)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"id":1}]`, *response.ResultJson)
+
+	// An open cursor that only reads doesn't prevent writing to the database.
+	response, err = ExecuteQuery(ctx, "file", "sqlite", "INSERT INTO items (id) VALUES (4)", "[]")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), response.RowsAffected)
+
+	// The cursor continues to read the rows as they were when it was opened.
+	response, err = FetchCursor(ctx, cursorId, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"id":2},{"id":3}]`, *response.ResultJson)
+	require.NoError(t, CloseCursor(ctx, cursorId))
+}
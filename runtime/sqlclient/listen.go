@@ -0,0 +1,115 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const unlockTimeout = 5 * time.Second
+
+// ErrListenerLocked is returned by Listen when another listener holds the lock.
+var ErrListenerLocked = errors.New("another listener holds the lock")
+
+// A Listener receives the notifications that are sent to a channel of a postgresql connection.
+type Listener struct {
+	conn    listenerConn
+	lockKey string
+}
+
+// listenerConn is the part of *pgx.Conn that is used by a listener.
+type listenerConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// Listen opens a connection of its own to the postgresql connection, and listens on the channel.
+// The connection is not taken from the pool, because it is held for as long as the listener is open.
+//
+// Before listening, the listener takes a session-level advisory lock on the given key, so that only one listener
+// with the same key receives the notifications, such as when several instances of the runtime use the same database.
+// ErrListenerLocked is returned if the lock is held by another listener.  The lock is released when the listener
+// is closed, or automatically if its connection is lost.
+func Listen(ctx context.Context, connectionName, channel, lockKey string) (*Listener, error) {
+	info, ok := manifestdata.GetManifest().Connections[connectionName]
+	if !ok {
+		return nil, fmt.Errorf("postgresql connection [%s] not found", connectionName)
+	}
+
+	conf, ok := info.(manifest.PostgresqlConnectionInfo)
+	if !ok {
+		return nil, fmt.Errorf("[%s] is not a postgresql connection", connectionName)
+	}
+
+	connStr, err := getConnectionString(ctx, info, conf.ConnStr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pgx.Connect(ctx, connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres connection [%s]: %w", connectionName, err)
+	}
+
+	l, err := listen(ctx, conn, channel, lockKey)
+	if err != nil && !errors.Is(err, ErrListenerLocked) {
+		return nil, fmt.Errorf("failed to listen on channel [%s] of postgres connection [%s]: %w", channel, connectionName, err)
+	}
+	return l, err
+}
+
+// listen takes the lock, and listens on the channel.  The connection is closed if it fails.
+func listen(ctx context.Context, conn listenerConn, channel, lockKey string) (*Listener, error) {
+	var acquired bool
+	err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", lockKey).Scan(&acquired)
+	if err == nil && !acquired {
+		err = ErrListenerLocked
+	}
+	if err == nil {
+		_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+	}
+	if err != nil {
+		// Closing the connection also releases the lock, if it was taken.
+		_ = conn.Close(context.WithoutCancel(ctx))
+		return nil, err
+	}
+
+	return &Listener{conn, lockKey}, nil
+}
+
+// WaitForNotification blocks until a notification is received, and returns its payload.
+// An error is returned if the context is done, or the connection is lost.
+func (l *Listener) WaitForNotification(ctx context.Context) (string, error) {
+	n, err := l.conn.WaitForNotification(ctx)
+	if err != nil {
+		return "", err
+	}
+	return n.Payload, nil
+}
+
+// Close releases the lock, and closes the connection of the listener, which stops listening on the channel.
+func (l *Listener) Close(ctx context.Context) error {
+	// The lock is bound to the session, so closing the connection releases it even if unlocking fails.
+	unlockCtx, cancel := context.WithTimeout(ctx, unlockTimeout)
+	defer cancel()
+	_, _ = l.conn.Exec(unlockCtx, "SELECT pg_advisory_unlock(hashtext($1))", l.lockKey)
+	return l.conn.Close(ctx)
+}
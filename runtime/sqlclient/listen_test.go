@@ -0,0 +1,106 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeListenerConn records the statements that are run on it, and holds advisory locks in a map that is
+// shared with other fake connections, like the sessions of a database.
+type fakeListenerConn struct {
+	locks      map[string]*fakeListenerConn
+	statements []string
+	closed     bool
+}
+
+func (c *fakeListenerConn) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	c.statements = append(c.statements, sql)
+	if sql == "SELECT pg_advisory_unlock(hashtext($1))" {
+		if key := args[0].(string); c.locks[key] == c {
+			delete(c.locks, key)
+		}
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (c *fakeListenerConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	c.statements = append(c.statements, sql)
+	key := args[0].(string)
+	if holder, ok := c.locks[key]; ok && holder != c {
+		return fakeRow{false}
+	}
+	c.locks[key] = c
+	return fakeRow{true}
+}
+
+func (c *fakeListenerConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	return &pgconn.Notification{Payload: "payload"}, nil
+}
+
+func (c *fakeListenerConn) Close(ctx context.Context) error {
+	c.closed = true
+	for key, holder := range c.locks {
+		if holder == c {
+			delete(c.locks, key)
+		}
+	}
+	return nil
+}
+
+type fakeRow struct {
+	value bool
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	*dest[0].(*bool) = r.value
+	return nil
+}
+
+func TestListen_Lock(t *testing.T) {
+	ctx := context.Background()
+	locks := make(map[string]*fakeListenerConn)
+
+	// The first listener takes the lock, and listens on the channel.
+	conn1 := &fakeListenerConn{locks: locks}
+	l1, err := listen(ctx, conn1, "orders", "modus_trigger:order-created")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SELECT pg_try_advisory_lock(hashtext($1))", `LISTEN "orders"`}, conn1.statements)
+	assert.Same(t, conn1, locks["modus_trigger:order-created"])
+
+	payload, err := l1.WaitForNotification(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", payload)
+
+	// Another listener with the same key doesn't listen while the lock is held, and its connection is closed.
+	conn2 := &fakeListenerConn{locks: locks}
+	_, err = listen(ctx, conn2, "orders", "modus_trigger:order-created")
+	assert.ErrorIs(t, err, ErrListenerLocked)
+	assert.Equal(t, []string{"SELECT pg_try_advisory_lock(hashtext($1))"}, conn2.statements)
+	assert.True(t, conn2.closed)
+
+	// Closing the first listener releases the lock, so that another listener can take over.
+	require.NoError(t, l1.Close(ctx))
+	assert.Contains(t, conn1.statements, "SELECT pg_advisory_unlock(hashtext($1))")
+	assert.True(t, conn1.closed)
+	assert.Empty(t, locks)
+
+	conn3 := &fakeListenerConn{locks: locks}
+	l3, err := listen(ctx, conn3, "orders", "modus_trigger:order-created")
+	require.NoError(t, err)
+	assert.Same(t, conn3, locks["modus_trigger:order-created"])
+	require.NoError(t, l3.Close(ctx))
+}
@@ -19,20 +19,20 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// ShutdownPGPools shuts down all the PostgreSQL connection pools.
-func ShutdownPGPools() {
-	dsr.cache.Range(func(key string, _ *postgresqlDS) bool {
+// ShutdownPools shuts down all the database connection pools.
+func ShutdownPools() {
+	dsr.cache.Range(func(key string, _ dataSource) bool {
 		if ds, ok := dsr.cache.LoadAndDelete(key); ok {
-			ds.pool.Close()
+			ds.close()
 		}
 		return true
 	})
 }
 
-func (r *dsRegistry) getPostgresDS(ctx context.Context, dsName string) (*postgresqlDS, error) {
+func (r *dsRegistry) getDS(ctx context.Context, dsName, dsType string) (dataSource, error) {
 	var creationErr error
-	ds, _ := r.cache.LoadOrCompute(dsName, func() *postgresqlDS {
-		ds, err := createDS(ctx, dsName)
+	ds, _ := r.cache.LoadOrCompute(dsName, func() dataSource {
+		ds, err := createDS(ctx, dsName, dsType)
 		if err != nil {
 			creationErr = err
 			return nil
@@ -48,31 +48,66 @@ func (r *dsRegistry) getPostgresDS(ctx context.Context, dsName string) (*postgre
 	return ds, nil
 }
 
-func createDS(ctx context.Context, dsName string) (*postgresqlDS, error) {
+func createDS(ctx context.Context, dsName, dsType string) (dataSource, error) {
 	man := manifestdata.GetManifest()
 	info, ok := man.Connections[dsName]
 	if !ok {
-		return nil, fmt.Errorf("postgresql connection [%s] not found", dsName)
+		return nil, fmt.Errorf("%s connection [%s] not found", dsType, dsName)
 	}
 
-	if info.ConnectionType() != manifest.ConnectionTypePostgresql {
-		return nil, fmt.Errorf("[%s] is not a postgresql connection", dsName)
+	if info.ConnectionType() != manifest.ConnectionType(dsType) {
+		return nil, fmt.Errorf("[%s] is not a %s connection", dsName, dsType)
 	}
 
-	conf := info.(manifest.PostgresqlConnectionInfo)
-	if conf.ConnStr == "" {
-		return nil, fmt.Errorf("postgresql connection [%s] has empty connString", dsName)
+	switch conf := info.(type) {
+	case manifest.PostgresqlConnectionInfo:
+		connStr, err := getConnectionString(ctx, info, conf.ConnStr)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := pgxpool.New(ctx, connStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to postgres connection [%s]: %w", dsName, err)
+		}
+
+		return &postgresqlDS{pool}, nil
+
+	case manifest.MysqlConnectionInfo:
+		connStr, err := getConnectionString(ctx, info, conf.ConnStr)
+		if err != nil {
+			return nil, err
+		}
+
+		db, err := openMysqlDB(connStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to mysql connection [%s]: %w", dsName, err)
+		}
+
+		return &sqlDS{db, mysqlDialect{}}, nil
+
+	case manifest.SqliteConnectionInfo:
+		ds, err := openSqliteDB(ctx, conf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sqlite connection [%s]: %w", dsName, err)
+		}
+
+		return ds, nil
+
+	default:
+		return nil, fmt.Errorf("connection [%s] has an unsupported type: %s", dsName, dsType)
 	}
+}
 
-	connStr, err := secrets.ApplySecretsToString(ctx, info, conf.ConnStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to apply secrets to connection string for connection [%s]: %w", dsName, err)
+func getConnectionString(ctx context.Context, info manifest.ConnectionInfo, connStr string) (string, error) {
+	if connStr == "" {
+		return "", fmt.Errorf("%s connection [%s] has empty connString", info.ConnectionType(), info.ConnectionName())
 	}
 
-	pool, err := pgxpool.New(ctx, connStr)
+	connStr, err := secrets.ApplySecretsToString(ctx, info, connStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to postgres connection [%s]: %w", dsName, err)
+		return "", fmt.Errorf("failed to apply secrets to connection string for connection [%s]: %w", info.ConnectionName(), err)
 	}
 
-	return &postgresqlDS{pool}, nil
+	return connStr, nil
 }
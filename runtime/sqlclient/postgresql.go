@@ -11,10 +11,9 @@ package sqlclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/hypermodeinc/modus/runtime/logger"
-
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -23,41 +22,67 @@ type postgresqlDS struct {
 	pool *pgxpool.Pool
 }
 
-func (ds *postgresqlDS) query(ctx context.Context, stmt string, params []any) (*dbResponse, error) {
+type postgresqlTx struct {
+	tx pgx.Tx
+}
 
+func (ds *postgresqlDS) begin(ctx context.Context) (transaction, error) {
 	tx, err := ds.pool.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error setting up a new tx: %w", err)
 	}
-	defer func() {
-		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
-			logger.Warn(ctx).Err(err).Msg("Error rolling back transaction.")
-			return
-		}
-	}()
+	return &postgresqlTx{tx}, nil
+}
+
+func (ds *postgresqlDS) close() {
+	ds.pool.Close()
+}
+
+func (t *postgresqlTx) query(ctx context.Context, stmt string, params []any) (rowReader, error) {
 
 	// TODO: what if connection times out and we need to retry
-	rows, err := tx.Query(ctx, stmt, params...)
+	rows, err := t.tx.Query(ctx, stmt, params...)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := pgx.CollectRows(rows, pgx.RowToMap)
-	if err != nil {
-		return nil, err
+	return &postgresqlRows{rows}, nil
+}
+
+func (t *postgresqlTx) commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+func (t *postgresqlTx) rollback(ctx context.Context) error {
+	if err := t.tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		return err
 	}
+	return nil
+}
 
-	rowsAffected := uint32(rows.CommandTag().RowsAffected())
+type postgresqlRows struct {
+	rows pgx.Rows
+}
 
-	if err := tx.Commit(ctx); err != nil {
+func (r *postgresqlRows) next() bool {
+	return r.rows.Next()
+}
+
+func (r *postgresqlRows) row() (map[string]any, error) {
+	values, err := r.rows.Values()
+	if err != nil {
 		return nil, err
 	}
 
-	response := &dbResponse{
-		// Error: "",
-		Result:       data,
-		RowsAffected: rowsAffected,
+	fields := r.rows.FieldDescriptions()
+	row := make(map[string]any, len(fields))
+	for i, field := range fields {
+		row[field.Name] = values[i]
 	}
+	return row, nil
+}
 
-	return response, nil
+func (r *postgresqlRows) close() (uint32, error) {
+	r.rows.Close()
+	return uint32(r.rows.CommandTag().RowsAffected()), r.rows.Err()
 }
@@ -14,11 +14,11 @@ import "github.com/puzpuzpuz/xsync/v3"
 var dsr = newDSRegistry()
 
 type dsRegistry struct {
-	cache *xsync.MapOf[string, *postgresqlDS]
+	cache *xsync.MapOf[string, dataSource]
 }
 
 func newDSRegistry() *dsRegistry {
 	return &dsRegistry{
-		cache: xsync.NewMapOf[string, *postgresqlDS](),
+		cache: xsync.NewMapOf[string, dataSource](),
 	}
 }
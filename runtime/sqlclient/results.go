@@ -0,0 +1,161 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+// The default limits on the results of a query, when they are not set in the manifest.
+const (
+	defaultMaxQueryRows  = 10000
+	defaultMaxQueryBytes = 16 * 1024 * 1024
+)
+
+type queryLimits struct {
+	maxRows  int
+	maxBytes int
+}
+
+// getQueryLimits returns the limits on query results for the function that is executing.
+func getQueryLimits(ctx context.Context) queryLimits {
+	fnName, _ := ctx.Value(utils.FunctionNameContextKey).(string)
+	limits := manifestdata.GetManifest().Limits.ForFunction(fnName)
+
+	ql := queryLimits{
+		maxRows:  limits.MaxQueryRows,
+		maxBytes: limits.MaxQueryBytes,
+	}
+	if ql.maxRows <= 0 {
+		ql.maxRows = defaultMaxQueryRows
+	}
+	if ql.maxBytes <= 0 {
+		ql.maxBytes = defaultMaxQueryBytes
+	}
+	return ql
+}
+
+// runQuery runs the statement within the transaction, and returns all of its rows as JSON.
+// If the result would exceed the limits, or reading it fails, the query is canceled and an error is returned.
+// Canceling the query stops the database from sending the rest of the rows, which closing the rows would otherwise
+// read and discard.  On PostgreSQL, canceling a query also aborts the transaction it is part of.
+func runQuery(ctx context.Context, tx transaction, stmt string, params []any) (*dbResponse, error) {
+	queryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rr, err := tx.query(queryCtx, stmt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := getQueryLimits(ctx)
+	result, err := readAllRows(rr, limits)
+	if err != nil {
+		cancel()
+		_, _ = rr.close()
+		return nil, err
+	}
+
+	rowsAffected, err := rr.close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dbResponse{
+		ResultJson:   result,
+		RowsAffected: rowsAffected,
+	}
+
+	return response, nil
+}
+
+func readAllRows(rr rowReader, limits queryLimits) ([]byte, error) {
+	result, _, pending, done, err := encodeRows(rr, nil, limits.maxRows, limits.maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending != nil {
+		return nil, fmt.Errorf("query result exceeds the limit of %d bytes; use a cursor to read large results in pages, or increase the maxQueryBytes limit in the manifest", limits.maxBytes)
+	}
+	if !done && rr.next() {
+		return nil, fmt.Errorf("query returned more than the limit of %d rows; use a cursor to read large results in pages, or increase the maxQueryRows limit in the manifest", limits.maxRows)
+	}
+
+	return result, nil
+}
+
+// encodeRows reads up to maxRows rows, and encodes them as a JSON array of at most maxBytes bytes.
+// If pending is not nil, it is the encoded row that didn't fit in the previous array, and is written first.
+// A row that doesn't fit is returned as pending, unless it is the first row, in which case an error is returned.
+// The done result reports whether there are no more rows to read.
+func encodeRows(rr rowReader, pending []byte, maxRows, maxBytes int) (result []byte, count int, nextPending []byte, done bool, err error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('[')
+
+	add := func(row []byte) bool {
+		size := len(row) + 1 // the closing bracket
+		if count > 0 {
+			size++ // the separator
+		}
+		if buf.Len()+size > maxBytes {
+			return false
+		}
+		if count > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(row)
+		count++
+		return true
+	}
+
+	if pending != nil {
+		if !add(pending) {
+			return nil, 0, nil, false, rowTooLargeError(maxBytes)
+		}
+	}
+
+	for count < maxRows {
+		if !rr.next() {
+			done = true
+			break
+		}
+
+		row, err := rr.row()
+		if err != nil {
+			return nil, 0, nil, false, err
+		}
+
+		b, err := utils.JsonSerialize(row)
+		if err != nil {
+			return nil, 0, nil, false, fmt.Errorf("error serializing result: %w", err)
+		}
+
+		if !add(b) {
+			if count == 0 {
+				return nil, 0, nil, false, rowTooLargeError(maxBytes)
+			}
+			nextPending = b
+			break
+		}
+	}
+
+	buf.WriteByte(']')
+	return buf.Bytes(), count, nextPending, done, nil
+}
+
+func rowTooLargeError(maxBytes int) error {
+	return fmt.Errorf("a row of the query result exceeds the limit of %d bytes; increase the maxQueryBytes limit in the manifest", maxBytes)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx is a transaction that reads its own rows, and records whether the query was canceled when its rows were closed.
+type fakeTx struct {
+	ctx              context.Context
+	canceledAtClose  bool
+	closed           bool
+	remainingRows    int
+	unlimitedResults bool
+}
+
+func (t *fakeTx) query(ctx context.Context, stmt string, params []any) (rowReader, error) {
+	t.ctx = ctx
+	return t, nil
+}
+
+func (t *fakeTx) commit(ctx context.Context) error   { return nil }
+func (t *fakeTx) rollback(ctx context.Context) error { return nil }
+
+func (t *fakeTx) next() bool {
+	if t.unlimitedResults {
+		return true
+	}
+	t.remainingRows--
+	return t.remainingRows >= 0
+}
+
+func (t *fakeTx) row() (map[string]any, error) {
+	return map[string]any{"id": 1}, nil
+}
+
+func (t *fakeTx) close() (uint32, error) {
+	t.closed = true
+	t.canceledAtClose = t.ctx.Err() != nil
+	return 0, nil
+}
+
+func TestRunQuery_CancelsQueryOverLimit(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Limits: manifest.LimitsInfo{
+			ExecutionLimits: manifest.ExecutionLimits{MaxQueryRows: 3},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	tx := &fakeTx{unlimitedResults: true}
+	_, err := runQuery(context.Background(), tx, "SELECT", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than the limit of 3 rows")
+	assert.True(t, tx.closed)
+	assert.True(t, tx.canceledAtClose, "expected the query to be canceled before its rows were closed")
+
+	tx = &fakeTx{remainingRows: 2}
+	response, err := runQuery(context.Background(), tx, "SELECT", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":1},{"id":1}]`, string(response.ResultJson))
+	assert.True(t, tx.closed)
+	assert.False(t, tx.canceledAtClose, "expected a query within the limits not to be canceled")
+}
@@ -13,21 +13,22 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hypermodeinc/modus/runtime/logger"
 	"github.com/hypermodeinc/modus/runtime/manifestdata"
 	"github.com/hypermodeinc/modus/runtime/utils"
 )
 
 func Initialize() {
 	manifestdata.RegisterManifestLoadedCallback(func(ctx context.Context) error {
-		ShutdownPGPools()
+		ShutdownPools()
 		return nil
 	})
 }
 
 func ExecuteQuery(ctx context.Context, connectionName, dbType, statement, paramsJson string) (*HostQueryResponse, error) {
-	var params []any
-	if err := utils.JsonDeserialize([]byte(paramsJson), &params); err != nil {
-		return nil, fmt.Errorf("error deserializing database query parameters: %w", err)
+	params, err := parseParams(paramsJson)
+	if err != nil {
+		return nil, err
 	}
 
 	dbResponse, err := doExecuteQuery(ctx, connectionName, dbType, statement, params)
@@ -35,22 +36,25 @@ func ExecuteQuery(ctx context.Context, connectionName, dbType, statement, params
 		return nil, err
 	}
 
-	var resultJson []byte
-	if dbResponse.Result != nil {
-		var err error
-		resultJson, err = utils.JsonSerialize(dbResponse.Result)
-		if err != nil {
-			return nil, fmt.Errorf("error serializing result: %w", err)
-		}
+	return newHostQueryResponse(dbResponse)
+}
+
+func parseParams(paramsJson string) ([]any, error) {
+	var params []any
+	if err := utils.JsonDeserialize([]byte(paramsJson), &params); err != nil {
+		return nil, fmt.Errorf("error deserializing database query parameters: %w", err)
 	}
+	return params, nil
+}
 
+func newHostQueryResponse(dbResponse *dbResponse) (*HostQueryResponse, error) {
 	response := &HostQueryResponse{
 		Error:        dbResponse.Error,
 		RowsAffected: dbResponse.RowsAffected,
 	}
 
-	if len(resultJson) > 0 {
-		s := string(resultJson)
+	if len(dbResponse.ResultJson) > 0 {
+		s := string(dbResponse.ResultJson)
 		response.ResultJson = &s
 	}
 
@@ -58,16 +62,44 @@ func ExecuteQuery(ctx context.Context, connectionName, dbType, statement, params
 }
 
 func doExecuteQuery(ctx context.Context, dsName, dsType, stmt string, params []any) (*dbResponse, error) {
-	switch dsType {
-	case "postgresql":
-		ds, err := dsr.getPostgresDS(ctx, dsName)
-		if err != nil {
-			return nil, err
-		}
+	ds, err := getDataSource(ctx, dsName, dsType)
+	if err != nil {
+		return nil, err
+	}
 
-		return ds.query(ctx, stmt, params)
+	return queryInNewTx(ctx, ds, stmt, params)
+}
+
+func getDataSource(ctx context.Context, dsName, dsType string) (dataSource, error) {
+	switch dsType {
+	case "postgresql", "mysql", "sqlite":
+		return dsr.getDS(ctx, dsName, dsType)
 
 	default:
 		return nil, fmt.Errorf("connection [%s] has an unsupported type: %s", dsName, dsType)
 	}
 }
+
+// queryInNewTx runs the statement in a transaction of its own, which is committed if the statement succeeds.
+func queryInNewTx(ctx context.Context, ds dataSource, stmt string, params []any) (*dbResponse, error) {
+	tx, err := ds.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := tx.rollback(ctx); err != nil {
+			logger.Warn(ctx).Err(err).Msg("Error rolling back transaction.")
+		}
+	}()
+
+	response, err := runQuery(ctx, tx, stmt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
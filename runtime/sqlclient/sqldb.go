@@ -0,0 +1,230 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+// sqlDS is a data source that uses a database/sql connection pool.
+// The dialect handles the differences between the databases.
+type sqlDS struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+type sqlTx struct {
+	tx      *sql.Tx
+	dialect sqlDialect
+}
+
+type sqlDialect interface {
+	// returnsRows reports whether the statement returns rows, or if it should be executed to get the number of affected rows.
+	returnsRows(stmt string) bool
+
+	// convertValue converts a value that was scanned from a column of the given database type,
+	// so that it serializes to JSON the same way as the equivalent PostgreSQL value.
+	convertValue(dbType string, value any) (any, error)
+}
+
+func (ds *sqlDS) begin(ctx context.Context) (transaction, error) {
+	tx, err := ds.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up a new tx: %w", err)
+	}
+	return &sqlTx{tx, ds.dialect}, nil
+}
+
+func (ds *sqlDS) close() {
+	ds.db.Close()
+}
+
+func (t *sqlTx) query(ctx context.Context, stmt string, params []any) (rowReader, error) {
+
+	args, err := convertParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.dialect.returnsRows(stmt) {
+		rows, err := t.tx.QueryContext(ctx, stmt, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		columns, err := rows.ColumnTypes()
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		return newSqlRows(rows, columns, t.dialect), nil
+	}
+
+	result, err := t.tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	return &execResult{uint32(n)}, nil
+}
+
+func (t *sqlTx) commit(ctx context.Context) error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) rollback(ctx context.Context) error {
+	if err := t.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	return nil
+}
+
+type sqlRows struct {
+	rows     *sql.Rows
+	columns  []*sql.ColumnType
+	dialect  sqlDialect
+	values   []any
+	pointers []any
+	count    uint32
+}
+
+func newSqlRows(rows *sql.Rows, columns []*sql.ColumnType, dialect sqlDialect) *sqlRows {
+	r := &sqlRows{
+		rows:     rows,
+		columns:  columns,
+		dialect:  dialect,
+		values:   make([]any, len(columns)),
+		pointers: make([]any, len(columns)),
+	}
+	for i := range r.values {
+		r.pointers[i] = &r.values[i]
+	}
+	return r
+}
+
+func (r *sqlRows) next() bool {
+	if !r.rows.Next() {
+		return false
+	}
+	r.count++
+	return true
+}
+
+func (r *sqlRows) row() (map[string]any, error) {
+	if err := r.rows.Scan(r.pointers...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]any, len(r.columns))
+	for i, column := range r.columns {
+		v, err := r.dialect.convertValue(column.DatabaseTypeName(), r.values[i])
+		if err != nil {
+			return nil, fmt.Errorf("error reading column %s: %w", column.Name(), err)
+		}
+		row[column.Name()] = v
+	}
+	return row, nil
+}
+
+// The number of rows affected by a statement that returns rows is not available, so the number of rows read is used.
+func (r *sqlRows) close() (uint32, error) {
+	err := r.rows.Close()
+	if e := r.rows.Err(); e != nil {
+		err = e
+	}
+	return r.count, err
+}
+
+// execResult is the result of a statement that was executed without reading rows.
+type execResult struct {
+	rowsAffected uint32
+}
+
+func (r *execResult) next() bool {
+	return false
+}
+
+func (r *execResult) row() (map[string]any, error) {
+	return nil, errors.New("no rows")
+}
+
+func (r *execResult) close() (uint32, error) {
+	return r.rowsAffected, nil
+}
+
+// convertParams converts the deserialized JSON parameters to values that database/sql drivers accept.
+func convertParams(params []any) ([]any, error) {
+	args := make([]any, len(params))
+	for i, p := range params {
+		switch v := p.(type) {
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				args[i] = n
+			} else if f, err := v.Float64(); err == nil {
+				args[i] = f
+			} else {
+				args[i] = v.String()
+			}
+		case map[string]any, []any:
+			// Objects and arrays are passed as JSON, such as for a JSON column.
+			bytes, err := utils.JsonSerialize(v)
+			if err != nil {
+				return nil, fmt.Errorf("error serializing query parameter %d: %w", i+1, err)
+			}
+			args[i] = string(bytes)
+		default:
+			args[i] = v
+		}
+	}
+	return args, nil
+}
+
+// getFirstKeyword returns the first keyword of the statement in upper case, skipping any leading comments.
+func getFirstKeyword(stmt string) string {
+	s := stmt
+	for {
+		s = strings.TrimLeft(s, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(s, "--"), strings.HasPrefix(s, "#"):
+			i := strings.IndexByte(s, '\n')
+			if i < 0 {
+				return ""
+			}
+			s = s[i+1:]
+		case strings.HasPrefix(s, "/*"):
+			i := strings.Index(s, "*/")
+			if i < 0 {
+				return ""
+			}
+			s = s[i+2:]
+		default:
+			end := strings.IndexFunc(s, func(r rune) bool {
+				return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+			})
+			if end >= 0 {
+				s = s[:end]
+			}
+			return strings.ToUpper(s)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/storage"
+
+	_ "modernc.org/sqlite"
+)
+
+type sqliteDialect struct{}
+
+// sqliteDS is a SQLite data source.  When the app is in AWS storage, the database file is copied
+// to a temporary file, which is removed when the data source is closed.
+type sqliteDS struct {
+	*sqlDS
+	tempFile string
+}
+
+func (ds *sqliteDS) close() {
+	ds.sqlDS.close()
+	if ds.tempFile != "" {
+		os.Remove(ds.tempFile)
+	}
+}
+
+// beginCursor begins the transaction of a cursor.  The only connection to an in-memory database can't be held by a cursor,
+// because any other query would wait for it until the cursor is closed.  For a database file, the transaction is deferred
+// rather than immediate, so that a cursor that only reads doesn't hold the write lock while it is open.
+func (ds *sqliteDS) beginCursor(ctx context.Context) (transaction, error) {
+	if ds.db.Stats().MaxOpenConnections == 1 {
+		return nil, errors.New("cursors can't be used with an in-memory SQLite database, because its only connection would be held until the cursor is closed")
+	}
+
+	// The driver begins read-only transactions as deferred, instead of using the _txlock mode of the connection.
+	// Statements that modify data are still allowed, and take the write lock when they run.
+	tx, err := ds.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("error setting up a new tx: %w", err)
+	}
+	return &sqlTx{tx, ds.dialect}, nil
+}
+
+func openSqliteDB(ctx context.Context, info manifest.SqliteConnectionInfo) (*sqliteDS, error) {
+	if info.Path == "" {
+		return nil, fmt.Errorf("sqlite connection [%s] has empty path", info.Name)
+	}
+
+	if info.Path == manifest.SqliteMemoryPath {
+		db, err := sql.Open("sqlite", sqliteDSN(info.Path, info.ReadOnly))
+		if err != nil {
+			return nil, err
+		}
+
+		// Each connection to an in-memory database has its own database, so only a single connection is used,
+		// and it is kept open for the lifetime of the data source.
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+		db.SetConnMaxIdleTime(0)
+
+		return &sqliteDS{sqlDS: &sqlDS{db, sqliteDialect{}}}, nil
+	}
+
+	if !filepath.IsLocal(info.Path) {
+		return nil, fmt.Errorf("sqlite connection [%s] must have a path within the app directory", info.Name)
+	}
+
+	var path, tempFile string
+	if config.UseAwsStorage {
+		content, err := storage.GetFileContents(ctx, info.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := os.CreateTemp("", "modus-*.db")
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if _, err := f.Write(content); err != nil {
+			os.Remove(f.Name())
+			return nil, err
+		}
+
+		path = f.Name()
+		tempFile = f.Name()
+
+		if !info.ReadOnly {
+			logger.Warn(ctx).
+				Str("connection", info.Name).
+				Msg("The SQLite database is a copy of the file in app storage.  Changes to it will not be persisted.")
+		}
+	} else {
+		path = filepath.Join(config.AppPath, info.Path)
+	}
+
+	db, err := sql.Open("sqlite", sqliteDSN(path, info.ReadOnly))
+	if err != nil {
+		if tempFile != "" {
+			os.Remove(tempFile)
+		}
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(max(4, runtime.NumCPU()))
+	db.SetMaxIdleConns(max(4, runtime.NumCPU()))
+
+	return &sqliteDS{&sqlDS{db, sqliteDialect{}}, tempFile}, nil
+}
+
+func sqliteDSN(path string, readOnly bool) string {
+	q := url.Values{}
+	q.Add("_pragma", "busy_timeout(5000)")
+	q.Add("_pragma", "foreign_keys(1)")
+	if readOnly {
+		q.Set("mode", "ro")
+	} else {
+		// Take the write lock when the transaction begins, so that concurrent transactions
+		// wait for each other instead of failing when they try to write.
+		q.Set("_txlock", "immediate")
+	}
+
+	if path == manifest.SqliteMemoryPath {
+		return path + "?" + q.Encode()
+	}
+
+	if !readOnly {
+		// Use write-ahead logging, so that reading, such as with an open cursor, doesn't block writing.
+		q.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: q.Encode()}
+	return u.String()
+}
+
+// SQLite reports the number of affected rows for a statement that returns rows, but database/sql
+// doesn't expose it, so statements that modify data are executed unless they have a RETURNING clause.
+func (sqliteDialect) returnsRows(stmt string) bool {
+	switch getFirstKeyword(stmt) {
+	case "INSERT", "UPDATE", "DELETE", "REPLACE":
+		return strings.Contains(strings.ToUpper(stmt), "RETURNING")
+	default:
+		return true
+	}
+}
+
+// SQLite values are dynamically typed, so the declared type of the column is used
+// for the values that have no equivalent storage class.
+func (sqliteDialect) convertValue(dbType string, value any) (any, error) {
+	switch v := value.(type) {
+	case int64:
+		if dbType == "BOOLEAN" || dbType == "BOOL" {
+			return v != 0, nil
+		}
+	case string:
+		if (dbType == "JSON" || dbType == "JSONB") && json.Valid([]byte(v)) {
+			return json.RawMessage(v), nil
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteQuery(t *testing.T) {
+	ctx := context.Background()
+	ds, err := openSqliteDB(ctx, manifest.SqliteConnectionInfo{Name: "test", Path: manifest.SqliteMemoryPath})
+	require.NoError(t, err)
+	defer ds.close()
+
+	_, err = queryInNewTx(ctx, ds, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT, active BOOLEAN, tags JSON)", nil)
+	require.NoError(t, err)
+
+	response, err := queryInNewTx(ctx, ds, "INSERT INTO items (name, active, tags) VALUES (?, ?, ?), (?, ?, ?)",
+		[]any{"apple", true, []any{"fruit"}, "carrot", false, nil})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), response.RowsAffected)
+
+	response, err = queryInNewTx(ctx, ds, "UPDATE items SET active = ? WHERE id = ? RETURNING id, name", []any{true, json.Number("2")})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), response.RowsAffected)
+	assert.JSONEq(t, `[{"id":2,"name":"carrot"}]`, string(response.ResultJson))
+
+	// The in-memory database is kept across queries.
+	response, err = queryInNewTx(ctx, ds, "SELECT * FROM items ORDER BY id", nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), response.RowsAffected)
+
+	assert.JSONEq(t, `[{"id":1,"name":"apple","active":true,"tags":["fruit"]},{"id":2,"name":"carrot","active":true,"tags":null}]`, string(response.ResultJson))
+
+	_, err = queryInNewTx(ctx, ds, "SELECT * FROM missing", nil)
+	assert.Error(t, err)
+}
+
+func TestSqliteFile(t *testing.T) {
+	ctx := context.Background()
+	config.AppPath = t.TempDir()
+	defer func() { config.AppPath = "" }()
+
+	ds, err := openSqliteDB(ctx, manifest.SqliteConnectionInfo{Name: "test", Path: "reference.db"})
+	require.NoError(t, err)
+
+	_, err = queryInNewTx(ctx, ds, "CREATE TABLE countries (code TEXT PRIMARY KEY, name TEXT)", nil)
+	require.NoError(t, err)
+	_, err = queryInNewTx(ctx, ds, "INSERT INTO countries VALUES ('NZ', 'New Zealand')", nil)
+	require.NoError(t, err)
+	ds.close()
+
+	ds, err = openSqliteDB(ctx, manifest.SqliteConnectionInfo{Name: "test", Path: "reference.db", ReadOnly: true})
+	require.NoError(t, err)
+	defer ds.close()
+
+	response, err := queryInNewTx(ctx, ds, "SELECT name FROM countries WHERE code = ?", []any{"NZ"})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"name":"New Zealand"}]`, string(response.ResultJson))
+
+	_, err = queryInNewTx(ctx, ds, "DELETE FROM countries", nil)
+	assert.Error(t, err)
+
+	_, err = openSqliteDB(ctx, manifest.SqliteConnectionInfo{Name: "test", Path: "../outside.db"})
+	assert.Error(t, err)
+}
+
+func TestSqliteReturnsRows(t *testing.T) {
+	tests := map[string]bool{
+		"SELECT * FROM items":                             true,
+		"CREATE TABLE t (id INTEGER)":                     true,
+		"INSERT INTO items (name) VALUES (?)":             false,
+		"insert into items (name) values (?) returning *": true,
+		"DELETE FROM items":                               false,
+	}
+
+	for stmt, expected := range tests {
+		assert.Equal(t, expected, sqliteDialect{}.returnsRows(stmt), stmt)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/puzpuzpuz/xsync/v3"
+	"github.com/rs/xid"
+)
+
+// openTransactions holds the transactions that were begun by function executions, by transaction id.
+var openTransactions = xsync.NewMapOf[string, *openTx]()
+
+type openTx struct {
+	tx          transaction
+	executionId string
+
+	// stop unregisters the rollback that happens when the execution ends.
+	stop func() bool
+
+	// mu serializes the use of the transaction, which is not safe for concurrent use.
+	mu sync.Mutex
+}
+
+// BeginTransaction begins a transaction on the connection, and returns its id.
+// The transaction can only be used by the function execution that began it, and it is rolled back
+// if it hasn't been committed or rolled back by the time the execution ends.
+func BeginTransaction(ctx context.Context, connectionName, dbType string) (string, error) {
+	executionId, ok := ctx.Value(utils.ExecutionIdContextKey).(string)
+	if !ok {
+		return "", errors.New("transactions can only be used from within a function execution")
+	}
+
+	ds, err := getDataSource(ctx, connectionName, dbType)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := ds.begin(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	id := xid.New().String()
+	t := &openTx{tx: tx, executionId: executionId}
+	openTransactions.Store(id, t)
+
+	// The context of a host function call is done when the function execution ends.
+	t.stop = context.AfterFunc(ctx, func() {
+		if t, ok := openTransactions.LoadAndDelete(id); ok {
+			logger.Warn(ctx).
+				Str("transaction_id", id).
+				Bool("user_visible", true).
+				Msg("Rolling back a database transaction that was not committed before the function ended.")
+
+			ctx := context.WithoutCancel(ctx)
+			if err := t.rollback(ctx); err != nil {
+				logger.Warn(ctx).Err(err).Msg("Error rolling back transaction.")
+			}
+		}
+	})
+
+	return id, nil
+}
+
+// ExecuteQueryInTransaction runs the statement within the transaction, without committing it.
+func ExecuteQueryInTransaction(ctx context.Context, transactionId, statement, paramsJson string) (*HostQueryResponse, error) {
+	params, err := parseParams(paramsJson)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := getTransaction(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	dbResponse, err := runQuery(ctx, t.tx, statement, params)
+	t.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return newHostQueryResponse(dbResponse)
+}
+
+// CommitTransaction commits the transaction.  The transaction ends, even if the commit fails.
+func CommitTransaction(ctx context.Context, transactionId string) error {
+	t, err := endTransaction(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+
+	if err := t.commit(ctx); err != nil {
+		// Release the connection, in case the transaction is still open after the failed commit.
+		_ = t.rollback(context.WithoutCancel(ctx))
+		return err
+	}
+	return nil
+}
+
+// RollbackTransaction rolls back the transaction.
+func RollbackTransaction(ctx context.Context, transactionId string) error {
+	t, err := endTransaction(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+
+	return t.rollback(ctx)
+}
+
+func getTransaction(ctx context.Context, transactionId string) (*openTx, error) {
+	t, ok := openTransactions.Load(transactionId)
+	if !ok || t.executionId != ctx.Value(utils.ExecutionIdContextKey) {
+		return nil, fmt.Errorf("transaction [%s] not found", transactionId)
+	}
+	return t, nil
+}
+
+// endTransaction removes the transaction, so that it can't be used again.
+func endTransaction(ctx context.Context, transactionId string) (*openTx, error) {
+	t, err := getTransaction(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := openTransactions.LoadAndDelete(transactionId); !ok {
+		return nil, fmt.Errorf("transaction [%s] not found", transactionId)
+	}
+
+	t.stop()
+	return t, nil
+}
+
+func (t *openTx) commit(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tx.commit(ctx)
+}
+
+func (t *openTx) rollback(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tx.rollback(ctx)
+}
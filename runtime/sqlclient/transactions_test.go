@@ -0,0 +1,93 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactions(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Connections: map[string]manifest.ConnectionInfo{
+			"scratch": manifest.SqliteConnectionInfo{Name: "scratch", Type: manifest.ConnectionTypeSqlite, Path: manifest.SqliteMemoryPath},
+		},
+	})
+	defer ShutdownPools()
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), utils.ExecutionIdContextKey, "execution1"))
+	defer cancel()
+
+	_, err := ExecuteQuery(ctx, "scratch", "sqlite", "CREATE TABLE counters (name TEXT PRIMARY KEY, value INTEGER)", "[]")
+	require.NoError(t, err)
+	_, err = ExecuteQuery(ctx, "scratch", "sqlite", "INSERT INTO counters VALUES ('visits', 1)", "[]")
+	require.NoError(t, err)
+
+	// A committed transaction keeps its changes.
+	txId, err := BeginTransaction(ctx, "scratch", "sqlite")
+	require.NoError(t, err)
+
+	response, err := ExecuteQueryInTransaction(ctx, txId, "SELECT value FROM counters WHERE name = ?", `["visits"]`)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"value":1}]`, *response.ResultJson)
+
+	response, err = ExecuteQueryInTransaction(ctx, txId, "UPDATE counters SET value = ? WHERE name = ?", `[2,"visits"]`)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), response.RowsAffected)
+
+	require.NoError(t, CommitTransaction(ctx, txId))
+	assert.Error(t, CommitTransaction(ctx, txId))
+
+	response, err = ExecuteQuery(ctx, "scratch", "sqlite", "SELECT value FROM counters", "[]")
+	require.NoError(t, err)
+	assert.Equal(t, `[{"value":2}]`, *response.ResultJson)
+
+	// A rolled back transaction discards its changes.
+	txId, err = BeginTransaction(ctx, "scratch", "sqlite")
+	require.NoError(t, err)
+	_, err = ExecuteQueryInTransaction(ctx, txId, "UPDATE counters SET value = 3", "[]")
+	require.NoError(t, err)
+	require.NoError(t, RollbackTransaction(ctx, txId))
+
+	_, err = ExecuteQueryInTransaction(ctx, txId, "SELECT 1", "[]")
+	assert.Error(t, err)
+
+	response, err = ExecuteQuery(ctx, "scratch", "sqlite", "SELECT value FROM counters", "[]")
+	require.NoError(t, err)
+	assert.Equal(t, `[{"value":2}]`, *response.ResultJson)
+
+	// A transaction can't be used by another execution.
+	txId, err = BeginTransaction(ctx, "scratch", "sqlite")
+	require.NoError(t, err)
+	_, err = ExecuteQueryInTransaction(ctx, txId, "UPDATE counters SET value = 4", "[]")
+	require.NoError(t, err)
+
+	otherCtx := context.WithValue(context.Background(), utils.ExecutionIdContextKey, "execution2")
+	_, err = ExecuteQueryInTransaction(otherCtx, txId, "SELECT 1", "[]")
+	assert.Error(t, err)
+	assert.Error(t, CommitTransaction(otherCtx, txId))
+
+	// A transaction is rolled back when the execution ends.
+	cancel()
+	response, err = ExecuteQuery(otherCtx, "scratch", "sqlite", "SELECT value FROM counters", "[]")
+	require.NoError(t, err)
+	assert.Equal(t, `[{"value":2}]`, *response.ResultJson)
+	assert.Zero(t, openTransactions.Size())
+
+	_, err = BeginTransaction(context.Background(), "scratch", "sqlite")
+	assert.Error(t, err)
+}
@@ -9,9 +9,45 @@
 
 package sqlclient
 
+import "context"
+
+// dataSource is the interface for a pool of connections to a database.
+type dataSource interface {
+	begin(ctx context.Context) (transaction, error)
+	close()
+}
+
+// cursorSource is implemented by data sources that begin the transactions of cursors differently than other transactions,
+// because a cursor holds its connection until it is closed.
+type cursorSource interface {
+	beginCursor(ctx context.Context) (transaction, error)
+}
+
+// transaction is the interface for a database transaction, which holds a connection from the pool until it ends.
+// Rolling back a transaction that has already ended is not an error.
+type transaction interface {
+	query(ctx context.Context, stmt string, params []any) (rowReader, error)
+	commit(ctx context.Context) error
+	rollback(ctx context.Context) error
+}
+
+// rowReader reads the rows that are returned by a statement, one at a time, as they are received from the database.
+// The transaction can't run another statement until the reader is closed.
+type rowReader interface {
+	// next advances to the next row.  It returns false when there are no more rows, or if an error occurred.
+	next() bool
+
+	// row returns the current row.
+	row() (map[string]any, error)
+
+	// close releases the rows, and returns the number of rows affected by the statement,
+	// or the error that stopped reading the rows, if any.
+	close() (uint32, error)
+}
+
 type dbResponse struct {
 	Error        *string
-	Result       any
+	ResultJson   []byte
 	RowsAffected uint32
 }
 
@@ -20,3 +56,8 @@ type HostQueryResponse struct {
 	ResultJson   *string
 	RowsAffected uint32
 }
+
+// HostCopyResponse is the result of copying rows, which can be more than fit in the count of a query response.
+type HostCopyResponse struct {
+	RowsAffected uint64
+}
@@ -11,9 +11,15 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/hypermodeinc/modus/runtime/aws"
 	"github.com/hypermodeinc/modus/runtime/config"
@@ -22,8 +28,17 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
+// The maximum total size of the files that are downloaded for a snapshot of a directory in S3.
+const maxDirSnapshotSize = 256 * 1024 * 1024
+
+var errDirSnapshotTooLarge = errors.New("the size limit of the snapshot was exceeded")
+
 type awsStorageProvider struct {
 	s3Client *s3.Client
+
+	// snapshots holds the temporary directory of the latest snapshot of each directory that was requested.
+	snapshots      map[string]string
+	snapshotsMutex sync.Mutex
 }
 
 func (stg *awsStorageProvider) initialize(ctx context.Context) {
@@ -96,3 +111,114 @@ func (stg *awsStorageProvider) getFileContents(ctx context.Context, name string)
 
 	return content, nil
 }
+
+func (stg *awsStorageProvider) getDirFS(ctx context.Context, dir string) (fs.FS, error) {
+
+	// S3 has no directories, so the files are downloaded into a snapshot of the directory, in a temporary directory.
+	snapshotDir, err := os.MkdirTemp("", "modus-s3-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temporary directory for directory %s from S3: %w", dir, err)
+	}
+
+	if err := stg.downloadDir(ctx, dir, snapshotDir); err != nil {
+		_ = os.RemoveAll(snapshotDir)
+		return nil, err
+	}
+
+	// The new snapshot replaces any previous snapshot of the same directory.
+	stg.snapshotsMutex.Lock()
+	previous := stg.snapshots[dir]
+	stg.snapshots[dir] = snapshotDir
+	stg.snapshotsMutex.Unlock()
+
+	if previous != "" {
+		if err := os.RemoveAll(previous); err != nil {
+			logger.Warn(ctx).Err(err).Str("snapshot_dir", previous).Msg("Failed to remove a previous snapshot of a directory from S3.")
+		}
+	}
+
+	return os.DirFS(snapshotDir), nil
+}
+
+// downloadDir downloads the files of a directory in S3 into the snapshot directory,
+// failing if their total size exceeds maxDirSnapshotSize.
+func (stg *awsStorageProvider) downloadDir(ctx context.Context, dir, snapshotDir string) error {
+	prefix := path.Join(config.S3Path, dir) + "/"
+	input := &s3.ListObjectsV2Input{
+		Bucket: &config.S3Bucket,
+		Prefix: &prefix,
+	}
+
+	var remaining int64 = maxDirSnapshotSize
+	paginator := s3.NewListObjectsV2Paginator(stg.s3Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list files of directory %s in S3 bucket: %w", dir, err)
+		}
+
+		for _, obj := range page.Contents {
+			name := strings.TrimPrefix(*obj.Key, prefix)
+			if name == "" || strings.HasSuffix(name, "/") || !fs.ValidPath(name) {
+				continue
+			}
+
+			if obj.Size != nil && *obj.Size > remaining {
+				return dirTooLargeError(dir)
+			}
+
+			filePath := filepath.Join(snapshotDir, filepath.FromSlash(name))
+			n, err := stg.downloadFile(ctx, *obj.Key, filePath, remaining)
+			if errors.Is(err, errDirSnapshotTooLarge) {
+				return dirTooLargeError(dir)
+			} else if err != nil {
+				return fmt.Errorf("failed to get file %s of directory %s from S3: %w", name, dir, err)
+			}
+			remaining -= n
+
+			if obj.LastModified != nil {
+				_ = os.Chtimes(filePath, *obj.LastModified, *obj.LastModified)
+			}
+		}
+	}
+
+	return nil
+}
+
+func dirTooLargeError(dir string) error {
+	return fmt.Errorf("directory %s in S3 bucket exceeds the maximum size of %d MB", dir, maxDirSnapshotSize/(1024*1024))
+}
+
+// downloadFile writes the contents of an object in S3 to a file, and returns the number of bytes written.
+// It fails if the object is larger than the given limit.
+func (stg *awsStorageProvider) downloadFile(ctx context.Context, key, filePath string, limit int64) (int64, error) {
+	input := &s3.GetObjectInput{
+		Bucket: &config.S3Bucket,
+		Key:    &key,
+	}
+
+	obj, err := stg.s3Client.GetObject(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	defer obj.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return 0, err
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(obj.Body, limit+1))
+	if err != nil {
+		return n, err
+	} else if n > limit {
+		return n, errDirSnapshotTooLarge
+	}
+
+	return n, f.Close()
+}
@@ -12,6 +12,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -108,3 +109,15 @@ func (stg *localStorageProvider) getFileContents(ctx context.Context, name strin
 
 	return content, nil
 }
+
+func (stg *localStorageProvider) getDirFS(ctx context.Context, dir string) (fs.FS, error) {
+	path := filepath.Join(config.AppPath, dir)
+	if info, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to access directory %s in local storage: %w", dir, err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory in local storage", dir)
+	}
+
+	// The directory is used in place, so changes to its files are seen immediately.
+	return os.DirFS(path), nil
+}
@@ -11,6 +11,7 @@ package storage
 
 import (
 	"context"
+	"io/fs"
 	"time"
 
 	"github.com/hypermodeinc/modus/runtime/config"
@@ -24,6 +25,7 @@ type storageProvider interface {
 	initialize(ctx context.Context)
 	listFiles(ctx context.Context, patterns ...string) ([]FileInfo, error)
 	getFileContents(ctx context.Context, name string) ([]byte, error)
+	getDirFS(ctx context.Context, dir string) (fs.FS, error)
 }
 
 type FileInfo struct {
@@ -37,7 +39,7 @@ func Initialize(ctx context.Context) {
 	defer span.Finish()
 
 	if config.UseAwsStorage {
-		provider = &awsStorageProvider{}
+		provider = &awsStorageProvider{snapshots: make(map[string]string)}
 	} else {
 		provider = &localStorageProvider{}
 	}
@@ -55,3 +57,11 @@ func GetFileContents(ctx context.Context, name string) ([]byte, error) {
 
 	return provider.getFileContents(ctx, name)
 }
+
+// GetDirFS returns a read-only filesystem for the given directory in app storage, including its subdirectories.
+func GetDirFS(ctx context.Context, dir string) (fs.FS, error) {
+	span, ctx := utils.NewSentrySpanForCurrentFunc(ctx)
+	defer span.Finish()
+
+	return provider.getDirFS(ctx, dir)
+}
@@ -46,7 +46,7 @@ func (f *WasmTestFixture) CallFunction(t *testing.T, name string, paramValues ..
 
 	fnInfo, ok := functions.NewFunctionInfo(name, f.Plugin, false)
 	if !ok {
-		return nil, fmt.Errorf("no function registered named %s", name)
+		return nil, fmt.Errorf("%w named %s", functions.ErrFunctionNotRegistered, name)
 	}
 
 	params, err := functions.CreateParametersMap(fnInfo.Metadata(), paramValues...)
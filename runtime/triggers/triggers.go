@@ -0,0 +1,317 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package triggers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/functions"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+	"github.com/hypermodeinc/modus/runtime/sqlclient"
+	"github.com/hypermodeinc/modus/runtime/wasmhost"
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// How often an instance that is standing by checks whether it can take over listening for a trigger.
+const standbyInterval = 5 * time.Second
+
+const minReconnectDelay = 1 * time.Second
+const maxReconnectDelay = 1 * time.Minute
+
+var globalTriggers = &triggers{
+	listeners: make(map[string]*listener),
+}
+
+type triggers struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	listeners map[string]*listener
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+}
+
+type listener struct {
+	info       manifest.TriggerInfo
+	connection manifest.PostgresqlConnectionInfo
+	stop       context.CancelFunc
+}
+
+// Initialize starts listening for the notifications of the triggers in the manifest,
+// and updates the triggers whenever the manifest or the registered functions change.
+func Initialize(ctx context.Context) {
+	globalTriggers.ctx, globalTriggers.cancel = context.WithCancel(ctx)
+	manifestdata.RegisterManifestLoadedCallback(globalTriggers.update)
+	functions.RegisterFunctionsLoadedCallback(func(ctx context.Context) {
+		_ = globalTriggers.update(ctx)
+	})
+}
+
+// isFunctionRegistered is a variable so that it can be replaced in tests.
+var isFunctionRegistered = defaultIsFunctionRegistered
+
+// defaultIsFunctionRegistered reports whether a loaded plugin exports the function.
+func defaultIsFunctionRegistered(ctx context.Context, fnName string) bool {
+	_, err := wasmhost.GetWasmHost(ctx).GetFunctionInfo(fnName)
+	return err == nil
+}
+
+// Shutdown stops listening for notifications, and waits for any runs in progress to end.
+func Shutdown(ctx context.Context) {
+	t := globalTriggers
+	if t.cancel == nil {
+		return
+	}
+
+	t.mu.Lock()
+	for name, l := range t.listeners {
+		l.stop()
+		delete(t.listeners, name)
+	}
+	t.mu.Unlock()
+
+	t.cancel()
+	t.wg.Wait()
+}
+
+func (t *triggers) update(ctx context.Context) error {
+	man := manifestdata.GetManifest()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Stop any triggers that were removed or changed, including changes to their connection,
+	// and any triggers whose function is no longer registered.
+	for name, l := range t.listeners {
+		info, ok := man.Triggers[name]
+		if ok && reflect.DeepEqual(info, l.info) && reflect.DeepEqual(man.Connections[info.Connection], l.connection) &&
+			isFunctionRegistered(t.ctx, info.Function) {
+			continue
+		}
+		l.stop()
+		delete(t.listeners, name)
+		logger.Info(ctx).Str("trigger", name).Msg("Stopped trigger.")
+	}
+
+	// Start any triggers that were added or changed.
+	for name, info := range man.Triggers {
+		if _, ok := t.listeners[name]; ok {
+			continue
+		}
+
+		connection, ok := man.Connections[info.Connection].(manifest.PostgresqlConnectionInfo)
+		if !ok {
+			logger.Error(ctx).
+				Str("trigger", name).
+				Str("function", info.Function).
+				Str("connection", info.Connection).
+				Bool("user_visible", true).
+				Msg("Invalid trigger in manifest.  The connection must be the name of a postgresql connection.  The function will not be run.")
+			continue
+		}
+
+		// Notifications are not kept for a listener that isn't connected, so a trigger doesn't listen
+		// until its function can be run.  The triggers are updated again when the functions are registered.
+		if !isFunctionRegistered(t.ctx, info.Function) {
+			logger.Info(ctx).
+				Str("trigger", name).
+				Str("function", info.Function).
+				Msg("Trigger is waiting for its function to be loaded.")
+			continue
+		}
+
+		l := &listener{
+			info:       info,
+			connection: connection,
+		}
+
+		var lctx context.Context
+		lctx, l.stop = context.WithCancel(t.ctx)
+		t.listeners[name] = l
+
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+			l.worker(lctx)
+		}()
+
+		logger.Info(ctx).
+			Str("trigger", name).
+			Str("function", info.Function).
+			Str("connection", info.Connection).
+			Str("channel", info.Channel).
+			Msg("Started trigger.")
+	}
+
+	return nil
+}
+
+// worker listens on the channel until the trigger is stopped, reconnecting whenever the connection is lost.
+// Errors are logged once per outage, rather than on every attempt to reconnect.
+//
+// Only one instance of the runtime listens for each trigger, so that each notification runs the function once.
+// The others stand by, and one of them takes over if the instance that is listening stops or loses its connection.
+func (l *listener) worker(ctx context.Context) {
+	name := l.info.Name
+	lockKey := "modus_trigger:" + name
+	attempts := 0
+	connected := false
+	standby := false
+
+	for {
+		ln, err := sqlclient.Listen(ctx, l.info.Connection, l.info.Channel, lockKey)
+		if errors.Is(err, sqlclient.ErrListenerLocked) {
+			if !standby {
+				logger.Info(ctx).
+					Str("trigger", name).
+					Str("channel", l.info.Channel).
+					Msg("Another instance of the runtime is listening for notifications.  Standing by.")
+			}
+			standby = true
+			attempts = 0
+			connected = false
+			if !sleep(ctx, standbyInterval) {
+				return
+			}
+			continue
+		} else if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if attempts == 0 {
+				logger.Error(ctx).Err(err).
+					Str("trigger", name).
+					Str("connection", l.info.Connection).
+					Str("channel", l.info.Channel).
+					Bool("user_visible", true).
+					Msg("Failed to listen for notifications.  Retrying until connected.")
+			}
+			attempts++
+			if !sleep(ctx, getReconnectDelay(attempts)) {
+				return
+			}
+			continue
+		}
+
+		if connected || attempts > 0 {
+			// Postgres doesn't keep notifications for a listener that isn't connected.
+			logger.Warn(ctx).
+				Str("trigger", name).
+				Str("channel", l.info.Channel).
+				Bool("user_visible", true).
+				Msg("Reconnected.  Any notifications sent while disconnected were not received.")
+		} else if standby {
+			logger.Info(ctx).Str("trigger", name).Str("channel", l.info.Channel).Msg("Took over listening for notifications.")
+		} else {
+			logger.Debug(ctx).Str("trigger", name).Str("channel", l.info.Channel).Msg("Listening for notifications.")
+		}
+		attempts = 0
+		connected = true
+		standby = false
+
+		err = l.receive(ctx, ln)
+		_ = ln.Close(context.WithoutCancel(ctx))
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Warn(ctx).Err(err).
+			Str("trigger", name).
+			Str("connection", l.info.Connection).
+			Str("channel", l.info.Channel).
+			Bool("user_visible", true).
+			Msg("Lost connection while listening for notifications.  Reconnecting.")
+		attempts = 1
+		if !sleep(ctx, minReconnectDelay) {
+			return
+		}
+	}
+}
+
+// receive runs the function for each notification, in the order they are received, until the connection fails.
+func (l *listener) receive(ctx context.Context, ln *sqlclient.Listener) error {
+	for {
+		payload, err := ln.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.run(ctx, payload)
+	}
+}
+
+// run runs the function once for the notification.  A notification is delivered at most once:
+// the outcome is logged, but a run that fails is not retried.
+func (l *listener) run(ctx context.Context, payload string) {
+	name := l.info.Name
+	fnName := l.info.Function
+
+	host := wasmhost.GetWasmHost(ctx)
+
+	// The payload is passed to the function, unless it takes no arguments.
+	var args []any
+	if fnInfo, err := host.GetFunctionInfo(fnName); err == nil && len(fnInfo.Metadata().Parameters) > 0 {
+		args = []any{payload}
+	}
+
+	start := time.Now()
+	_, err := host.CallFunctionByName(ctx, fnName, args...)
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.Error(ctx).Err(err).
+			Str("trigger", name).
+			Str("function", fnName).
+			Dur("duration_ms", duration).
+			Bool("user_visible", true).
+			Msg("Triggered run failed.  The notification will not be retried.")
+		metrics.TriggeredRunsNum.WithLabelValues(name, outcomeError).Inc()
+		return
+	}
+
+	logger.Info(ctx).
+		Str("trigger", name).
+		Str("function", fnName).
+		Dur("duration_ms", duration).
+		Bool("user_visible", true).
+		Msg("Triggered run completed.")
+	metrics.TriggeredRunsNum.WithLabelValues(name, outcomeSuccess).Inc()
+}
+
+// getReconnectDelay returns the delay before the next attempt to reconnect, doubling with each attempt.
+func getReconnectDelay(attempts int) time.Duration {
+	delay := float64(minReconnectDelay) * math.Pow(2, float64(attempts-1))
+	if delay > float64(maxReconnectDelay) {
+		return maxReconnectDelay
+	}
+	return time.Duration(delay)
+}
+
+// sleep waits for the duration, and reports whether it ended before the context was done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package triggers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/secrets"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReconnectDelay(t *testing.T) {
+	assert.Equal(t, 1*time.Second, getReconnectDelay(1))
+	assert.Equal(t, 2*time.Second, getReconnectDelay(2))
+	assert.Equal(t, 32*time.Second, getReconnectDelay(6))
+	assert.Equal(t, maxReconnectDelay, getReconnectDelay(7))
+	assert.Equal(t, maxReconnectDelay, getReconnectDelay(100))
+}
+
+func TestUpdateSkipsTriggersWithoutPostgresqlConnection(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Connections: map[string]manifest.ConnectionInfo{
+			"scratch": manifest.SqliteConnectionInfo{Name: "scratch", Type: manifest.ConnectionTypeSqlite, Path: manifest.SqliteMemoryPath},
+		},
+		Triggers: map[string]manifest.TriggerInfo{
+			"wrong-type": {Name: "wrong-type", Function: "onChange", Connection: "scratch", Channel: "changes"},
+			"missing":    {Name: "missing", Function: "onChange", Connection: "nope", Channel: "changes"},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tr := &triggers{listeners: make(map[string]*listener)}
+	tr.ctx, tr.cancel = context.WithCancel(ctx)
+
+	assert.NoError(t, tr.update(ctx))
+	assert.Empty(t, tr.listeners)
+}
+
+func TestUpdateWaitsForFunction(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Connections: map[string]manifest.ConnectionInfo{
+			"pg": manifest.PostgresqlConnectionInfo{Name: "pg", Type: manifest.ConnectionTypePostgresql, ConnStr: "postgres://localhost:1/none"},
+		},
+		Triggers: map[string]manifest.TriggerInfo{
+			"changes": {Name: "changes", Function: "onChange", Connection: "pg", Channel: "changes"},
+		},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	registered := false
+	isFunctionRegistered = func(ctx context.Context, fnName string) bool {
+		return registered && fnName == "onChange"
+	}
+	defer func() { isFunctionRegistered = defaultIsFunctionRegistered }()
+
+	ctx := context.Background()
+	secrets.Initialize(ctx)
+
+	tr := &triggers{listeners: make(map[string]*listener)}
+	tr.ctx, tr.cancel = context.WithCancel(ctx)
+	defer func() {
+		tr.cancel()
+		tr.wg.Wait()
+	}()
+
+	assert.NoError(t, tr.update(ctx))
+	assert.Empty(t, tr.listeners, "expected the trigger not to listen before its function is registered")
+
+	registered = true
+	assert.NoError(t, tr.update(ctx))
+	assert.Contains(t, tr.listeners, "changes")
+
+	registered = false
+	assert.NoError(t, tr.update(ctx))
+	assert.Empty(t, tr.listeners, "expected the trigger to stop when its function is no longer registered")
+}
@@ -13,6 +13,8 @@ type contextKey string
 
 const WasmHostContextKey contextKey = "wasm_host"
 const ExecutionIdContextKey contextKey = "execution_id"
+const ParentExecutionIdContextKey contextKey = "parent_execution_id"
+const ExecutionInfoContextKey contextKey = "execution_info"
 const PluginContextKey contextKey = "plugin"
 const MetadataContextKey contextKey = "metadata"
 const WasmAdapterContextKey contextKey = "wasm_adapter"
@@ -20,3 +22,6 @@ const FunctionNameContextKey contextKey = "function_name"
 const FunctionOutputContextKey contextKey = "function_output"
 const FunctionMessagesContextKey contextKey = "function_messages"
 const CustomTypesContextKey contextKey = "custom_types"
+const UploadsContextKey contextKey = "uploads"
+const RecordingContextKey contextKey = "recording"
+const ReplayContextKey contextKey = "replay"
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// getVersionNumber is a variable so that it can be replaced in tests.
+var getVersionNumber = config.GetVersionNumber
+
+// newCompilationCache returns a cache of compiled modules that persists across restarts, or nil if it is not configured.
+//
+// The cache is kept in a subdirectory for the runtime version, so that a new runtime never uses modules compiled by another.
+// Within it, wazero identifies each compiled module by a hash of the module's content, so a new build of a plugin
+// is always compiled again, while an unchanged plugin is loaded from the cache.
+// The subdirectories of other runtime versions are removed, since their modules can't be used anymore.
+func newCompilationCache(ctx context.Context) wazero.CompilationCache {
+	if config.CompilationCacheDir == "" {
+		return nil
+	}
+
+	versionDir := sanitizePathElement(getVersionNumber())
+	pruneCompilationCache(ctx, config.CompilationCacheDir, versionDir)
+
+	dir := filepath.Join(config.CompilationCacheDir, versionDir)
+	cache, err := wazero.NewCompilationCacheWithDir(dir)
+	if err != nil {
+		logger.Warn(ctx).Err(err).Str("cache_dir", dir).Msg("Failed to use the compilation cache.  Plugins will be compiled on each load.")
+		return nil
+	}
+
+	logger.Info(ctx).Str("cache_dir", dir).Msg("Using compilation cache.")
+	return cache
+}
+
+// pruneCompilationCache removes the subdirectories of the cache directory that hold modules compiled by other runtime versions.
+// Only directories that contain nothing but wazero's cache directories are removed, in case the cache directory is shared.
+func pruneCompilationCache(ctx context.Context, cacheDir, keep string) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || e.Name() == keep {
+			continue
+		}
+
+		dir := filepath.Join(cacheDir, e.Name())
+		if !isWazeroCacheDir(dir) {
+			continue
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warn(ctx).Err(err).Str("cache_dir", dir).Msg("Failed to remove an outdated compilation cache.")
+		} else {
+			logger.Info(ctx).Str("cache_dir", dir).Msg("Removed an outdated compilation cache.")
+		}
+	}
+}
+
+// isWazeroCacheDir reports whether the directory contains only the directories that wazero creates for its cache,
+// which are named for the wazero version, architecture and OS that compiled the modules they hold.
+func isWazeroCacheDir(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) == 0 {
+		return false
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), "wazero-") {
+			return false
+		}
+	}
+	return true
+}
+
+func sanitizePathElement(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
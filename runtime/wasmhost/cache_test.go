@@ -0,0 +1,117 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// A module with a single function that does nothing.
+var testCacheModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section: () -> ()
+	0x03, 0x02, 0x01, 0x00, // function section: one function of type 0
+	0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b, // code section: an empty body
+}
+
+func TestCompilationCache(t *testing.T) {
+	ctx := context.Background()
+
+	defer func(dir string) { config.CompilationCacheDir = dir }(config.CompilationCacheDir)
+	defer func(fn func() string) { getVersionNumber = fn }(getVersionNumber)
+
+	config.CompilationCacheDir = t.TempDir()
+	version := "v1.0.0"
+	getVersionNumber = func() string { return version }
+
+	compile := func() {
+		cache := newCompilationCache(ctx)
+		if cache == nil {
+			t.Fatal("expected a compilation cache")
+		}
+		runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigCompiler().WithCompilationCache(cache))
+		defer runtime.Close(ctx)
+		if _, err := runtime.CompileModule(ctx, testCacheModule); err != nil {
+			t.Fatalf("failed to compile module: %v", err)
+		}
+	}
+
+	compile()
+	v1Dir := filepath.Join(config.CompilationCacheDir, "v1.0.0")
+	first := getCacheFiles(t, v1Dir)
+	if len(first) == 0 {
+		t.Fatal("expected the compiled module to be cached")
+	}
+
+	// compiling the same module again is a cache hit, which doesn't write the cache
+	time.Sleep(10 * time.Millisecond)
+	compile()
+	second := getCacheFiles(t, v1Dir)
+	if len(second) != len(first) {
+		t.Fatalf("expected %d cached files, got %d", len(first), len(second))
+	}
+	for name, modTime := range first {
+		if !second[name].Equal(modTime) {
+			t.Errorf("expected cached file %s not to be written again", name)
+		}
+	}
+
+	// a new runtime version misses the cache, and removes the cache of the old version
+	version = "v2.0.0"
+	compile()
+	if files := getCacheFiles(t, filepath.Join(config.CompilationCacheDir, "v2.0.0")); len(files) == 0 {
+		t.Error("expected the module to be compiled again for the new version")
+	}
+	if _, err := os.Stat(v1Dir); !os.IsNotExist(err) {
+		t.Error("expected the cache of the old version to be removed")
+	}
+}
+
+func TestPruneCompilationCacheKeepsOtherDirectories(t *testing.T) {
+	cacheDir := t.TempDir()
+	other := filepath.Join(cacheDir, "other")
+	if err := os.MkdirAll(filepath.Join(other, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	pruneCompilationCache(context.Background(), cacheDir, "v1.0.0")
+
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("expected a directory that isn't a compilation cache to be kept: %v", err)
+	}
+}
+
+func getCacheFiles(t *testing.T, dir string) map[string]time.Time {
+	files := make(map[string]time.Time)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = info.ModTime()
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/plugins"
+)
+
+// errExecutionCanceled is the cause of the context of an execution that was canceled with CancelExecution.
+var errExecutionCanceled = fmt.Errorf("function execution was canceled: %w", context.Canceled)
+
+// A RunningExecution describes a function execution that is currently in progress.
+type RunningExecution struct {
+	ExecutionId       string    `json:"executionId"`
+	ParentExecutionId string    `json:"parentExecutionId,omitempty"`
+	Function          string    `json:"function"`
+	Plugin            string    `json:"plugin"`
+	StartTime         time.Time `json:"startTime"`
+	ElapsedMs         int64     `json:"elapsedMs"`
+}
+
+type runningExecution struct {
+	info   RunningExecution
+	plugin *plugins.Plugin
+	cancel context.CancelCauseFunc
+}
+
+var runningExecutions = make(map[string]*runningExecution)
+var runningExecutionsMutex sync.RWMutex
+
+// trackExecution registers an execution as running, and returns a context that is canceled
+// if the execution is canceled.  The returned function must be called when the execution completes.
+func trackExecution(ctx context.Context, execInfo *executionInfo, fnName string, plugin *plugins.Plugin) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	e := &runningExecution{
+		info: RunningExecution{
+			ExecutionId:       execInfo.executionId,
+			ParentExecutionId: execInfo.parentExecutionId,
+			Function:          fnName,
+			Plugin:            plugin.Name(),
+			StartTime:         time.Now().UTC(),
+		},
+		plugin: plugin,
+		cancel: cancel,
+	}
+
+	runningExecutionsMutex.Lock()
+	runningExecutions[e.info.ExecutionId] = e
+	runningExecutionsMutex.Unlock()
+
+	return ctx, func() {
+		runningExecutionsMutex.Lock()
+		delete(runningExecutions, e.info.ExecutionId)
+		runningExecutionsMutex.Unlock()
+		cancel(nil)
+	}
+}
+
+// GetRunningExecutions returns the function executions that are currently in progress, oldest first.
+func GetRunningExecutions() []RunningExecution {
+	runningExecutionsMutex.RLock()
+	defer runningExecutionsMutex.RUnlock()
+
+	now := time.Now()
+	results := make([]RunningExecution, 0, len(runningExecutions))
+	for _, e := range runningExecutions {
+		info := e.info
+		info.ElapsedMs = now.Sub(info.StartTime).Milliseconds()
+		results = append(results, info)
+	}
+
+	slices.SortFunc(results, func(a, b RunningExecution) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return results
+}
+
+// CancelExecution cancels the function execution with the given id, which terminates its module instance.
+// Any functions it called are also canceled.  It returns false if no such execution is running.
+func CancelExecution(executionId string) bool {
+	runningExecutionsMutex.RLock()
+	e, ok := runningExecutions[executionId]
+	runningExecutionsMutex.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	e.cancel(errExecutionCanceled)
+	return true
+}
+
+// errPluginRestored is returned by RetirePlugin when the plugin is restored before its calls have ended.
+var errPluginRestored = errors.New("the plugin was restored before its calls ended")
+
+// pluginRefs counts the calls that are using a plugin.  Once the plugin is retired, no new call can use it,
+// and idle is closed when the last call that was using it ends.
+type pluginRefs struct {
+	count    int
+	retired  bool
+	idle     chan struct{}
+	restored chan struct{}
+}
+
+var pluginRefCounts = make(map[*plugins.Plugin]*pluginRefs)
+var pluginRefsMutex sync.Mutex
+
+// acquirePlugin takes a reference to the plugin for a call, which must be released with releasePlugin when the call ends.
+// It returns false if the plugin was retired, in which case no reference is taken.
+func acquirePlugin(plugin *plugins.Plugin) bool {
+	pluginRefsMutex.Lock()
+	defer pluginRefsMutex.Unlock()
+
+	refs, ok := pluginRefCounts[plugin]
+	if !ok {
+		refs = &pluginRefs{}
+		pluginRefCounts[plugin] = refs
+	} else if refs.retired {
+		return false
+	}
+
+	refs.count++
+	return true
+}
+
+// releasePlugin releases a reference to the plugin that was taken by acquirePlugin.
+func releasePlugin(plugin *plugins.Plugin) {
+	pluginRefsMutex.Lock()
+	defer pluginRefsMutex.Unlock()
+
+	refs := pluginRefCounts[plugin]
+	refs.count--
+	if refs.count > 0 {
+		return
+	}
+
+	if refs.retired {
+		close(refs.idle)
+	} else {
+		delete(pluginRefCounts, plugin)
+	}
+}
+
+// RetirePlugin stops new calls from using the plugin, and waits until the calls that are using it have ended.
+// Calls to functions that were looked up before the plugin was retired use the build that replaced it instead.
+// It returns an error if the context is done first, or if the plugin is restored first.
+func RetirePlugin(ctx context.Context, plugin *plugins.Plugin) error {
+	pluginRefsMutex.Lock()
+	refs, ok := pluginRefCounts[plugin]
+	if !ok {
+		refs = &pluginRefs{}
+		pluginRefCounts[plugin] = refs
+	}
+	if !refs.retired {
+		refs.retired = true
+		refs.idle = make(chan struct{})
+		refs.restored = make(chan struct{})
+		if refs.count == 0 {
+			close(refs.idle)
+		}
+	}
+	idle, restored := refs.idle, refs.restored
+	pluginRefsMutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-restored:
+		return errPluginRestored
+	case <-idle:
+		return nil
+	}
+}
+
+// restorePlugin allows new calls to use a plugin that was retired.
+func restorePlugin(plugin *plugins.Plugin) {
+	pluginRefsMutex.Lock()
+	defer pluginRefsMutex.Unlock()
+
+	refs, ok := pluginRefCounts[plugin]
+	if !ok || !refs.retired {
+		return
+	}
+
+	close(refs.restored)
+	if refs.count == 0 {
+		delete(pluginRefCounts, plugin)
+	} else {
+		refs.retired = false
+		refs.idle = nil
+		refs.restored = nil
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+)
+
+func TestCancelExecution(t *testing.T) {
+	plugin := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "myPlugin"}}
+	execInfo := &executionInfo{executionId: "exec1"}
+	ctx, untrack := trackExecution(context.Background(), execInfo, "myFunction", plugin)
+
+	executions := GetRunningExecutions()
+	if len(executions) != 1 {
+		t.Fatalf("expected 1 running execution, got %d", len(executions))
+	}
+	if e := executions[0]; e.ExecutionId != "exec1" || e.Function != "myFunction" || e.Plugin != "myPlugin" {
+		t.Errorf("unexpected running execution: %+v", e)
+	}
+
+	if CancelExecution("other") {
+		t.Error("expected canceling an unknown execution to fail")
+	}
+	if ctx.Err() != nil {
+		t.Fatal("expected the execution not to be canceled")
+	}
+
+	if !CancelExecution("exec1") {
+		t.Fatal("expected the execution to be canceled")
+	}
+	if !errors.Is(context.Cause(ctx), errExecutionCanceled) {
+		t.Errorf("expected the context to be canceled by the request, got %v", context.Cause(ctx))
+	}
+	if !errors.Is(errExecutionCanceled, context.Canceled) {
+		t.Error("expected a canceled execution to be reported as context.Canceled")
+	}
+
+	untrack()
+	if n := len(GetRunningExecutions()); n != 0 {
+		t.Errorf("expected no running executions, got %d", n)
+	}
+	if CancelExecution("exec1") {
+		t.Error("expected canceling a completed execution to fail")
+	}
+}
+
+func TestRetirePlugin(t *testing.T) {
+	plugin := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "myPlugin"}}
+	other := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "myPlugin"}}
+	if !acquirePlugin(plugin) {
+		t.Fatal("expected to acquire the plugin")
+	}
+
+	// Other builds of the same plugin are not affected by the calls using this one.
+	if err := RetirePlugin(context.Background(), other); err != nil {
+		t.Fatal(err)
+	}
+	if acquirePlugin(other) {
+		t.Fatal("expected a retired plugin not to be acquired")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := RetirePlugin(ctx, plugin); err == nil {
+		t.Fatal("expected retiring a plugin that is in use to time out")
+	}
+	if acquirePlugin(plugin) {
+		t.Fatal("expected a plugin that is being retired not to be acquired")
+	}
+
+	time.AfterFunc(10*time.Millisecond, func() { releasePlugin(plugin) })
+	if err := RetirePlugin(context.Background(), plugin); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestorePlugin(t *testing.T) {
+	plugin := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "myPlugin"}}
+	if !acquirePlugin(plugin) {
+		t.Fatal("expected to acquire the plugin")
+	}
+
+	time.AfterFunc(10*time.Millisecond, func() { restorePlugin(plugin) })
+	if err := RetirePlugin(context.Background(), plugin); !errors.Is(err, errPluginRestored) {
+		t.Fatalf("expected retiring to stop when the plugin is restored, got %v", err)
+	}
+
+	if !acquirePlugin(plugin) {
+		t.Fatal("expected a restored plugin to be acquired")
+	}
+	releasePlugin(plugin)
+	releasePlugin(plugin)
+
+	pluginRefsMutex.Lock()
+	defer pluginRefsMutex.Unlock()
+	if _, ok := pluginRefCounts[plugin]; ok {
+		t.Error("expected the references of an unused plugin to be removed")
+	}
+}
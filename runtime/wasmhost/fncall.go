@@ -14,10 +14,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/hypermodeinc/modus/runtime/functions"
 	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
 	"github.com/hypermodeinc/modus/runtime/metrics"
 	"github.com/hypermodeinc/modus/runtime/utils"
 
@@ -27,22 +29,32 @@ import (
 
 type ExecutionInfo interface {
 	ExecutionId() string
+	ParentExecutionId() string
 	Buffers() utils.OutputBuffers
 	Messages() []utils.LogMessage
 	Result() any
+	Children() []ExecutionInfo
 }
 
 type executionInfo struct {
-	executionId string
-	buffers     utils.OutputBuffers
-	messages    []utils.LogMessage
-	result      any
+	executionId       string
+	parentExecutionId string
+	parent            *executionInfo
+	buffers           utils.OutputBuffers
+	messages          []utils.LogMessage
+	result            any
+	children          []ExecutionInfo
+	mu                sync.Mutex
 }
 
 func (e *executionInfo) ExecutionId() string {
 	return e.executionId
 }
 
+func (e *executionInfo) ParentExecutionId() string {
+	return e.parentExecutionId
+}
+
 func (e *executionInfo) Buffers() utils.OutputBuffers {
 	return e.buffers
 }
@@ -55,6 +67,19 @@ func (e *executionInfo) Result() any {
 	return e.result
 }
 
+// Children returns the executions of any functions that were called by this function.
+func (e *executionInfo) Children() []ExecutionInfo {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.children
+}
+
+func (e *executionInfo) addChild(child ExecutionInfo) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.children = append(e.children, child)
+}
+
 func CallFunction(ctx context.Context, fnName string, paramValues ...any) (ExecutionInfo, error) {
 	return GetWasmHost(ctx).CallFunctionByName(ctx, fnName, paramValues...)
 }
@@ -74,6 +99,21 @@ func (host *wasmHost) CallFunctionByName(ctx context.Context, fnName string, par
 	return host.CallFunction(ctx, info, parameters)
 }
 
+// acquireFunction takes a reference to the plugin of the function, for a call.
+// If the plugin was retired after the function was looked up, the function of the build that replaced it is used instead.
+func (host *wasmHost) acquireFunction(fnInfo functions.FunctionInfo) (functions.FunctionInfo, error) {
+	for !acquirePlugin(fnInfo.Plugin()) {
+		info, err := host.GetFunctionInfo(fnInfo.Name())
+		if err != nil {
+			return nil, err
+		} else if info.Plugin() == fnInfo.Plugin() {
+			return nil, fmt.Errorf("function %s belongs to a plugin build that was retired", fnInfo.Name())
+		}
+		fnInfo = info
+	}
+	return fnInfo, nil
+}
+
 func (host *wasmHost) CallFunction(ctx context.Context, fnInfo functions.FunctionInfo, parameters map[string]any) (ExecutionInfo, error) {
 	span, ctx := utils.NewSentrySpanForCurrentFunc(ctx)
 	defer span.Finish()
@@ -84,23 +124,60 @@ func (host *wasmHost) CallFunction(ctx context.Context, fnInfo functions.Functio
 		messages:    []utils.LogMessage{},
 	}
 
+	// Hold a reference to the plugin until the call ends, so that the plugin isn't released while it is in use.
+	fnInfo, err := host.acquireFunction(fnInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer releasePlugin(fnInfo.Plugin())
+
 	fnName := fnInfo.Name()
 	plugin := fnInfo.Plugin()
 	plan := fnInfo.ExecutionPlan()
 
+	// When called from within another function, link this execution to the caller's execution.
+	if parent, ok := ctx.Value(utils.ExecutionInfoContextKey).(*executionInfo); ok {
+		execInfo.parentExecutionId = parent.executionId
+		execInfo.parent = parent
+		parent.addChild(execInfo)
+		ctx = context.WithValue(ctx, utils.ParentExecutionIdContextKey, parent.executionId)
+	}
+
 	ctx = context.WithValue(ctx, utils.ExecutionIdContextKey, execInfo.executionId)
+	ctx = context.WithValue(ctx, utils.ExecutionInfoContextKey, execInfo)
 	ctx = context.WithValue(ctx, utils.FunctionMessagesContextKey, &execInfo.messages)
 	ctx = context.WithValue(ctx, utils.FunctionNameContextKey, fnName)
 	ctx = context.WithValue(ctx, utils.PluginContextKey, plugin)
 	ctx = context.WithValue(ctx, utils.MetadataContextKey, plugin.Metadata)
 	ctx = context.WithValue(ctx, utils.WasmHostContextKey, host)
 
+	var rec *Recording
+	if isRecordingEnabled(ctx) {
+		rec = &Recording{
+			ExecutionId:   execInfo.executionId,
+			Function:      fnName,
+			PluginName:    plugin.Name(),
+			PluginBuildId: plugin.BuildId(),
+			Parameters:    parameters,
+			StartTime:     time.Now().UTC(),
+			Calls:         []*RecordedCall{},
+		}
+		ctx = context.WithValue(ctx, utils.RecordingContextKey, rec)
+	}
+
 	// Each request will get its own instance of the plugin module, so that we can run
 	// multiple requests in parallel without risk of corrupting the module's memory.
 	// This also protects against security risk, as each request will have its own
 	// isolated memory space.  (One request cannot access another request's memory.)
 
-	mod, err := host.GetModuleInstance(ctx, plugin, execInfo.buffers)
+	// Track the execution while it runs, so that it can be listed and canceled.
+	ctx, untrack := trackExecution(ctx, execInfo, fnName, plugin)
+	defer untrack()
+
+	limits := manifestdata.GetManifest().Limits.ForFunction(fnName)
+	limiter := &memoryLimiter{maxPages: limits.MaxMemoryPages}
+
+	mod, err := host.getModuleInstance(ctx, plugin, execInfo.buffers, limiter)
 	if err != nil {
 		logger.Err(ctx, err).Msg("Error getting module instance.")
 		return nil, err
@@ -115,11 +192,21 @@ func (host *wasmHost) CallFunction(ctx context.Context, fnInfo functions.Functio
 		Bool("user_visible", true).
 		Msg("Calling function.")
 
+	invokeCtx, cancel := applyTimeLimit(ctx, limits)
+	defer cancel()
+
 	start := time.Now()
-	result, err := plan.InvokeFunction(ctx, wa, parameters)
+	result, err := plan.InvokeFunction(invokeCtx, wa, parameters)
 	duration := time.Since(start)
 
+	if limitErr := checkLimits(invokeCtx, fnName, limits, limiter); limitErr != nil {
+		err = limitErr
+	} else if err != nil && errors.Is(context.Cause(invokeCtx), errExecutionCanceled) {
+		err = errExecutionCanceled
+	}
+
 	exitErr := &sys.ExitError{}
+	limitErr := &limitExceededError{}
 
 	if err == nil {
 		logger.Info(ctx).
@@ -148,9 +235,16 @@ func (host *wasmHost) CallFunction(ctx context.Context, fnInfo functions.Functio
 				Int32("exit_code", exitCode).
 				Msgf("Function ended prematurely with exit code %d.  This may have been intentional, or caused by an exception or panic in your code.", exitCode)
 		}
+	} else if errors.As(err, &limitErr) {
+		logger.Error(ctx).
+			Str("function", fnName).
+			Dur("duration_ms", duration).
+			Bool("user_visible", true).
+			Str("limit", limitErr.limit).
+			Msg(limitErr.Error())
 	} else if errors.Is(err, context.Canceled) {
 		// Cancellation is not an error, but we still want to log it.
-		// This can occur if the function takes too long to execute, or if the user cancels the request.
+		// This can occur if the function takes too long to execute, if the user cancels the request, or if the execution is canceled by an administrator.
 		logger.Warn(ctx).
 			Str("function", fnName).
 			Dur("duration_ms", duration).
@@ -181,7 +275,33 @@ func (host *wasmHost) CallFunction(ctx context.Context, fnInfo functions.Functio
 	d := float64(duration.Milliseconds())
 	metrics.FunctionExecutionDurationMilliseconds.WithLabelValues(fnName).Observe(d)
 	metrics.FunctionExecutionDurationMillisecondsSummary.WithLabelValues(fnName).Observe(d)
+	if hasFunctionErrors(err, execInfo.messages) {
+		metrics.FunctionErrorsNum.WithLabelValues(fnName).Inc()
+	}
+
+	if rec != nil {
+		rec.finish(result, err, duration)
+		rec.save(ctx)
+	}
 
 	execInfo.result = result
 	return execInfo, err
 }
+
+// hasFunctionErrors reports whether a function execution failed, or completed but logged any errors.
+func hasFunctionErrors(err error, messages []utils.LogMessage) bool {
+	if err != nil {
+		exitErr := &sys.ExitError{}
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode() != 0
+		}
+		return !errors.Is(err, context.Canceled)
+	}
+
+	for _, msg := range messages {
+		if msg.IsError() {
+			return true
+		}
+	}
+	return false
+}
@@ -259,12 +259,33 @@ func (host *wasmHost) newHostFunction(modName, funcName string, fn any, opts ...
 			inputs = append(inputs, reflect.ValueOf(param))
 		}
 
+		// Host functions that return results are recorded or replayed, when enabled for the execution.
+		// Host functions without results, such as logging, are always invoked.
+		var rec *Recording
+		var rp *replayer
+		if numResults > 0 {
+			rec, _ = ctx.Value(utils.RecordingContextKey).(*Recording)
+			rp, _ = ctx.Value(utils.ReplayContextKey).(*replayer)
+		}
+
 		// Prepare to call the host function
 		results := make([]any, 0, numResults)
 		wrappedFn := func() error {
 
-			// invoke the function
-			out := rvFunc.Call(inputs)
+			// invoke the function, or get its results from the recording being replayed
+			var out []reflect.Value
+			if rp != nil {
+				var err error
+				if out, err = rp.nextCall(fullName, rtFunc, hasErrorResult); err != nil {
+					return err
+				}
+			} else {
+				out = rvFunc.Call(inputs)
+			}
+
+			if rec != nil {
+				rec.addCall(fullName, params, out, hasErrorResult)
+			}
 
 			// check for an error
 			if hasErrorResult && len(out) > 0 {
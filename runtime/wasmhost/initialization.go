@@ -32,6 +32,9 @@ func configureLogger() {
 		if executionId, ok := ctx.Value(utils.ExecutionIdContextKey).(string); ok {
 			lc = lc.Str("execution_id", executionId)
 		}
+		if parentExecutionId, ok := ctx.Value(utils.ParentExecutionIdContextKey).(string); ok {
+			lc = lc.Str("parent_execution_id", parentExecutionId)
+		}
 
 		return lc
 	})
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+const wasmPageSize = 65536
+
+const defaultMaxCallDepth = 16
+
+var errTimeLimitExceeded = errors.New("time limit exceeded")
+
+// limitExceededError is returned when a function execution is terminated because it exceeded one of its limits.
+type limitExceededError struct {
+	limit   string
+	message string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.message
+}
+
+// memoryLimiter tracks the memory limit of a single function execution.
+type memoryLimiter struct {
+	maxPages uint32
+	exceeded bool
+}
+
+// memoryAllocator allocates the linear memory of a module instance, enforcing the memory limit of the execution that uses it.
+// The limiter can be replaced before the module instance is used, which allows pooled modules to be limited.
+type memoryAllocator struct {
+	limiter *memoryLimiter
+}
+
+func (a *memoryAllocator) Allocate(cap, max uint64) experimental.LinearMemory {
+	return &limitedMemory{
+		allocator: a,
+		buf:       make([]byte, 0, cap),
+	}
+}
+
+type limitedMemory struct {
+	allocator *memoryAllocator
+	buf       []byte
+}
+
+func (m *limitedMemory) Reallocate(size uint64) []byte {
+	if size <= uint64(len(m.buf)) {
+		m.buf = m.buf[:size]
+		return m.buf
+	}
+
+	// The module's initial memory is always allocated. The limit only applies to growing it.
+	if l := m.allocator.limiter; l != nil && l.maxPages > 0 && len(m.buf) > 0 && size > uint64(l.maxPages)*wasmPageSize {
+		l.exceeded = true
+		return nil
+	}
+
+	m.buf = append(m.buf, make([]byte, size-uint64(len(m.buf)))...)
+	return m.buf
+}
+
+func (m *limitedMemory) Free() {
+	m.buf = nil
+}
+
+// applyTimeLimit returns a context that will terminate the function execution if it runs longer than the time limit.
+func applyTimeLimit(ctx context.Context, limits manifest.ExecutionLimits) (context.Context, context.CancelFunc) {
+	if limits.TimeoutMs <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, time.Duration(limits.TimeoutMs)*time.Millisecond, errTimeLimitExceeded)
+}
+
+// checkLimits returns an error if the function execution exceeded any of its limits, and updates the metrics accordingly.
+func checkLimits(ctx context.Context, fnName string, limits manifest.ExecutionLimits, limiter *memoryLimiter) error {
+	var err *limitExceededError
+	if limiter.exceeded {
+		err = &limitExceededError{
+			limit: "memory",
+			message: fmt.Sprintf("Function exceeded its memory limit of %d pages (%d MiB), and was terminated.",
+				limits.MaxMemoryPages, uint64(limits.MaxMemoryPages)*wasmPageSize/(1<<20)),
+		}
+	} else if errors.Is(context.Cause(ctx), errTimeLimitExceeded) {
+		err = &limitExceededError{
+			limit:   "time",
+			message: fmt.Sprintf("Function exceeded its time limit of %d ms, and was terminated.", limits.TimeoutMs),
+		}
+	} else {
+		return nil
+	}
+
+	metrics.FunctionLimitsExceededNum.WithLabelValues(fnName, err.limit).Inc()
+	return err
+}
+
+// CheckCallDepth returns an error if calling another function from the current execution would exceed the maximum call depth.
+// The depth is found by walking the chain of executions that led to the current one.
+func CheckCallDepth(ctx context.Context, fnName string) error {
+	depth := 0
+	parent, _ := ctx.Value(utils.ExecutionInfoContextKey).(*executionInfo)
+	for e := parent; e != nil; e = e.parent {
+		depth++
+	}
+
+	maxDepth := manifestdata.GetManifest().Limits.MaxCallDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxCallDepth
+	}
+	if depth < maxDepth {
+		return nil
+	}
+
+	metrics.FunctionLimitsExceededNum.WithLabelValues(fnName, "call_depth").Inc()
+	return &limitExceededError{
+		limit:   "call_depth",
+		message: fmt.Sprintf("Function %s was not called, because it would exceed the maximum call depth of %d nested function calls.", fnName, maxDepth),
+	}
+}
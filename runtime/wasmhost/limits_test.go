@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/manifest"
+	"github.com/hypermodeinc/modus/runtime/manifestdata"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+func TestCheckCallDepth(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{
+		Limits: manifest.LimitsInfo{MaxCallDepth: 3},
+	})
+	defer manifestdata.SetManifest(&manifest.Manifest{})
+
+	ctx := context.Background()
+	if err := CheckCallDepth(ctx, "myFunction"); err != nil {
+		t.Fatalf("expected no error outside of a function execution, got %v", err)
+	}
+
+	var parent *executionInfo
+	for i := 1; i <= 3; i++ {
+		parent = &executionInfo{executionId: "exec", parent: parent}
+		ctx = context.WithValue(context.Background(), utils.ExecutionInfoContextKey, parent)
+		err := CheckCallDepth(ctx, "myFunction")
+		if i < 3 && err != nil {
+			t.Fatalf("expected no error at depth %d, got %v", i, err)
+		}
+		if i == 3 {
+			limitErr := &limitExceededError{}
+			if !errors.As(err, &limitErr) || limitErr.limit != "call_depth" {
+				t.Fatalf("expected a call depth limit error at depth %d, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestCheckCallDepth_Default(t *testing.T) {
+	manifestdata.SetManifest(&manifest.Manifest{})
+
+	var parent *executionInfo
+	for range defaultMaxCallDepth - 1 {
+		parent = &executionInfo{parent: parent}
+	}
+	ctx := context.WithValue(context.Background(), utils.ExecutionInfoContextKey, parent)
+	if err := CheckCallDepth(ctx, "myFunction"); err != nil {
+		t.Fatalf("expected no error below the default call depth, got %v", err)
+	}
+
+	ctx = context.WithValue(context.Background(), utils.ExecutionInfoContextKey, &executionInfo{parent: parent})
+	if err := CheckCallDepth(ctx, "myFunction"); err == nil {
+		t.Fatal("expected an error at the default call depth")
+	}
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"io"
+	"math"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/rs/zerolog"
+	wasm "github.com/tetratelabs/wazero/api"
+)
+
+// A modulePool holds module instances of a plugin that are instantiated ahead of time,
+// so that the cost of instantiation (including running the plugin's `_start` function)
+// is not paid during a function call.
+//
+// Each module instance is still used for only a single invocation, and is closed afterwards.
+// This keeps the same isolation between requests as instantiating a module on demand.
+// The delays before trying again to fill the pool, after failing to instantiate a module.
+const minFillRetryDelay = 1 * time.Second
+const maxFillRetryDelay = 1 * time.Minute
+
+type modulePool struct {
+	host    *wasmHost
+	plugin  *plugins.Plugin
+	modules chan *pooledModule
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+type pooledModule struct {
+	mod       wasm.Module
+	stdout    *switchWriter
+	stderr    *switchWriter
+	allocator *memoryAllocator
+}
+
+// switchWriter allows the output of a pooled module to be directed to the writers of the request that uses it.
+// It is only switched before the module is used, so no synchronization is needed.
+type switchWriter struct {
+	w io.Writer
+}
+
+func (sw *switchWriter) Write(p []byte) (int, error) {
+	return sw.w.Write(p)
+}
+
+// getModulePool returns the module pool for the plugin, creating it if necessary.
+// It returns nil if module pooling is disabled.
+func (host *wasmHost) getModulePool(plugin *plugins.Plugin) *modulePool {
+	if config.ModulePoolSize <= 0 {
+		return nil
+	}
+
+	host.poolsMutex.Lock()
+	defer host.poolsMutex.Unlock()
+
+	if pool, ok := host.pools[plugin]; ok {
+		return pool
+	}
+
+	// A released plugin may still be called by executions that started before it was replaced,
+	// but it doesn't get a new pool, which would be filled with module instances that are never used.
+	if _, ok := host.released[plugin]; ok {
+		return nil
+	}
+
+	pool := &modulePool{
+		host:    host,
+		plugin:  plugin,
+		modules: make(chan *pooledModule, config.ModulePoolSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	host.pools[plugin] = pool
+
+	go pool.fill()
+
+	return pool
+}
+
+// ReleasePlugin closes any module instances that are held for the plugin, and stops holding them from then on.
+// It should be called when the plugin is unloaded or replaced.
+func (host *wasmHost) ReleasePlugin(ctx context.Context, plugin *plugins.Plugin) {
+	host.poolsMutex.Lock()
+	defer host.poolsMutex.Unlock()
+
+	host.released[plugin] = struct{}{}
+	if pool, ok := host.pools[plugin]; ok {
+		delete(host.pools, plugin)
+		close(pool.done)
+	}
+}
+
+// RestorePlugin allows calls to use a plugin that was retired, and module instances to be held again for it.
+// It should be called when a replaced plugin is made active again, such as by a rollback.
+func (host *wasmHost) RestorePlugin(ctx context.Context, plugin *plugins.Plugin) {
+	restorePlugin(plugin)
+
+	host.poolsMutex.Lock()
+	defer host.poolsMutex.Unlock()
+
+	delete(host.released, plugin)
+}
+
+// hasModulePool reports whether any build of the named plugin has a module pool.
+func (host *wasmHost) hasModulePool(name string) bool {
+	host.poolsMutex.Lock()
+	defer host.poolsMutex.Unlock()
+
+	for plugin := range host.pools {
+		if plugin.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// closeModulePools closes all module pools, and waits for them to stop instantiating modules.
+func (host *wasmHost) closeModulePools() {
+	host.poolsMutex.Lock()
+	pools := host.pools
+	host.pools = make(map[*plugins.Plugin]*modulePool)
+	host.poolsMutex.Unlock()
+
+	for _, pool := range pools {
+		close(pool.done)
+	}
+	for _, pool := range pools {
+		<-pool.stopped
+	}
+}
+
+// get returns a pre-instantiated module, or nil if none is ready.
+func (p *modulePool) get() *pooledModule {
+	name := p.plugin.Name()
+	select {
+	case pm := <-p.modules:
+		metrics.ModulePoolRequestsNum.WithLabelValues(name, "hit").Inc()
+		metrics.ModulePoolIdleNum.WithLabelValues(name).Set(float64(len(p.modules)))
+		return pm
+	default:
+		metrics.ModulePoolRequestsNum.WithLabelValues(name, "miss").Inc()
+		return nil
+	}
+}
+
+// fill keeps the pool filled with module instances, until the pool is closed.
+func (p *modulePool) fill() {
+	defer close(p.stopped)
+	name := p.plugin.Name()
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, utils.WasmHostContextKey, WasmHost(p.host))
+	ctx = context.WithValue(ctx, utils.PluginContextKey, p.plugin)
+	ctx = context.WithValue(ctx, utils.MetadataContextKey, p.plugin.Metadata)
+
+	failures := 0
+	for {
+		select {
+		case <-p.done:
+			p.drain(ctx)
+			return
+		default:
+		}
+
+		pm, err := p.newPooledModule(ctx)
+		if err != nil {
+			// Requests will still instantiate modules on demand, which will report the error to the caller.
+			// The error is only logged once, until the pool can be filled again.
+			if failures == 0 {
+				logger.Err(ctx, err).Str("plugin", name).Msg("Failed to pre-instantiate a module for the pool.  Retrying until it succeeds.")
+			}
+			failures++
+
+			timer := time.NewTimer(getFillRetryDelay(failures))
+			select {
+			case <-p.done:
+				timer.Stop()
+				p.drain(ctx)
+				return
+			case <-timer.C:
+			}
+			continue
+		}
+
+		if failures > 0 {
+			logger.Info(ctx).Str("plugin", name).Int("attempts", failures+1).Msg("Resumed pre-instantiating modules for the pool.")
+			failures = 0
+		}
+
+		select {
+		case p.modules <- pm:
+			metrics.ModulePoolIdleNum.WithLabelValues(name).Set(float64(len(p.modules)))
+		case <-p.done:
+			_ = pm.mod.Close(ctx)
+			p.drain(ctx)
+			return
+		}
+	}
+}
+
+// getFillRetryDelay returns the delay before trying again to instantiate a module, doubling with each failure.
+func getFillRetryDelay(failures int) time.Duration {
+	delay := float64(minFillRetryDelay) * math.Pow(2, float64(failures-1))
+	if delay > float64(maxFillRetryDelay) {
+		return maxFillRetryDelay
+	}
+	return time.Duration(delay)
+}
+
+func (p *modulePool) drain(ctx context.Context) {
+	for {
+		select {
+		case pm := <-p.modules:
+			_ = pm.mod.Close(ctx)
+		default:
+			// The gauge is shared by all builds of the plugin, so it is left to the pool of the build that replaced this one.
+			if name := p.plugin.Name(); !p.host.hasModulePool(name) {
+				metrics.ModulePoolIdleNum.DeleteLabelValues(name)
+			}
+			return
+		}
+	}
+}
+
+func (p *modulePool) newPooledModule(ctx context.Context) (*pooledModule, error) {
+
+	// Messages logged by the plugin's top-level code are not associated with any function call.
+	ctx = context.WithValue(ctx, utils.FunctionMessagesContextKey, &[]utils.LogMessage{})
+
+	// Until the module is used, its output goes only to the logs.
+	log := logger.Get(ctx).With().Str("plugin", p.plugin.Name()).Bool("user_visible", true).Logger()
+	pm := &pooledModule{
+		stdout:    &switchWriter{logger.NewLogWriter(&log, zerolog.InfoLevel)},
+		stderr:    &switchWriter{logger.NewLogWriter(&log, zerolog.ErrorLevel)},
+		allocator: &memoryAllocator{},
+	}
+
+	// Pooled modules are instantiated without any JWT claims, or memory limit.
+	mod, err := p.host.instantiateModule(ctx, p.plugin, pm.stdout, pm.stderr, "", pm.allocator)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.mod = mod
+	return pm, nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hypermodeinc/modus/lib/metadata"
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/metrics"
+	"github.com/hypermodeinc/modus/runtime/plugins"
+	"github.com/hypermodeinc/modus/runtime/utils"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestPoolHost() *wasmHost {
+	return &wasmHost{
+		pools:    make(map[*plugins.Plugin]*modulePool),
+		released: make(map[*plugins.Plugin]struct{}),
+	}
+}
+
+func addTestPool(host *wasmHost, plugin *plugins.Plugin) *modulePool {
+	pool := &modulePool{
+		host:    host,
+		plugin:  plugin,
+		modules: make(chan *pooledModule, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	host.pools[plugin] = pool
+	return pool
+}
+
+func TestReleasePluginStopsPooling(t *testing.T) {
+	defer func(size int) { config.ModulePoolSize = size }(config.ModulePoolSize)
+	config.ModulePoolSize = 1
+
+	host := newTestPoolHost()
+	plugin := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "myPlugin"}}
+	pool := addTestPool(host, plugin)
+
+	host.ReleasePlugin(context.Background(), plugin)
+
+	select {
+	case <-pool.done:
+	default:
+		t.Fatal("expected the pool to be closed")
+	}
+
+	if p := host.getModulePool(plugin); p != nil {
+		t.Fatal("expected no pool to be created for a released plugin")
+	}
+	if host.hasModulePool("myPlugin") {
+		t.Error("expected the released plugin to have no pool")
+	}
+
+	host.RestorePlugin(context.Background(), plugin)
+	if _, ok := host.released[plugin]; ok {
+		t.Error("expected the restored plugin not to be marked as released")
+	}
+}
+
+func TestDrainKeepsGaugeOfReplacingBuild(t *testing.T) {
+	host := newTestPoolHost()
+	oldBuild := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "gaugePlugin", BuildId: "1"}}
+	newBuild := &plugins.Plugin{Metadata: &metadata.Metadata{Plugin: "gaugePlugin", BuildId: "2"}}
+
+	oldPool := addTestPool(host, oldBuild)
+	host.ReleasePlugin(context.Background(), oldBuild)
+	addTestPool(host, newBuild)
+
+	metrics.ModulePoolIdleNum.WithLabelValues("gaugePlugin").Set(1)
+	oldPool.drain(context.Background())
+
+	if n := testutil.CollectAndCount(metrics.ModulePoolIdleNum); n != 1 {
+		t.Fatalf("expected the gauge of the replacing build to be kept, got %d series", n)
+	}
+
+	host.ReleasePlugin(context.Background(), newBuild)
+	oldPool.drain(context.Background())
+
+	if n := testutil.CollectAndCount(metrics.ModulePoolIdleNum); n != 0 {
+		t.Errorf("expected the gauge to be deleted when no build has a pool, got %d series", n)
+	}
+}
+
+func TestPoolIsBypassedForRecordedExecutions(t *testing.T) {
+	ctx := context.Background()
+	if isRecordingOrReplaying(ctx) {
+		t.Error("expected an execution without a recording to use the pool")
+	}
+
+	recordingCtx := context.WithValue(ctx, utils.RecordingContextKey, &Recording{})
+	if !isRecordingOrReplaying(recordingCtx) {
+		t.Error("expected a recorded execution not to use the pool")
+	}
+
+	replayCtx := WithReplay(ctx, &Recording{})
+	if !isRecordingOrReplaying(replayCtx) {
+		t.Error("expected a replayed execution not to use the pool")
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package wasmhost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hypermodeinc/modus/runtime/config"
+	"github.com/hypermodeinc/modus/runtime/logger"
+	"github.com/hypermodeinc/modus/runtime/utils"
+)
+
+// A Recording captures a function execution, including every call it made to a host function,
+// so that the execution can be replayed later without access to any external services.
+type Recording struct {
+	ExecutionId   string          `json:"executionId"`
+	Function      string          `json:"function"`
+	PluginName    string          `json:"pluginName"`
+	PluginBuildId string          `json:"pluginBuildId"`
+	Parameters    map[string]any  `json:"parameters"`
+	StartTime     time.Time       `json:"startTime"`
+	DurationMs    int64           `json:"durationMs"`
+	Calls         []*RecordedCall `json:"calls"`
+	Result        json.RawMessage `json:"result,omitempty"`
+	Error         string          `json:"error,omitempty"`
+
+	mu sync.Mutex
+}
+
+// A RecordedCall is a single call to a host function, made during a recorded execution.
+type RecordedCall struct {
+	HostFunction string            `json:"hostFunction"`
+	Params       []any             `json:"params"`
+	Results      []json.RawMessage `json:"results"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// ReadRecording reads a recording that was previously saved to a file.
+func ReadRecording(path string) (*Recording, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var rec Recording
+	if err := utils.JsonDeserialize(bytes, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse recording: %w", err)
+	}
+
+	return &rec, nil
+}
+
+// WithReplay returns a context in which a function execution uses the host function calls of the recording,
+// instead of calling the host functions.
+func WithReplay(ctx context.Context, rec *Recording) context.Context {
+	return context.WithValue(ctx, utils.ReplayContextKey, &replayer{calls: rec.Calls})
+}
+
+func isRecordingEnabled(ctx context.Context) bool {
+	if config.RecordDir == "" {
+		return false
+	}
+
+	// Executions that are being replayed are not recorded again.
+	_, replaying := ctx.Value(utils.ReplayContextKey).(*replayer)
+	return !replaying
+}
+
+// isRecordingOrReplaying reports whether the current execution is being recorded or replayed.
+func isRecordingOrReplaying(ctx context.Context) bool {
+	if _, ok := ctx.Value(utils.RecordingContextKey).(*Recording); ok {
+		return true
+	}
+	_, ok := ctx.Value(utils.ReplayContextKey).(*replayer)
+	return ok
+}
+
+func (r *Recording) addCall(fullName string, params []any, out []reflect.Value, hasErrorResult bool) {
+	call := &RecordedCall{
+		HostFunction: fullName,
+		Params:       params,
+		Results:      make([]json.RawMessage, 0, len(out)),
+	}
+
+	for i, v := range out {
+		if hasErrorResult && i == len(out)-1 {
+			if err, ok := v.Interface().(error); ok && err != nil {
+				call.Error = err.Error()
+			}
+			continue
+		}
+
+		bytes, err := utils.JsonSerialize(v.Interface())
+		if err != nil {
+			bytes = []byte("null")
+		}
+		call.Results = append(call.Results, bytes)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Calls = append(r.Calls, call)
+}
+
+func (r *Recording) finish(result any, err error, duration time.Duration) {
+	r.DurationMs = duration.Milliseconds()
+	if err != nil {
+		r.Error = err.Error()
+	} else if bytes, e := utils.JsonSerialize(result); e == nil {
+		r.Result = bytes
+	}
+}
+
+func (r *Recording) save(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(config.RecordDir, 0755); err != nil {
+		logger.Err(ctx, err).Msg("Failed to create directory for recordings.")
+		return
+	}
+
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		logger.Err(ctx, err).Msg("Failed to serialize recording.")
+		return
+	}
+
+	path := filepath.Join(config.RecordDir, r.ExecutionId+".json")
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		logger.Err(ctx, err).Str("path", path).Msg("Failed to save recording.")
+		return
+	}
+
+	logger.Debug(ctx).Str("path", path).Msg("Saved recording of function execution.")
+}
+
+// A replayer provides the recorded results of host function calls, in the order they were made.
+type replayer struct {
+	calls []*RecordedCall
+	next  int
+	err   error
+	mu    sync.Mutex
+}
+
+var errReplayDiverged = errors.New("the replayed execution diverged from the recording")
+
+func (r *replayer) nextCall(fullName string, rtFunc reflect.Type, hasErrorResult bool) ([]reflect.Value, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Once diverged, the remaining calls can't be matched reliably.
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if r.next >= len(r.calls) {
+		r.err = fmt.Errorf("%w: unexpected call to host function %s", errReplayDiverged, fullName)
+		return nil, r.err
+	}
+
+	call := r.calls[r.next]
+	if call.HostFunction != fullName {
+		r.err = fmt.Errorf("%w: expected call to host function %s, but %s was called", errReplayDiverged, call.HostFunction, fullName)
+		return nil, r.err
+	}
+	r.next++
+
+	numResults := rtFunc.NumOut()
+	out := make([]reflect.Value, numResults)
+	j := 0
+	for i := 0; i < numResults; i++ {
+		rtResult := rtFunc.Out(i)
+		if hasErrorResult && i == numResults-1 {
+			var err error
+			if call.Error != "" {
+				err = errors.New(call.Error)
+			}
+			out[i] = reflect.ValueOf(&err).Elem()
+			continue
+		}
+
+		rvResult := reflect.New(rtResult)
+		if j < len(call.Results) {
+			if err := utils.JsonDeserialize(call.Results[j], rvResult.Interface()); err != nil {
+				return nil, fmt.Errorf("failed to decode recorded result of host function %s: %w", fullName, err)
+			}
+		}
+		out[i] = rvResult.Elem()
+		j++
+	}
+
+	return out, nil
+}
+
+// CheckReplay returns an error if the execution replayed in the given context did not make
+// the same host function calls as the recording.
+func CheckReplay(ctx context.Context) error {
+	r, ok := ctx.Value(utils.ReplayContextKey).(*replayer)
+	if !ok {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	if n := len(r.calls) - r.next; n > 0 {
+		return fmt.Errorf("%w: %d recorded host function calls were not made", errReplayDiverged, n)
+	}
+	return nil
+}
@@ -14,7 +14,9 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"sync"
 
+	"github.com/hypermodeinc/modus/runtime/assets"
 	"github.com/hypermodeinc/modus/runtime/functions"
 	"github.com/hypermodeinc/modus/runtime/logger"
 	"github.com/hypermodeinc/modus/runtime/middleware"
@@ -24,6 +26,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/tetratelabs/wazero"
 	wasm "github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
 	wasi "github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
 )
 
@@ -36,16 +39,27 @@ type WasmHost interface {
 	GetFunctionInfo(fnName string) (functions.FunctionInfo, error)
 	GetFunctionRegistry() functions.FunctionRegistry
 	GetModuleInstance(ctx context.Context, plugin *plugins.Plugin, buffers utils.OutputBuffers) (wasm.Module, error)
+	ReleasePlugin(ctx context.Context, plugin *plugins.Plugin)
+	RestorePlugin(ctx context.Context, plugin *plugins.Plugin)
 }
 
 type wasmHost struct {
 	runtime       wazero.Runtime
+	cache         wazero.CompilationCache
 	fnRegistry    functions.FunctionRegistry
 	hostFunctions []*hostFunction
+	pools         map[*plugins.Plugin]*modulePool
+	released      map[*plugins.Plugin]struct{}
+	poolsMutex    sync.Mutex
 }
 
 func NewWasmHost(ctx context.Context, registrations ...func(WasmHost) error) WasmHost {
 	cfg := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	cache := newCompilationCache(ctx)
+	if cache != nil {
+		cfg = cfg.WithCompilationCache(cache)
+	}
+
 	runtime := wazero.NewRuntimeWithConfig(ctx, cfg)
 	wasi.MustInstantiate(ctx, runtime)
 
@@ -56,7 +70,10 @@ func NewWasmHost(ctx context.Context, registrations ...func(WasmHost) error) Was
 
 	host := &wasmHost{
 		runtime:    runtime,
+		cache:      cache,
 		fnRegistry: functions.NewFunctionRegistry(),
+		pools:      make(map[*plugins.Plugin]*modulePool),
+		released:   make(map[*plugins.Plugin]struct{}),
 	}
 
 	for _, reg := range registrations {
@@ -84,9 +101,15 @@ func GetWasmHost(ctx context.Context) WasmHost {
 }
 
 func (host *wasmHost) Close(ctx context.Context) {
+	host.closeModulePools()
 	if err := host.runtime.Close(ctx); err != nil {
 		logger.Err(ctx, err).Msg("Failed to cleanly close the WASM runtime.")
 	}
+	if host.cache != nil {
+		if err := host.cache.Close(ctx); err != nil {
+			logger.Err(ctx, err).Msg("Failed to cleanly close the compilation cache.")
+		}
+	}
 }
 
 func (host *wasmHost) GetFunctionInfo(fnName string) (functions.FunctionInfo, error) {
@@ -99,6 +122,10 @@ func (host *wasmHost) GetFunctionRegistry() functions.FunctionRegistry {
 
 // Gets a module instance for the given plugin, used for a single invocation.
 func (host *wasmHost) GetModuleInstance(ctx context.Context, plugin *plugins.Plugin, buffers utils.OutputBuffers) (wasm.Module, error) {
+	return host.getModuleInstance(ctx, plugin, buffers, &memoryLimiter{})
+}
+
+func (host *wasmHost) getModuleInstance(ctx context.Context, plugin *plugins.Plugin, buffers utils.OutputBuffers, limiter *memoryLimiter) (wasm.Module, error) {
 	span, ctx := utils.NewSentrySpanForCurrentFunc(ctx)
 	defer span.Finish()
 
@@ -111,12 +138,32 @@ func (host *wasmHost) GetModuleInstance(ctx context.Context, plugin *plugins.Plu
 	wOut := io.MultiWriter(buffers.StdOut(), wInfoLog)
 	wErr := io.MultiWriter(buffers.StdErr(), wErrorLog)
 
+	// Use a pre-instantiated module from the pool, if one is ready.
+	// Pooled modules are instantiated without JWT claims, so they can't be used for requests that have them.
+	// They have also already run their `_start` function, so they can't be used for executions that are recorded
+	// or replayed, which must include the host functions that `_start` calls.
+	jwtClaims := middleware.GetJWTClaims(ctx)
+	if jwtClaims == "" && !isRecordingOrReplaying(ctx) {
+		if pool := host.getModulePool(plugin); pool != nil {
+			if pm := pool.get(); pm != nil {
+				pm.stdout.w = wOut
+				pm.stderr.w = wErr
+				pm.allocator.limiter = limiter
+				return pm.mod, nil
+			}
+		}
+	}
+
+	return host.instantiateModule(ctx, plugin, wOut, wErr, jwtClaims, &memoryAllocator{limiter})
+}
+
+func (host *wasmHost) instantiateModule(ctx context.Context, plugin *plugins.Plugin, wOut, wErr io.Writer, jwtClaims string, allocator *memoryAllocator) (wasm.Module, error) {
+
 	// Configure the module instance.
 	// Note, we use an anonymous module name (empty string) here,
 	// for concurrency and performance reasons.
 	// See https://github.com/tetratelabs/wazero/pull/2275
 	// And https://gophers.slack.com/archives/C040AKTNTE0/p1719587772724619?thread_ts=1719522663.531579&cid=C040AKTNTE0
-	jwtClaims := middleware.GetJWTClaims(ctx)
 	cfg := wazero.NewModuleConfig().
 		WithName("").
 		WithSysWalltime().WithSysNanotime().
@@ -124,6 +171,14 @@ func (host *wasmHost) GetModuleInstance(ctx context.Context, plugin *plugins.Plu
 		WithStdout(wOut).WithStderr(wErr).
 		WithEnv("CLAIMS", jwtClaims)
 
+	// Mount the app's assets directory, if it has one.
+	if mountPath, ok := assets.GetMountPath(); ok {
+		cfg = cfg.WithFSConfig(wazero.NewFSConfig().WithFSMount(assets.FS, mountPath))
+	}
+
+	// Use our own memory allocator, so that the memory limit of the execution can be enforced.
+	ctx = experimental.WithMemoryAllocator(ctx, allocator)
+
 	// Instantiate the plugin as a module.
 	// NOTE: This will also invoke the plugin's `_start` function,
 	// which will call any top-level code in the plugin.
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { expect, it, mockImport, run } from "as-test";
+import { crypto, DynamicMap } from "..";
+
+let lastAlgorithm: string = "";
+let lastKeyName: string = "";
+let lastData: string = "";
+let returnData: string = "";
+
+mockImport("modus_crypto.hash", (algorithm: string, data: string): string => {
+  lastAlgorithm = algorithm;
+  lastData = data;
+  return returnData;
+});
+
+mockImport(
+  "modus_crypto.hmac",
+  (algorithm: string, keyName: string, data: string): string => {
+    lastAlgorithm = algorithm;
+    lastKeyName = keyName;
+    lastData = data;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_crypto.verifyHmac",
+  (algorithm: string, keyName: string, data: string, mac: string): string => {
+    lastAlgorithm = algorithm;
+    lastKeyName = keyName;
+    lastData = mac;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_crypto.encryptAesGcm",
+  (keyName: string, plaintext: string, associatedData: string): string => {
+    lastKeyName = keyName;
+    lastData = associatedData;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_crypto.sign",
+  (algorithm: string, keyName: string, data: string): string => {
+    lastAlgorithm = algorithm;
+    lastKeyName = keyName;
+    lastData = data;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_crypto.createJwt",
+  (algorithm: string, keyName: string, claimsJson: string): string => {
+    lastAlgorithm = algorithm;
+    lastKeyName = keyName;
+    lastData = claimsJson;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_crypto.verifyJwt",
+  (keyName: string, token: string): string => {
+    lastKeyName = keyName;
+    lastData = token;
+    return returnData;
+  },
+);
+
+it("should compute a hash", () => {
+  returnData =
+    "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad";
+
+  const digest = crypto.hash(crypto.SHA256, "abc");
+  expect(digest).toBe(returnData);
+  expect(lastAlgorithm).toBe("sha256");
+  expect(lastData).toBe("abc");
+});
+
+it("should compute an hmac", () => {
+  returnData = "f7bc83f430538424b13298e6aa6fb143";
+
+  const mac = crypto.hmac(crypto.SHA256, "webhook", "payload");
+  expect(mac).toBe(returnData);
+  expect(lastKeyName).toBe("webhook");
+  expect(lastData).toBe("payload");
+});
+
+it("should verify an hmac", () => {
+  returnData = "true";
+
+  expect(crypto.verifyHmac(crypto.SHA256, "webhook", "payload", "abcd")).toBe(
+    true,
+  );
+  expect(lastData).toBe("abcd");
+});
+
+it("should encrypt with aes-gcm", () => {
+  returnData = "bm9uY2U=";
+
+  const ciphertext = crypto.encryptAesGcm("data", "secret");
+  expect(ciphertext).toBe(returnData);
+  expect(lastKeyName).toBe("data");
+  expect(lastData).toBe("");
+});
+
+it("should sign data", () => {
+  returnData = "c2lnbmF0dXJl";
+
+  const signature = crypto.sign(crypto.ED25519, "signing", "payload");
+  expect(signature).toBe(returnData);
+  expect(lastAlgorithm).toBe("ed25519");
+  expect(lastKeyName).toBe("signing");
+});
+
+it("should create a jwt", () => {
+  returnData = "header.payload.signature";
+
+  const claims = new DynamicMap();
+  claims.set("sub", "user1");
+
+  const token = crypto.createJwt("HS256", "tokens", claims);
+  expect(token).toBe(returnData);
+  expect(lastAlgorithm).toBe("HS256");
+  expect(lastData).toBe('{"sub":"user1"}');
+});
+
+it("should verify a jwt", () => {
+  returnData = '{"valid":true,"claims":{"sub":"user1"}}';
+
+  const claims = crypto.verifyJwt("tokens", "header.payload.signature");
+  expect(claims.get<string>("sub")).toBe("user1");
+  expect(lastKeyName).toBe("tokens");
+  expect(lastData).toBe("header.payload.signature");
+});
+
+run();
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { expect, it, mockImport, run } from "as-test";
+import { functions } from "..";
+
+let lastFnName: string = "";
+let lastArgs: string = "";
+let returnData: string = "";
+mockImport(
+  "modus_functions.callFunction",
+  (fnName: string, args: string): string => {
+    lastFnName = fnName;
+    lastArgs = args;
+    return returnData;
+  },
+);
+
+it("should call a function with arguments", () => {
+  returnData = '"Hello, World!"';
+
+  const args = new functions.Arguments();
+  args.push("World");
+  args.push(42);
+
+  const result = functions.call<string>("sayHello", args);
+  expect(result).toBe("Hello, World!");
+  expect(lastFnName).toBe("sayHello");
+  expect(lastArgs).toBe('["World",42]');
+});
+
+it("should call a function without arguments", () => {
+  returnData = "3";
+
+  const result = functions.call<i32>("getCount");
+  expect(result).toBe(3);
+  expect(lastFnName).toBe("getCount");
+  expect(lastArgs).toBe("[]");
+});
+
+run();
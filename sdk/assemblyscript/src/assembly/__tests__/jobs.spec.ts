@@ -0,0 +1,80 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { expect, it, mockImport, run } from "as-test";
+import { jobs } from "..";
+
+const jobId = "0192a8b4-3c5e-7d6f-8a9b-0c1d2e3f4a5b";
+
+let lastFnName: string = "";
+let lastArgs: string = "";
+let lastOptions: string = "";
+mockImport(
+  "modus_jobs.enqueueJob",
+  (fnName: string, args: string, options: string): string => {
+    lastFnName = fnName;
+    lastArgs = args;
+    lastOptions = options;
+    return jobId;
+  },
+);
+
+let lastId: string = "";
+let returnData: string = "";
+mockImport("modus_jobs.getJob", (id: string): string => {
+  lastId = id;
+  return returnData;
+});
+
+it("should enqueue a job with arguments", () => {
+  const args = new jobs.Arguments();
+  args.push("weekly");
+  args.push(42);
+
+  const id = jobs.enqueue("generateReport", args);
+  expect(id).toBe(jobId);
+  expect(lastFnName).toBe("generateReport");
+  expect(lastArgs).toBe('["weekly",42]');
+  expect(lastOptions).toBe('{"priority":0,"maxAttempts":0}');
+});
+
+it("should enqueue a job with options", () => {
+  const options = new jobs.Options();
+  options.priority = 10;
+  options.maxAttempts = 5;
+
+  jobs.enqueue("generateReport", new jobs.Arguments(), options);
+  expect(lastArgs).toBe("[]");
+  expect(lastOptions).toBe('{"priority":10,"maxAttempts":5}');
+});
+
+it("should get a job", () => {
+  returnData =
+    '{"id":"' +
+    jobId +
+    '","function":"generateReport","priority":0,"status":"succeeded","attempts":1,"maxAttempts":3,' +
+    '"result":"{\\"pages\\":3}","error":null,"executionId":"cs5cl4v9p3tb7fvu6q3g",' +
+    '"createdAt":"2024-10-15T12:00:00.000Z","updatedAt":"2024-10-15T12:00:05.000Z",' +
+    '"runAt":"2024-10-15T12:00:00.000Z","finishedAt":"2024-10-15T12:00:05.000Z"}';
+
+  const job = jobs.get(jobId)!;
+  expect(lastId).toBe(jobId);
+  expect(job.id).toBe(jobId);
+  expect(job.status).toBe(jobs.STATUS_SUCCEEDED);
+  expect(job.attempts).toBe(1);
+  expect(job.result!).toBe('{"pages":3}');
+});
+
+it("should return null for a missing job", () => {
+  returnData = "null";
+  const job = jobs.get("missing");
+  expect(job).toBe(null);
+});
+
+run();
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { expect, it, mockImport, run } from "as-test";
+import { kv } from "..";
+
+let lastNamespace: string = "";
+let lastKey: string = "";
+let lastValue: string = "";
+let lastVersion: i64 = 0;
+let lastTtl: i32 = 0;
+let lastLimit: i32 = 0;
+let returnData: string = "";
+
+mockImport("modus_kv.get", (namespace: string, key: string): string => {
+  lastNamespace = namespace;
+  lastKey = key;
+  return returnData;
+});
+
+mockImport(
+  "modus_kv.set",
+  (namespace: string, key: string, value: string, ttlSeconds: i32): string => {
+    lastNamespace = namespace;
+    lastKey = key;
+    lastValue = value;
+    lastTtl = ttlSeconds;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_kv.compareAndSwap",
+  (
+    namespace: string,
+    key: string,
+    version: i64,
+    value: string,
+    ttlSeconds: i32,
+  ): string => {
+    lastNamespace = namespace;
+    lastKey = key;
+    lastVersion = version;
+    lastValue = value;
+    lastTtl = ttlSeconds;
+    return returnData;
+  },
+);
+
+mockImport("modus_kv.delete", (namespace: string, key: string): string => {
+  lastNamespace = namespace;
+  lastKey = key;
+  return returnData;
+});
+
+mockImport(
+  "modus_kv.scan",
+  (namespace: string, prefix: string, limit: i32): string => {
+    lastNamespace = namespace;
+    lastKey = prefix;
+    lastLimit = limit;
+    return returnData;
+  },
+);
+
+it("should get an entry", () => {
+  returnData = '{"key":"greeting","value":"Hello","version":2}';
+
+  const entry = kv.get("greeting", "app")!;
+  expect(entry.value).toBe("Hello");
+  expect(entry.version).toBe(2);
+  expect(lastNamespace).toBe("app");
+  expect(lastKey).toBe("greeting");
+});
+
+it("should return null for a missing entry", () => {
+  returnData = "null";
+
+  const entry = kv.get("missing");
+  expect(entry).toBe(null);
+  expect(lastNamespace).toBe("");
+});
+
+it("should set an entry with a ttl", () => {
+  returnData = '{"key":"session","value":"abc","version":1}';
+
+  const entry = kv.set("session", "abc", 90);
+  expect(entry.version).toBe(1);
+  expect(lastValue).toBe("abc");
+  expect(lastTtl).toBe(90);
+});
+
+it("should compare and swap an entry", () => {
+  returnData = '{"key":"greeting","value":"Hi","version":3}';
+
+  const entry = kv.compareAndSwap("greeting", 2, "Hi")!;
+  expect(entry.version).toBe(3);
+  expect(lastVersion).toBe(2);
+  expect(lastValue).toBe("Hi");
+});
+
+it("should delete an entry", () => {
+  returnData = "true";
+
+  expect(kv.remove("greeting")).toBe(true);
+  expect(lastKey).toBe("greeting");
+});
+
+it("should scan entries by prefix", () => {
+  returnData =
+    '[{"key":"user:1","value":"Alice","version":1},{"key":"user:2","value":"Bob","version":4}]';
+
+  const entries = kv.scan("user:", 10);
+  expect(entries.length).toBe(2);
+  expect(entries[1].value).toBe("Bob");
+  expect(lastKey).toBe("user:");
+  expect(lastLimit).toBe(10);
+});
+
+run();
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { expect, it, mockImport, run } from "as-test";
+import { s3 } from "..";
+
+let lastConnection: string = "";
+let lastKey: string = "";
+let lastMethod: string = "";
+let lastContentType: string = "";
+let lastContent: ArrayBuffer = new ArrayBuffer(0);
+let lastLimit: i32 = 0;
+let lastExpires: i32 = 0;
+let returnData: string = "";
+
+mockImport(
+  "modus_s3_client.listObjects",
+  (
+    connection: string,
+    prefix: string,
+    startAfter: string,
+    limit: i32,
+  ): string => {
+    lastConnection = connection;
+    lastKey = startAfter;
+    lastLimit = limit;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_s3_client.getObject",
+  (connection: string, key: string): ArrayBuffer => {
+    lastConnection = connection;
+    lastKey = key;
+    return String.UTF8.encode(returnData);
+  },
+);
+
+mockImport(
+  "modus_s3_client.putObject",
+  (
+    connection: string,
+    key: string,
+    content: ArrayBuffer,
+    contentType: string,
+  ): string => {
+    lastConnection = connection;
+    lastKey = key;
+    lastContent = content;
+    lastContentType = contentType;
+    return returnData;
+  },
+);
+
+mockImport(
+  "modus_s3_client.deleteObject",
+  (connection: string, key: string): string => {
+    lastConnection = connection;
+    lastKey = key;
+    return "true";
+  },
+);
+
+mockImport(
+  "modus_s3_client.presignUrl",
+  (
+    connection: string,
+    method: string,
+    key: string,
+    expiresInSeconds: i32,
+  ): string => {
+    lastMethod = method;
+    lastKey = key;
+    lastExpires = expiresInSeconds;
+    return returnData;
+  },
+);
+
+it("should list objects", () => {
+  returnData =
+    '[{"key":"reports/2024.pdf","size":8,"etag":"\\"abc\\"","lastModified":"2024-01-01T00:00:00.000Z"}]';
+
+  const objects = s3.listObjects(
+    "documents",
+    "reports/",
+    "reports/2023.pdf",
+    10,
+  );
+  expect(objects.length).toBe(1);
+  expect(objects[0].key).toBe("reports/2024.pdf");
+  expect(objects[0].size).toBe(8);
+  expect(lastConnection).toBe("documents");
+  expect(lastKey).toBe("reports/2023.pdf");
+  expect(lastLimit).toBe(10);
+});
+
+it("should get an object as text", () => {
+  returnData = "Hello";
+
+  expect(s3.getObjectText("documents", "hello.txt")).toBe("Hello");
+  expect(lastKey).toBe("hello.txt");
+});
+
+it("should put an object as text", () => {
+  returnData = '{"key":"summary.txt","size":5}';
+
+  const info = s3.putObjectText("documents", "summary.txt", "Hello");
+  expect(info.size).toBe(5);
+  expect(String.UTF8.decode(lastContent)).toBe("Hello");
+  expect(lastContentType).toBe("text/plain");
+});
+
+it("should delete an object", () => {
+  s3.deleteObject("documents", "summary.txt");
+  expect(lastKey).toBe("summary.txt");
+});
+
+it("should presign a url", () => {
+  returnData = "https://bucket.s3.amazonaws.com/upload.pdf";
+
+  const url = s3.presignPutUrl("documents", "upload.pdf", 3600);
+  expect(url).toBe(returnData);
+  expect(lastMethod).toBe("PUT");
+  expect(lastExpires).toBe(3600);
+});
+
+run();
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { JSON } from "json-as";
+import { DynamicMap } from "./dynamicmap";
+import * as utils from "./utils";
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "hash")
+declare function hostHash(algorithm: string, data: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "hmac")
+declare function hostHmac(
+  algorithm: string,
+  keyName: string,
+  data: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "verifyHmac")
+declare function hostVerifyHmac(
+  algorithm: string,
+  keyName: string,
+  data: string,
+  mac: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "encryptAesGcm")
+declare function hostEncryptAesGcm(
+  keyName: string,
+  plaintext: string,
+  associatedData: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "decryptAesGcm")
+declare function hostDecryptAesGcm(
+  keyName: string,
+  ciphertext: string,
+  associatedData: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "sign")
+declare function hostSign(
+  algorithm: string,
+  keyName: string,
+  data: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "verify")
+declare function hostVerify(
+  algorithm: string,
+  keyName: string,
+  data: string,
+  signature: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "createJwt")
+declare function hostCreateJwt(
+  algorithm: string,
+  keyName: string,
+  claimsJson: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_crypto", "verifyJwt")
+declare function hostVerifyJwt(keyName: string, token: string): string;
+
+// Keys are referenced by name, and are never passed to the function.  The runtime reads each key
+// from the MODUS_KEY_<NAME> secret, where the name is uppercased and dashes are replaced with underscores.
+
+export const SHA224 = "sha224";
+export const SHA256 = "sha256";
+export const SHA384 = "sha384";
+export const SHA512 = "sha512";
+export const SHA3_224 = "sha3-224";
+export const SHA3_256 = "sha3-256";
+export const SHA3_384 = "sha3-384";
+export const SHA3_512 = "sha3-512";
+
+/**
+ * Ed25519 signatures require an Ed25519 key.
+ */
+export const ED25519 = "ed25519";
+
+/**
+ * ECDSA signatures are ASN.1 encoded, and require an ECDSA key on the P-256, P-384 or P-521 curve.
+ * The data is hashed with SHA-256, SHA-384 or SHA-512 respectively.
+ */
+export const ECDSA = "ecdsa";
+
+/**
+ * Computes the digest of the data.
+ * @param algorithm The SHA-2 or SHA-3 hash algorithm, such as SHA256.
+ * @param data The data to hash.
+ * @returns The hex-encoded digest.
+ */
+export function hash(algorithm: string, data: string): string {
+  const response = hostHash(algorithm, data);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error computing ${algorithm} hash.`);
+  }
+  return response;
+}
+
+/**
+ * Computes the HMAC of the data.
+ * @param algorithm The hash algorithm, such as SHA256.
+ * @param keyName The name of the key to use as the secret.
+ * @param data The data to authenticate.
+ * @returns The hex-encoded HMAC.
+ */
+export function hmac(algorithm: string, keyName: string, data: string): string {
+  const response = hostHmac(algorithm, keyName, data);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error computing HMAC with key ${keyName}.`);
+  }
+  return response;
+}
+
+/**
+ * Verifies the HMAC of the data.  The comparison is done in constant time.
+ * @param algorithm The hash algorithm, such as SHA256.
+ * @param keyName The name of the key to use as the secret.
+ * @param data The data that was authenticated.
+ * @param mac The hex-encoded HMAC to verify.
+ * @returns True if the HMAC matches the data.
+ */
+export function verifyHmac(
+  algorithm: string,
+  keyName: string,
+  data: string,
+  mac: string,
+): bool {
+  const response = hostVerifyHmac(algorithm, keyName, data, mac);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error verifying HMAC with key ${keyName}.`);
+  }
+  return JSON.parse<bool>(response);
+}
+
+/**
+ * Encrypts the plaintext with AES-GCM.
+ * @param keyName The name of the key, which must be a base64-encoded 128, 192 or 256-bit key.
+ * @param plaintext The data to encrypt.
+ * @param associatedData Data that is authenticated, but not encrypted.  It may be empty.
+ * @returns The base64-encoded nonce, followed by the ciphertext.
+ */
+export function encryptAesGcm(
+  keyName: string,
+  plaintext: string,
+  associatedData: string = "",
+): string {
+  const response = hostEncryptAesGcm(keyName, plaintext, associatedData);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error encrypting with key ${keyName}.`);
+  }
+  return response;
+}
+
+/**
+ * Decrypts a ciphertext that was returned by encryptAesGcm.
+ * @param keyName The name of the key that was used to encrypt the data.
+ * @param ciphertext The ciphertext to decrypt.
+ * @param associatedData The associated data that was used to encrypt the data.
+ * @returns The plaintext.
+ */
+export function decryptAesGcm(
+  keyName: string,
+  ciphertext: string,
+  associatedData: string = "",
+): string {
+  const response = hostDecryptAesGcm(keyName, ciphertext, associatedData);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error decrypting with key ${keyName}.`);
+  }
+  return response;
+}
+
+/**
+ * Signs the data.
+ * @param algorithm The signature algorithm, either ED25519 or ECDSA.
+ * @param keyName The name of the key, which must be a PEM-encoded private key.
+ * @param data The data to sign.
+ * @returns The base64-encoded signature.
+ */
+export function sign(algorithm: string, keyName: string, data: string): string {
+  const response = hostSign(algorithm, keyName, data);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error signing with key ${keyName}.`);
+  }
+  return response;
+}
+
+/**
+ * Verifies the signature of the data.
+ * @param algorithm The signature algorithm, either ED25519 or ECDSA.
+ * @param keyName The name of the key, which must be a PEM-encoded public key, or a private key from which the public key is derived.
+ * @param data The data that was signed.
+ * @param signature The base64-encoded signature to verify.
+ * @returns True if the signature is valid.
+ */
+export function verify(
+  algorithm: string,
+  keyName: string,
+  data: string,
+  signature: string,
+): bool {
+  const response = hostVerify(algorithm, keyName, data, signature);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error verifying signature with key ${keyName}.`);
+  }
+  return JSON.parse<bool>(response);
+}
+
+/**
+ * Creates a signed JWT.
+ * @param algorithm One of HS256, HS384 or HS512, which use the key as a shared secret,
+ * or one of RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384, ES512 or EdDSA,
+ * which require the key to be a PEM-encoded private key of the matching type.
+ * @param keyName The name of the key to sign with.
+ * @param claims The claims of the token.
+ * @returns The token.
+ */
+export function createJwt(
+  algorithm: string,
+  keyName: string,
+  claims: DynamicMap,
+): string {
+  const response = hostCreateJwt(algorithm, keyName, JSON.stringify(claims));
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error creating JWT with key ${keyName}.`);
+  }
+  return response;
+}
+
+@json
+class JwtVerification {
+  valid: bool = false;
+  claims: DynamicMap | null = null;
+  error: string | null = null;
+}
+
+/**
+ * Verifies the signature and the time-based claims of a JWT.
+ * Only the algorithms that match the type of the key are accepted.
+ * @param keyName The name of the key to verify with.
+ * @param token The token to verify.
+ * @returns The claims of the token.  An error is thrown if the token is not valid.
+ */
+export function verifyJwt(keyName: string, token: string): DynamicMap {
+  const response = hostVerifyJwt(keyName, token);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error verifying JWT with key ${keyName}.`);
+  }
+
+  const result = JSON.parse<JwtVerification>(response);
+  const claims = result.claims;
+  if (!result.valid || claims == null) {
+    throw new Error(`Invalid JWT: ${result.error || "unknown error"}`);
+  }
+  return claims;
+}
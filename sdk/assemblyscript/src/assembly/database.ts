@@ -19,12 +19,65 @@ declare function hostExecuteQuery(
   paramsJson: string,
 ): HostQueryResponse;
 
+// @ts-expect-error: decorator
+@external("modus_sql_client", "copyRows")
+declare function hostCopyRows(
+  hostName: string,
+  tableName: string,
+  columnsJson: string,
+  rowsJson: string,
+): HostCopyResponse;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "beginTransaction")
+declare function hostBeginTransaction(hostName: string, dbType: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "executeQueryInTransaction")
+declare function hostExecuteQueryInTransaction(
+  transactionId: string,
+  statement: string,
+  paramsJson: string,
+): HostQueryResponse;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "commitTransaction")
+declare function hostCommitTransaction(transactionId: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "rollbackTransaction")
+declare function hostRollbackTransaction(transactionId: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "openCursor")
+declare function hostOpenCursor(
+  hostName: string,
+  dbType: string,
+  statement: string,
+  paramsJson: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "fetchCursor")
+declare function hostFetchCursor(
+  cursorId: string,
+  maxRows: i32,
+): HostQueryResponse;
+
+// @ts-expect-error: decorator
+@external("modus_sql_client", "closeCursor")
+declare function hostCloseCursor(cursorId: string): string;
+
 class HostQueryResponse {
   error!: string | null;
   resultJson!: string | null;
   rowsAffected!: u32;
 }
 
+class HostCopyResponse {
+  rowsAffected!: u64;
+}
+
 interface Params {
   toJSON(): string;
 }
@@ -90,36 +143,245 @@ export function execute(
     paramsJson,
   );
 
+  return toResponse(response);
+}
+
+export function query<T>(
+  hostName: string,
+  dbType: string,
+  statement: string,
+  params: Params,
+): QueryResponse<T> {
+  const paramsJson = params.toJSON();
+  const response = hostExecuteQuery(
+    hostName,
+    dbType,
+    statement.trim(),
+    paramsJson,
+  );
+
+  return toQueryResponse<T>(response);
+}
+
+export function queryScalar<T>(
+  hostName: string,
+  dbType: string,
+  statement: string,
+  params: Params,
+): ScalarResponse<T> {
+  const response = query<Map<string, T>>(hostName, dbType, statement, params);
+  return toScalarResponse<T>(response);
+}
+
+/**
+ * Inserts the rows into the table of a postgresql connection with the COPY protocol,
+ * and returns the number of rows inserted.
+ * The rows are inserted by a single statement, so either all of them are inserted, or none of them are.
+ * Each row is either an object whose fields are named by the columns, or an array of values
+ * in the order of the columns.  The size of the rows as JSON is bounded by the maxQueryBytes limit
+ * in the manifest, so a large number of rows should be copied in batches.
+ */
+export function copyRows<T>(
+  hostName: string,
+  table: string,
+  columns: string[],
+  rows: T[],
+): u64 {
+  if (columns.length == 0) {
+    throw new Error("At least one column is required to copy rows.");
+  }
+  if (rows.length == 0) {
+    return 0;
+  }
+
+  const response = hostCopyRows(
+    hostName,
+    table,
+    JSON.stringify(columns),
+    JSON.stringify(rows),
+  );
+
   if (utils.resultIsInvalid(response)) {
-    throw new Error("Error performing database query.");
+    throw new Error("Error copying rows to database.");
   }
 
-  if (response.error) {
-    console.error("Database Error: " + response.error!);
+  return response.rowsAffected;
+}
+
+/**
+ * A database transaction, which spans any number of queries until it is committed or rolled back.
+ * If the function ends before then, the Modus runtime rolls back the transaction.
+ */
+export class Transaction {
+  private done: bool = false;
+
+  constructor(public readonly id: string) {}
+
+  execute(
+    statement: string,
+    params: Params = new PositionalParams(),
+  ): Response {
+    return toResponse(this.executeQuery(statement, params));
   }
 
-  const results: Response = {
-    error: response.error,
-    rowsAffected: response.rowsAffected,
-  };
+  query<T>(
+    statement: string,
+    params: Params = new PositionalParams(),
+  ): QueryResponse<T> {
+    return toQueryResponse<T>(this.executeQuery(statement, params));
+  }
 
-  return results;
+  queryScalar<T>(
+    statement: string,
+    params: Params = new PositionalParams(),
+  ): ScalarResponse<T> {
+    return toScalarResponse<T>(this.query<Map<string, T>>(statement, params));
+  }
+
+  /**
+   * Commits the transaction.
+   */
+  commit(): void {
+    if (this.done) {
+      throw new Error("Transaction has already ended.");
+    }
+    this.done = true;
+
+    const response = hostCommitTransaction(this.id);
+    if (utils.resultIsInvalid(response)) {
+      throw new Error("Error committing database transaction.");
+    }
+  }
+
+  /**
+   * Rolls back the transaction.  It does nothing if the transaction has already ended.
+   */
+  rollback(): void {
+    if (this.done) {
+      return;
+    }
+    this.done = true;
+
+    const response = hostRollbackTransaction(this.id);
+    if (utils.resultIsInvalid(response)) {
+      throw new Error("Error rolling back database transaction.");
+    }
+  }
+
+  private executeQuery(statement: string, params: Params): HostQueryResponse {
+    if (this.done) {
+      throw new Error("Transaction has already ended.");
+    }
+
+    return hostExecuteQueryInTransaction(
+      this.id,
+      statement.trim(),
+      params.toJSON(),
+    );
+  }
 }
 
-export function query<T>(
+export function beginTransaction(
+  hostName: string,
+  dbType: string,
+): Transaction {
+  const id = hostBeginTransaction(hostName, dbType);
+  if (utils.resultIsInvalid(id)) {
+    throw new Error("Error beginning database transaction.");
+  }
+  return new Transaction(id);
+}
+
+/**
+ * Runs the function in a transaction, which is committed when the function returns.
+ * If the function throws an error, the function execution ends, and the transaction is rolled back.
+ */
+export function withTx(
+  hostName: string,
+  dbType: string,
+  fn: (tx: Transaction) => void,
+): void {
+  const tx = beginTransaction(hostName, dbType);
+  fn(tx);
+  tx.commit();
+}
+
+/**
+ * A cursor reads the rows of a query in pages, so that large results don't have to fit in memory at once.
+ * The size of each page is also bounded by the maxQueryRows and maxQueryBytes limits of the function.
+ * A cursor should be closed when it is no longer needed.  If the function ends before then, the Modus runtime closes it.
+ */
+export class Cursor<T> {
+  private closed: bool = false;
+
+  constructor(public readonly id: string) {}
+
+  /**
+   * Returns the next page of rows.
+   * @param maxRows The maximum number of rows in the page, or zero to be bounded only by the limits.
+   * @returns The rows, which are empty when there are no more rows.
+   */
+  fetch(maxRows: i32 = 0): T[] {
+    if (this.closed) {
+      throw new Error("Cursor is closed.");
+    }
+
+    const response = toQueryResponse<T>(hostFetchCursor(this.id, maxRows));
+    return response.rows;
+  }
+
+  /**
+   * Closes the cursor.  It does nothing if the cursor is already closed.
+   */
+  close(): void {
+    if (this.closed) {
+      return;
+    }
+    this.closed = true;
+
+    const response = hostCloseCursor(this.id);
+    if (utils.resultIsInvalid(response)) {
+      throw new Error("Error closing database cursor.");
+    }
+  }
+}
+
+export function openCursor<T>(
   hostName: string,
   dbType: string,
   statement: string,
   params: Params,
-): QueryResponse<T> {
-  const paramsJson = params.toJSON();
-  const response = hostExecuteQuery(
+): Cursor<T> {
+  const id = hostOpenCursor(
     hostName,
     dbType,
     statement.trim(),
-    paramsJson,
+    params.toJSON(),
   );
+  if (utils.resultIsInvalid(id)) {
+    throw new Error("Error opening database cursor.");
+  }
+  return new Cursor<T>(id);
+}
+
+function toResponse(response: HostQueryResponse): Response {
+  if (utils.resultIsInvalid(response)) {
+    throw new Error("Error performing database query.");
+  }
+
+  if (response.error) {
+    console.error("Database Error: " + response.error!);
+  }
+
+  const results: Response = {
+    error: response.error,
+    rowsAffected: response.rowsAffected,
+  };
+
+  return results;
+}
 
+function toQueryResponse<T>(response: HostQueryResponse): QueryResponse<T> {
   if (utils.resultIsInvalid(response)) {
     throw new Error("Error performing database query.");
   }
@@ -137,14 +399,9 @@ export function query<T>(
   return results;
 }
 
-export function queryScalar<T>(
-  hostName: string,
-  dbType: string,
-  statement: string,
-  params: Params,
+function toScalarResponse<T>(
+  response: QueryResponse<Map<string, T>>,
 ): ScalarResponse<T> {
-  const response = query<Map<string, T>>(hostName, dbType, statement, params);
-
   if (response.rows.length == 0 || response.rows[0].size == 0) {
     throw new Error("No results returned from query.");
   }
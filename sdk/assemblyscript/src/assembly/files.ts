@@ -0,0 +1,30 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Represents a file that was uploaded with a GraphQL multipart request.
+ * Use it as the type of a function parameter to receive the file's name and content type
+ * along with its contents.  Use an `ArrayBuffer` parameter instead if only the contents are needed.
+ */
+export class File {
+  /**
+   * The original name of the file, as provided by the client.
+   */
+  name!: string;
+
+  /**
+   * The MIME content type of the file, as provided by the client.
+   */
+  contentType!: string;
+
+  /**
+   * The contents of the file.
+   */
+  data!: ArrayBuffer;
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { JSON } from "json-as";
+import * as utils from "./utils";
+import { PositionalParams as Arguments } from "./database";
+export { Arguments };
+
+// @ts-expect-error: decorator
+@external("modus_functions", "callFunction")
+declare function hostCallFunction(fnName: string, args: string): string;
+
+/**
+ * Calls another exported function by name, and returns its result.
+ * The function can be in this app, or in any other plugin loaded by the Modus runtime.
+ * It runs in its own module instance, isolated from the caller.
+ * @param fnName The name of the function to call.
+ * @param args The arguments to pass to the function, in order.
+ * @returns The result of the function.
+ */
+export function call<T>(fnName: string, args: Arguments = new Arguments()): T {
+  const response = hostCallFunction(fnName, args.toJSON());
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error calling function ${fnName}.`);
+  }
+
+  return JSON.parse<T>(response);
+}
+
+/**
+ * Calls another exported function by name, ignoring any result.
+ * Use this for functions that do not return a value.
+ * @param fnName The name of the function to call.
+ * @param args The arguments to pass to the function, in order.
+ */
+export function invoke(
+  fnName: string,
+  args: Arguments = new Arguments(),
+): void {
+  const response = hostCallFunction(fnName, args.toJSON());
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error calling function ${fnName}.`);
+  }
+}
@@ -10,6 +10,12 @@
 import * as postgresql from "./postgresql";
 export { postgresql };
 
+import * as mysql from "./mysql";
+export { mysql };
+
+import * as sqlite from "./sqlite";
+export { sqlite };
+
 import * as dgraph from "./dgraph";
 export { dgraph };
 
@@ -37,4 +43,24 @@ export { neo4j };
 import * as utils from "./utils";
 export { utils };
 
+import * as pagination from "./pagination";
+export { pagination };
+
+import * as functions from "./functions";
+export { functions };
+
+import * as jobs from "./jobs";
+export { jobs };
+
+import * as kv from "./kv";
+export { kv };
+
+import * as crypto from "./crypto";
+export { crypto };
+
+import * as s3 from "./s3";
+export { s3 };
+
 export * from "./dynamicmap";
+
+export * from "./files";
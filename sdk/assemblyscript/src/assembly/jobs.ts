@@ -0,0 +1,112 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { JSON } from "json-as";
+import * as utils from "./utils";
+import { PositionalParams as Arguments } from "./database";
+export { Arguments };
+
+// @ts-expect-error: decorator
+@external("modus_jobs", "enqueueJob")
+declare function hostEnqueueJob(
+  fnName: string,
+  args: string,
+  options: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_jobs", "getJob")
+declare function hostGetJob(id: string): string;
+
+export const STATUS_PENDING = "pending";
+export const STATUS_RUNNING = "running";
+export const STATUS_SUCCEEDED = "succeeded";
+export const STATUS_DEAD = "dead";
+
+/**
+ * Options that control how a job is run.
+ */
+@json
+export class Options {
+  /**
+   * Jobs with a higher priority are run before jobs with a lower priority.
+   * The default is 0.
+   */
+  priority: i32 = 0;
+
+  /**
+   * The maximum number of times the job is attempted before it is given up on.
+   * The default is 3.
+   */
+  maxAttempts: i32 = 0;
+}
+
+/**
+ * Describes a call to a function that is run in the background.
+ */
+@json
+export class Job {
+  id!: string;
+  function!: string;
+  priority: i32 = 0;
+  status!: string;
+  attempts: i32 = 0;
+  maxAttempts: i32 = 0;
+  result: string | null = null;
+  error: string | null = null;
+  executionId: string | null = null;
+  createdAt!: Date;
+  updatedAt!: Date;
+  runAt!: Date;
+  finishedAt: Date | null = null;
+
+  /**
+   * Deserializes the result of a job that has succeeded.
+   */
+  getResult<T>(): T {
+    const result = this.result;
+    if (this.status != STATUS_SUCCEEDED || result == null) {
+      throw new Error(`Job ${this.id} has not succeeded.`);
+    }
+    return JSON.parse<T>(result);
+  }
+}
+
+/**
+ * Adds a job to the queue, to call the named function with the given arguments in the background.
+ * Jobs require the runtime database to be configured.
+ * @param fnName The name of the function to call.
+ * @param args The arguments to pass to the function, in order.
+ * @param options Options that control how the job is run.
+ * @returns The ID of the job, which can be used to get its status and result.
+ */
+export function enqueue(
+  fnName: string,
+  args: Arguments = new Arguments(),
+  options: Options = new Options(),
+): string {
+  const id = hostEnqueueJob(fnName, args.toJSON(), JSON.stringify(options));
+  if (utils.resultIsInvalid(id)) {
+    throw new Error(`Error enqueuing job for function ${fnName}.`);
+  }
+  return id;
+}
+
+/**
+ * Gets the job with the given ID.
+ * @param id The ID of the job.
+ * @returns The job, or null if there is no such job.
+ */
+export function get(id: string): Job | null {
+  const response = hostGetJob(id);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error getting job ${id}.`);
+  }
+  return JSON.parse<Job | null>(response);
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { JSON } from "json-as";
+import * as utils from "./utils";
+
+// @ts-expect-error: decorator
+@external("modus_kv", "get")
+declare function hostGet(namespace: string, key: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_kv", "set")
+declare function hostSet(
+  namespace: string,
+  key: string,
+  value: string,
+  ttlSeconds: i32,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_kv", "compareAndSwap")
+declare function hostCompareAndSwap(
+  namespace: string,
+  key: string,
+  version: i64,
+  value: string,
+  ttlSeconds: i32,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_kv", "delete")
+declare function hostDelete(namespace: string, key: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_kv", "scan")
+declare function hostScan(
+  namespace: string,
+  prefix: string,
+  limit: i32,
+): string;
+
+/**
+ * A value stored in the key-value store.
+ */
+@json
+export class Entry {
+  key!: string;
+  value!: string;
+
+  /**
+   * The version is incremented each time the value is set, and is used for compare-and-swap.
+   */
+  version: i64 = 0;
+
+  /**
+   * The time the entry expires, if it was set with a TTL.
+   */
+  expiresAt: Date | null = null;
+}
+
+/**
+ * Gets the entry for the given key.
+ * @param key The key of the entry.
+ * @param namespace The namespace to use, within the app.  The default namespace is empty.
+ * @returns The entry, or null if there is no such entry.
+ */
+export function get(key: string, namespace: string = ""): Entry | null {
+  const response = hostGet(namespace, key);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error getting key ${key}.`);
+  }
+  return JSON.parse<Entry | null>(response);
+}
+
+/**
+ * Sets the value for the given key.
+ * @param key The key of the entry.
+ * @param value The value to set.
+ * @param ttlSeconds The number of seconds the entry is kept before it expires.  The default of 0 means it does not expire.
+ * @param namespace The namespace to use, within the app.  The default namespace is empty.
+ * @returns The updated entry.
+ */
+export function set(
+  key: string,
+  value: string,
+  ttlSeconds: i32 = 0,
+  namespace: string = "",
+): Entry {
+  const response = hostSet(namespace, key, value, ttlSeconds);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error setting key ${key}.`);
+  }
+  return JSON.parse<Entry>(response);
+}
+
+/**
+ * Sets the value for the given key, only if the current version of the entry matches the given version.
+ * @param key The key of the entry.
+ * @param version The expected version of the entry.  Use 0 to set the value only if there is no existing entry.
+ * @param value The value to set.
+ * @param ttlSeconds The number of seconds the entry is kept before it expires.  The default of 0 means it does not expire.
+ * @param namespace The namespace to use, within the app.  The default namespace is empty.
+ * @returns The updated entry, or null if the version did not match.
+ */
+export function compareAndSwap(
+  key: string,
+  version: i64,
+  value: string,
+  ttlSeconds: i32 = 0,
+  namespace: string = "",
+): Entry | null {
+  const response = hostCompareAndSwap(
+    namespace,
+    key,
+    version,
+    value,
+    ttlSeconds,
+  );
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error swapping key ${key}.`);
+  }
+  return JSON.parse<Entry | null>(response);
+}
+
+/**
+ * Deletes the entry for the given key.
+ * @param key The key of the entry.
+ * @param namespace The namespace to use, within the app.  The default namespace is empty.
+ * @returns True if the entry existed.
+ */
+export function remove(key: string, namespace: string = ""): bool {
+  const response = hostDelete(namespace, key);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error deleting key ${key}.`);
+  }
+  return JSON.parse<bool>(response);
+}
+
+/**
+ * Gets the entries whose keys start with the given prefix, in key order.
+ * @param prefix The prefix of the keys.
+ * @param limit The maximum number of entries to return.  The default of 0 returns up to 100 entries.
+ * @param namespace The namespace to use, within the app.  The default namespace is empty.
+ * @returns The entries.
+ */
+export function scan(
+  prefix: string,
+  limit: i32 = 0,
+  namespace: string = "",
+): Entry[] {
+  const response = hostScan(namespace, prefix, limit);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error scanning keys with prefix ${prefix}.`);
+  }
+  return JSON.parse<Entry[]>(response);
+}
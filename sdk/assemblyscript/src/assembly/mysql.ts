@@ -0,0 +1,69 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as db from "./database";
+import {
+  PositionalParams as Params,
+  Response,
+  QueryResponse,
+  ScalarResponse,
+  Transaction,
+  Cursor,
+} from "./database";
+
+export { Params, Response, QueryResponse, ScalarResponse, Transaction, Cursor };
+
+const dbType = "mysql";
+
+export function execute(
+  hostName: string,
+  statement: string,
+  params: Params = new Params(),
+): Response {
+  return db.execute(hostName, dbType, statement, params);
+}
+
+export function query<T>(
+  hostName: string,
+  statement: string,
+  params: Params = new Params(),
+): QueryResponse<T> {
+  return db.query<T>(hostName, dbType, statement, params);
+}
+
+export function queryScalar<T>(
+  hostName: string,
+  statement: string,
+  params: Params = new Params(),
+): ScalarResponse<T> {
+  return db.queryScalar<T>(hostName, dbType, statement, params);
+}
+
+/**
+ * Runs the query, and returns a cursor for reading its rows in pages.
+ */
+export function openCursor<T>(
+  hostName: string,
+  statement: string,
+  params: Params = new Params(),
+): Cursor<T> {
+  return db.openCursor<T>(hostName, dbType, statement, params);
+}
+
+export function beginTransaction(hostName: string): Transaction {
+  return db.beginTransaction(hostName, dbType);
+}
+
+/**
+ * Runs the function in a transaction, which is committed when the function returns.
+ * If the function throws an error, the function execution ends, and the transaction is rolled back.
+ */
+export function withTx(hostName: string, fn: (tx: Transaction) => void): void {
+  db.withTx(hostName, dbType, fn);
+}
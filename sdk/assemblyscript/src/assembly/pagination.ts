@@ -0,0 +1,45 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Describes whether there are more items before or after a page of results.
+ *
+ * A function is paginated when it has a `first` parameter of an integer type, and an `after`
+ * parameter of type `string`, and returns an array.  The GraphQL schema will then present the
+ * results as a Relay connection.  See https://relay.dev/graphql/connections.htm
+ *
+ * The function can instead return a class that has exactly two fields: an `items` array,
+ * and a `pageInfo` field of this type, to report the page info explicitly.
+ */
+export class PageInfo {
+  /**
+   * Indicates that there are more items after this page.
+   */
+  hasNextPage: bool = false;
+
+  /**
+   * Indicates that there are items before this page.
+   */
+  hasPreviousPage: bool = false;
+}
+
+/**
+ * Returns the number of items that precede the requested page, for items that don't have an id.
+ * It should be called with the value of the `after` parameter of a paginated function.
+ *
+ * The `after` parameter receives the id of the last item the client received, or if the items
+ * don't have an `id` field, the number of items the client has received so far.  It is empty
+ * when the first page is requested.  The function should return up to `first` items that follow,
+ * and can return one additional item to indicate that there is another page.
+ */
+export function offset(after: string): i32 {
+  if (after.length == 0) return 0;
+  const n = parseInt(after);
+  return isNaN(n) || n < 0 ? 0 : <i32>n;
+}
@@ -13,9 +13,11 @@ import {
   Response,
   QueryResponse,
   ScalarResponse,
+  Transaction,
+  Cursor,
 } from "./database";
 
-export { Params, Response, QueryResponse, ScalarResponse };
+export { Params, Response, QueryResponse, ScalarResponse, Transaction, Cursor };
 
 const dbType = "postgresql";
 
@@ -43,6 +45,44 @@ export function queryScalar<T>(
   return db.queryScalar<T>(hostName, dbType, statement, params);
 }
 
+/**
+ * Inserts the rows into the table with the COPY protocol, and returns the number of rows inserted.
+ * Each row is either an object whose fields are named by the columns, or an array of values
+ * in the order of the columns.  The size of the rows as JSON is bounded by the maxQueryBytes limit
+ * in the manifest.
+ */
+export function copyRows<T>(
+  hostName: string,
+  table: string,
+  columns: string[],
+  rows: T[],
+): u64 {
+  return db.copyRows<T>(hostName, table, columns, rows);
+}
+
+/**
+ * Runs the query, and returns a cursor for reading its rows in pages.
+ */
+export function openCursor<T>(
+  hostName: string,
+  statement: string,
+  params: Params = new Params(),
+): Cursor<T> {
+  return db.openCursor<T>(hostName, dbType, statement, params);
+}
+
+export function beginTransaction(hostName: string): Transaction {
+  return db.beginTransaction(hostName, dbType);
+}
+
+/**
+ * Runs the function in a transaction, which is committed when the function returns.
+ * If the function throws an error, the function execution ends, and the transaction is rolled back.
+ */
+export function withTx(hostName: string, fn: (tx: Transaction) => void): void {
+  db.withTx(hostName, dbType, fn);
+}
+
 function parsePointString(data: string): f64[] {
   if (!data.startsWith("(") || !data.endsWith(")")) {
     console.error(`Invalid Point string: "${data}"`);
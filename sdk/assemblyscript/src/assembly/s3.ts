@@ -0,0 +1,205 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { JSON } from "json-as";
+import * as utils from "./utils";
+
+// @ts-expect-error: decorator
+@external("modus_s3_client", "listObjects")
+declare function hostListObjects(
+  connection: string,
+  prefix: string,
+  startAfter: string,
+  limit: i32,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_s3_client", "getObject")
+declare function hostGetObject(connection: string, key: string): ArrayBuffer;
+
+// @ts-expect-error: decorator
+@external("modus_s3_client", "putObject")
+declare function hostPutObject(
+  connection: string,
+  key: string,
+  content: ArrayBuffer,
+  contentType: string,
+): string;
+
+// @ts-expect-error: decorator
+@external("modus_s3_client", "deleteObject")
+declare function hostDeleteObject(connection: string, key: string): string;
+
+// @ts-expect-error: decorator
+@external("modus_s3_client", "presignUrl")
+declare function hostPresignUrl(
+  connection: string,
+  method: string,
+  key: string,
+  expiresInSeconds: i32,
+): string;
+
+/**
+ * Describes an object in a bucket.
+ */
+@json
+export class ObjectInfo {
+  key!: string;
+  size: i64 = 0;
+
+  /**
+   * The entity tag of the object, which is not provided when using a local directory.
+   */
+  etag: string | null = null;
+
+  /**
+   * The time the object was last modified, which is not provided when putting an object to a bucket.
+   */
+  lastModified: Date | null = null;
+}
+
+/**
+ * Lists the objects whose keys start with the given prefix, in key order.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param prefix The prefix of the keys.  The default lists all objects.
+ * @param startAfter The key after which to start listing, to page through the objects.
+ * @param limit The maximum number of objects to return.  The default of 0 returns up to 1000 objects.
+ * @returns The objects.
+ */
+export function listObjects(
+  connection: string,
+  prefix: string = "",
+  startAfter: string = "",
+  limit: i32 = 0,
+): ObjectInfo[] {
+  const response = hostListObjects(connection, prefix, startAfter, limit);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error listing objects with prefix ${prefix}.`);
+  }
+  return JSON.parse<ObjectInfo[]>(response);
+}
+
+/**
+ * Gets the content of an object.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ * @returns The content of the object.
+ */
+export function getObject(connection: string, key: string): ArrayBuffer {
+  const response = hostGetObject(connection, key);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error getting object ${key}.`);
+  }
+  return response;
+}
+
+/**
+ * Gets the content of an object as a UTF-8 string.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ * @returns The content of the object.
+ */
+export function getObjectText(connection: string, key: string): string {
+  return String.UTF8.decode(getObject(connection, key));
+}
+
+/**
+ * Writes the content of an object, replacing any existing object.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ * @param content The content of the object.
+ * @param contentType The content type of the object, such as "application/pdf".
+ * @returns Information about the object.
+ */
+export function putObject(
+  connection: string,
+  key: string,
+  content: ArrayBuffer,
+  contentType: string = "",
+): ObjectInfo {
+  const response = hostPutObject(connection, key, content, contentType);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error putting object ${key}.`);
+  }
+  return JSON.parse<ObjectInfo>(response);
+}
+
+/**
+ * Writes a string as the UTF-8 content of an object, replacing any existing object.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ * @param text The content of the object.
+ * @param contentType The content type of the object.  The default is "text/plain".
+ * @returns Information about the object.
+ */
+export function putObjectText(
+  connection: string,
+  key: string,
+  text: string,
+  contentType: string = "text/plain",
+): ObjectInfo {
+  return putObject(connection, key, String.UTF8.encode(text), contentType);
+}
+
+/**
+ * Deletes an object.  It is not an error if the object does not exist.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ */
+export function deleteObject(connection: string, key: string): void {
+  const response = hostDeleteObject(connection, key);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error deleting object ${key}.`);
+  }
+}
+
+/**
+ * Creates a URL that can be used to download an object, without credentials.
+ * When the connection uses a local directory, a file URL is returned instead.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ * @param expiresInSeconds The number of seconds the URL is valid.  The default of 0 means 15 minutes.  The maximum is 7 days.
+ * @returns The URL.
+ */
+export function presignGetUrl(
+  connection: string,
+  key: string,
+  expiresInSeconds: i32 = 0,
+): string {
+  return presignUrl(connection, "GET", key, expiresInSeconds);
+}
+
+/**
+ * Creates a URL that can be used to upload an object, without credentials.
+ * When the connection uses a local directory, a file URL is returned instead.
+ * @param connection The name of the S3 connection, as defined in the manifest.
+ * @param key The key of the object.
+ * @param expiresInSeconds The number of seconds the URL is valid.  The default of 0 means 15 minutes.  The maximum is 7 days.
+ * @returns The URL.
+ */
+export function presignPutUrl(
+  connection: string,
+  key: string,
+  expiresInSeconds: i32 = 0,
+): string {
+  return presignUrl(connection, "PUT", key, expiresInSeconds);
+}
+
+function presignUrl(
+  connection: string,
+  method: string,
+  key: string,
+  expiresInSeconds: i32,
+): string {
+  const response = hostPresignUrl(connection, method, key, expiresInSeconds);
+  if (utils.resultIsInvalid(response)) {
+    throw new Error(`Error creating presigned ${method} URL for object ${key}.`);
+  }
+  return response;
+}
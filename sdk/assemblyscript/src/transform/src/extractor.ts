@@ -42,23 +42,35 @@ import {
   typeMap,
 } from "./types.js";
 import ModusTransform from "./index.js";
+import {
+  addFieldMethods,
+  FieldMethod,
+  renameFieldExports,
+} from "./fields.js";
+import { Parser } from "types:assemblyscript/src/parser";
 
 export class Extractor {
   binaryen: typeof binaryen;
   module: binaryen.Module;
   program: Program;
   transform: ModusTransform;
+  fieldMethods: FieldMethod[] = [];
 
   constructor(transform: ModusTransform) {
     this.binaryen = transform.binaryen;
   }
 
+  parseHook(parser: Parser): void {
+    this.fieldMethods = addFieldMethods(parser);
+  }
+
   initHook(program: Program): void {
     this.program = program;
   }
 
   compileHook(module: binaryen.Module): void {
     this.module = module;
+    renameFieldExports(module, this.fieldMethods);
   }
 
   getProgramInfo(): ProgramInfo {
@@ -78,6 +90,8 @@ export class Extractor {
             c.type.toString(),
             c.id,
             this.getClassFields(c),
+            undefined,
+            this.getClassVariants(c),
           );
         })
         .map((t) => [t.name, t]),
@@ -133,6 +147,16 @@ export class Extractor {
       });
     }
 
+    // include the concrete subclasses of abstract classes
+    if (type.variants) {
+      type.variants.forEach((v) => {
+        const typeDef = allTypes.get(v);
+        if (typeDef) {
+          dependentTypes.add(typeDef);
+        }
+      });
+    }
+
     // include generic type arguments
     const cls = this.program.managedClasses.get(type.id);
     if (cls.typeArguments) {
@@ -178,6 +202,22 @@ export class Extractor {
       );
   }
 
+  private getClassVariants(c: Class) {
+    if (!c.prototype.is(CommonFlags.Abstract)) {
+      return undefined;
+    }
+
+    // An abstract class is represented by the concrete classes that extend it.
+    const variants = Array.from(this.program.managedClasses.values())
+      .filter(
+        (d) => !d.prototype.is(CommonFlags.Abstract) && extendsClass(d, c),
+      )
+      .map((d) => d.type.toString())
+      .sort();
+
+    return variants.length > 0 ? variants : undefined;
+  }
+
   private getExportedFunctions() {
     const results: importExportInfo[] = [];
 
@@ -238,9 +278,24 @@ export class Extractor {
       { type: f.signature.returnType.toString() },
     ]);
 
-    signature.docs = this.getDocsFromFunction(signature);
+    const fm = this.fieldMethods.find((m) => m.name == e.name);
+    signature.docs = fm
+      ? this.getDocsFromMethod(fm)
+      : this.getDocsFromFunction(signature);
     return signature;
   }
+  private getDocsFromMethod(fm: FieldMethod) {
+    const members = fm.cls.members;
+    const index = members.indexOf(fm.method);
+    const start = index > 0 ? members[index - 1].range.end : fm.cls.range.start;
+    const end = fm.method.range.start;
+
+    const newRange = new Range(start, end);
+    newRange.source = fm.method.range.source;
+    const commentNodes = this.parseComments(newRange);
+    if (!commentNodes.length) return null;
+    return Docs.from(commentNodes);
+  }
   private getDocsFromFunction(signature: FunctionSignature) {
     let docs: Docs | null = null;
 
@@ -480,6 +535,13 @@ export function getLiteral(node: Expression | null): JsonLiteral {
   return "";
 }
 
+function extendsClass(c: Class, base: Class): boolean {
+  for (let b = c.base; b; b = b.base) {
+    if (b === base) return true;
+  }
+  return false;
+}
+
 const nullableTypeRegex = /\s?\|\s?null$/;
 
 function isNullable(type: string) {
@@ -0,0 +1,245 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as path from "path";
+import binaryen from "assemblyscript/lib/binaryen.js";
+import {
+  ArrowKind,
+  ClassDeclaration,
+  CommonFlags,
+  ExportStatement,
+  Expression,
+  FunctionDeclaration,
+  IdentifierExpression,
+  MethodDeclaration,
+  NamedTypeNode,
+  Node,
+  NodeKind,
+  ParameterKind,
+  Range,
+  Source,
+  SourceKind,
+  TypeNode,
+} from "assemblyscript/dist/assemblyscript.js";
+import { Parser } from "types:assemblyscript/src/parser";
+
+const fieldDecorator = "field";
+const wrapperPrefix = "__modus_field_";
+
+// A class method or getter that is decorated with @field, to opt in to its use
+// as a field of the class's type. It is exported as a function named
+// "<Class>.<method>", which takes the instance as its first parameter.
+export class FieldMethod {
+  constructor(
+    public name: string,
+    public wrapperName: string,
+    public cls: ClassDeclaration,
+    public method: MethodDeclaration,
+  ) {}
+}
+
+// Adds an exported wrapper function for each field method of the user's
+// classes. Wrappers of classes that are not declared in the entry file are
+// re-exported from it, so that they are exported from the module.
+export function addFieldMethods(parser: Parser): FieldMethod[] {
+  const results: FieldMethod[] = [];
+
+  const entry = parser.sources.find(
+    (s) => s.sourceKind == SourceKind.UserEntry,
+  );
+  if (!entry) return results;
+
+  const sources = parser.sources.filter(
+    (s) =>
+      s.sourceKind == SourceKind.User || s.sourceKind == SourceKind.UserEntry,
+  );
+
+  for (const source of sources) {
+    const wrappers: string[] = [];
+    for (const stmt of source.statements.slice()) {
+      if (stmt.kind != NodeKind.ClassDeclaration) continue;
+      const cls = stmt as ClassDeclaration;
+      for (const member of cls.members) {
+        if (member.kind != NodeKind.MethodDeclaration) continue;
+        const method = member as MethodDeclaration;
+        if (!hasFieldDecorator(method)) continue;
+
+        const fm = createFieldMethod(cls, method);
+        source.statements.push(createWrapper(fm));
+        wrappers.push(fm.wrapperName);
+        results.push(fm);
+      }
+    }
+
+    if (wrappers.length > 0 && source !== entry) {
+      entry.statements.push(createReExport(entry, source, wrappers));
+    }
+  }
+
+  return results;
+}
+
+// Exports the wrapper functions with the names of the field methods.
+export function renameFieldExports(
+  module: binaryen.Module,
+  methods: FieldMethod[],
+): void {
+  for (const fm of methods) {
+    const ref = module.getExport(fm.wrapperName);
+    if (!ref) continue;
+    const info = binaryen.getExportInfo(ref);
+    module.removeExport(fm.wrapperName);
+    module.addFunctionExport(info.value, fm.name);
+  }
+}
+
+function hasFieldDecorator(method: MethodDeclaration): boolean {
+  return (method.decorators || []).some(
+    (d) =>
+      d.name.kind == NodeKind.Identifier &&
+      (d.name as IdentifierExpression).text == fieldDecorator,
+  );
+}
+
+function createFieldMethod(
+  cls: ClassDeclaration,
+  method: MethodDeclaration,
+): FieldMethod {
+  const className = cls.name.text;
+  const methodName = method.name.text;
+  const name = className + "." + methodName;
+
+  const cannotUse = (reason: string) =>
+    new Error(`Method ${name} can't be used as a field, because ${reason}.`);
+
+  if (cls.typeParameters && cls.typeParameters.length > 0) {
+    throw cannotUse(`class ${className} is generic`);
+  }
+  if (method.flags & (CommonFlags.Private | CommonFlags.Protected)) {
+    throw cannotUse("it is not public");
+  }
+  if (
+    method.flags &
+    (CommonFlags.Static | CommonFlags.Set | CommonFlags.Constructor)
+  ) {
+    throw cannotUse("it is not an instance method or getter");
+  }
+  if (isVoid(method.signature.returnType)) {
+    throw cannotUse("it doesn't return a value");
+  }
+
+  return new FieldMethod(
+    name,
+    wrapperPrefix + className + "_" + methodName,
+    cls,
+    method,
+  );
+}
+
+// Creates a function equivalent to the following:
+//
+//   export function __modus_field_<Class>_<method>(self: <Class>, ...args) {
+//     return self.<method>(...args);
+//   }
+//
+// Getters are wrapped the same way, except that they are not called.
+function createWrapper(fm: FieldMethod): FunctionDeclaration {
+  const method = fm.method;
+  const range = method.range;
+  const ident = (text: string) => Node.createIdentifierExpression(text, range);
+
+  const selfType = Node.createNamedType(
+    Node.createSimpleTypeName(fm.cls.name.text, range),
+    null,
+    false,
+    range,
+  );
+  const parameters = [
+    Node.createParameter(
+      ParameterKind.Default,
+      ident("self"),
+      selfType,
+      null,
+      range,
+    ),
+    ...method.signature.parameters,
+  ];
+
+  let value: Expression = Node.createPropertyAccessExpression(
+    ident("self"),
+    ident(method.name.text),
+    range,
+  );
+  if (!(method.flags & CommonFlags.Get)) {
+    const args = method.signature.parameters.map((p) => ident(p.name.text));
+    value = Node.createCallExpression(value, null, args, range);
+  }
+
+  const body = Node.createBlockStatement(
+    [Node.createReturnStatement(value, range)],
+    range,
+  );
+
+  return Node.createFunctionDeclaration(
+    ident(fm.wrapperName),
+    null,
+    CommonFlags.Export,
+    null,
+    Node.createFunctionType(
+      parameters,
+      method.signature.returnType,
+      null,
+      false,
+      range,
+    ),
+    body,
+    ArrowKind.None,
+    range,
+  );
+}
+
+// Creates a statement equivalent to the following, in the entry file:
+//
+//   export { __modus_field_<Class>_<method>, ... } from "./<file>";
+function createReExport(
+  entry: Source,
+  source: Source,
+  names: string[],
+): ExportStatement {
+  const range = new Range(0, 0);
+  range.source = entry;
+
+  let relPath = path.posix.relative(
+    path.posix.dirname(entry.internalPath),
+    source.internalPath,
+  );
+  if (!relPath.startsWith(".")) relPath = "./" + relPath;
+
+  const members = names.map((name) =>
+    Node.createExportMember(
+      Node.createIdentifierExpression(name, range),
+      null,
+      range,
+    ),
+  );
+
+  return Node.createExportStatement(
+    members,
+    Node.createStringLiteralExpression(relPath, range),
+    false,
+    range,
+  );
+}
+
+function isVoid(type: TypeNode): boolean {
+  return (
+    type.kind == NodeKind.NamedType &&
+    (type as NamedTypeNode).name.identifier.text == "void"
+  );
+}
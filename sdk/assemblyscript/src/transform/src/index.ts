@@ -12,9 +12,13 @@ import { Metadata } from "./metadata.js";
 import { Extractor } from "./extractor.js";
 import binaryen from "assemblyscript/lib/binaryen.js";
 import { Program } from "types:assemblyscript/src/program";
+import { Parser } from "types:assemblyscript/src/parser";
 
 export default class ModusTransform extends Transform {
   private extractor = new Extractor(this);
+  afterParse(parser: Parser): void | Promise<void> {
+    this.extractor.parseHook(parser);
+  }
   afterInitialize(program: Program): void | Promise<void> {
     this.extractor.initHook(program);
   }
@@ -91,10 +91,16 @@ export class TypeDefinition {
     public id: number,
     public fields?: Field[],
     public docs: Docs | undefined = undefined,
+    public variants?: string[],
   ) {}
 
   toString() {
     const name = getTypeName(this.name);
+    if (this.variants && this.variants.length > 0) {
+      const variants = this.variants.map((v) => getTypeName(v)).join(" | ");
+      return `${name} = ${variants}`;
+    }
+
     if (!this.fields || this.fields.length === 0) {
       return name;
     }
@@ -109,6 +115,7 @@ export class TypeDefinition {
     return {
       id: this.id,
       fields: this.fields,
+      variants: this.variants,
       docs: this.docs,
     };
   }
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package crypto provides cryptographic operations that are performed by the Modus runtime.
+//
+// Keys are referenced by name, and are never passed to the function.  The runtime reads each key
+// from the MODUS_KEY_<NAME> secret, where the name is uppercased and dashes are replaced with underscores.
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/console"
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
+)
+
+// HashAlgorithm is the name of a SHA-2 or SHA-3 hash algorithm.
+type HashAlgorithm = string
+
+const (
+	SHA224   HashAlgorithm = "sha224"
+	SHA256   HashAlgorithm = "sha256"
+	SHA384   HashAlgorithm = "sha384"
+	SHA512   HashAlgorithm = "sha512"
+	SHA3_224 HashAlgorithm = "sha3-224"
+	SHA3_256 HashAlgorithm = "sha3-256"
+	SHA3_384 HashAlgorithm = "sha3-384"
+	SHA3_512 HashAlgorithm = "sha3-512"
+)
+
+// SignatureAlgorithm is the name of a digital signature algorithm.
+type SignatureAlgorithm = string
+
+const (
+	// Ed25519 signatures require an Ed25519 key.
+	Ed25519 SignatureAlgorithm = "ed25519"
+
+	// ECDSA signatures are ASN.1 encoded, and require an ECDSA key on the P-256, P-384 or P-521 curve.
+	// The data is hashed with SHA-256, SHA-384 or SHA-512 respectively.
+	ECDSA SignatureAlgorithm = "ecdsa"
+)
+
+// Hash returns the hex-encoded digest of the data.
+func Hash(algorithm HashAlgorithm, data string) (string, error) {
+	response := hostHash(&algorithm, &data)
+	if response == nil {
+		return "", fmt.Errorf("failed to compute %s hash", algorithm)
+	}
+	return *response, nil
+}
+
+// HMAC returns the hex-encoded HMAC of the data, using the named key as the secret.
+func HMAC(algorithm HashAlgorithm, keyName, data string) (string, error) {
+	response := hostHmac(&algorithm, &keyName, &data)
+	if response == nil {
+		return "", fmt.Errorf("failed to compute HMAC with key %s", keyName)
+	}
+	return *response, nil
+}
+
+// VerifyHMAC reports whether the hex-encoded HMAC matches the data, using the named key as the secret.
+// The comparison is done in constant time.
+func VerifyHMAC(algorithm HashAlgorithm, keyName, data, mac string) (bool, error) {
+	response := hostVerifyHmac(&algorithm, &keyName, &data, &mac)
+	if response == nil {
+		return false, fmt.Errorf("failed to verify HMAC with key %s", keyName)
+	}
+	return parseResponse[bool](*response)
+}
+
+// EncryptAESGCM encrypts the plaintext with AES-GCM, using the named key, which must be a base64-encoded
+// 128, 192 or 256-bit key.  The associated data is authenticated, but not encrypted, and may be empty.
+// It returns the base64-encoded nonce, followed by the ciphertext.
+func EncryptAESGCM(keyName, plaintext, associatedData string) (string, error) {
+	response := hostEncryptAesGcm(&keyName, &plaintext, &associatedData)
+	if response == nil {
+		return "", fmt.Errorf("failed to encrypt with key %s", keyName)
+	}
+	return *response, nil
+}
+
+// DecryptAESGCM decrypts a ciphertext that was returned by EncryptAESGCM, using the same key and associated data.
+func DecryptAESGCM(keyName, ciphertext, associatedData string) (string, error) {
+	response := hostDecryptAesGcm(&keyName, &ciphertext, &associatedData)
+	if response == nil {
+		return "", fmt.Errorf("failed to decrypt with key %s", keyName)
+	}
+	return *response, nil
+}
+
+// Sign returns the base64-encoded signature of the data, using the named key,
+// which must be a PEM-encoded private key.
+func Sign(algorithm SignatureAlgorithm, keyName, data string) (string, error) {
+	response := hostSign(&algorithm, &keyName, &data)
+	if response == nil {
+		return "", fmt.Errorf("failed to sign with key %s", keyName)
+	}
+	return *response, nil
+}
+
+// Verify reports whether the base64-encoded signature of the data is valid, using the named key,
+// which must be a PEM-encoded public key, or a private key from which the public key is derived.
+func Verify(algorithm SignatureAlgorithm, keyName, data, signature string) (bool, error) {
+	response := hostVerify(&algorithm, &keyName, &data, &signature)
+	if response == nil {
+		return false, fmt.Errorf("failed to verify signature with key %s", keyName)
+	}
+	return parseResponse[bool](*response)
+}
+
+// CreateJWT returns a JWT with the given claims, signed with the named key.
+// The algorithm is one of HS256, HS384 or HS512, which use the key as a shared secret,
+// or one of RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384, ES512 or EdDSA,
+// which require the key to be a PEM-encoded private key of the matching type.
+func CreateJWT(algorithm, keyName string, claims map[string]any) (string, error) {
+	bytes, err := utils.JsonSerialize(claims)
+	if err != nil {
+		return "", err
+	}
+
+	claimsJson := string(bytes)
+	response := hostCreateJwt(&algorithm, &keyName, &claimsJson)
+	if response == nil {
+		return "", fmt.Errorf("failed to create JWT with key %s", keyName)
+	}
+	return *response, nil
+}
+
+type jwtVerification struct {
+	Valid  bool           `json:"valid"`
+	Claims map[string]any `json:"claims"`
+	Error  string         `json:"error"`
+}
+
+// VerifyJWT verifies the signature and the time-based claims of a JWT, using the named key,
+// and returns its claims.  Only the algorithms that match the type of the key are accepted.
+func VerifyJWT(keyName, token string) (map[string]any, error) {
+	response := hostVerifyJwt(&keyName, &token)
+	if response == nil {
+		return nil, fmt.Errorf("failed to verify JWT with key %s", keyName)
+	}
+
+	result, err := parseResponse[jwtVerification](*response)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Valid {
+		return nil, errors.New(result.Error)
+	}
+	return result.Claims, nil
+}
+
+func parseResponse[T any](response string) (T, error) {
+	var result T
+	if err := utils.JsonDeserialize([]byte(response), &result); err != nil {
+		console.Error(err.Error())
+		return result, err
+	}
+	return result, nil
+}
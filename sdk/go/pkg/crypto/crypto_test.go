@@ -0,0 +1,152 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/crypto"
+)
+
+func TestHash(t *testing.T) {
+	digest, err := crypto.Hash(crypto.SHA256, "abc")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if digest != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("Unexpected digest: %s", digest)
+	}
+
+	values := crypto.HashCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostHash, but none was made")
+	}
+	if *values[0].(*string) != "sha256" {
+		t.Errorf("Expected algorithm: %s, but received: %s", "sha256", *values[0].(*string))
+	}
+	if *values[1].(*string) != "abc" {
+		t.Errorf("Expected data: %s, but received: %s", "abc", *values[1].(*string))
+	}
+}
+
+func TestHashError(t *testing.T) {
+	if _, err := crypto.Hash("md5", "abc"); err == nil {
+		t.Error("Expected an error, but received none")
+	}
+	crypto.HashCallStack.Pop()
+}
+
+func TestHMAC(t *testing.T) {
+	mac, err := crypto.HMAC(crypto.SHA256, "webhook", "payload")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	ok, err := crypto.VerifyHMAC(crypto.SHA256, "webhook", "payload", mac)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if !ok {
+		t.Error("Expected the HMAC to be valid")
+	}
+
+	values := crypto.HmacCallStack.Pop()
+	if *values[1].(*string) != "webhook" {
+		t.Errorf("Expected key name: %s, but received: %s", "webhook", *values[1].(*string))
+	}
+
+	values = crypto.VerifyHmacCallStack.Pop()
+	if *values[3].(*string) != mac {
+		t.Errorf("Expected mac: %s, but received: %s", mac, *values[3].(*string))
+	}
+
+	if _, err := crypto.HMAC(crypto.SHA256, "missing", "payload"); err == nil {
+		t.Error("Expected an error for a missing key, but received none")
+	}
+	crypto.HmacCallStack.Pop()
+}
+
+func TestAESGCM(t *testing.T) {
+	ciphertext, err := crypto.EncryptAESGCM("data", "secret", "context")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	plaintext, err := crypto.DecryptAESGCM("data", ciphertext, "context")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if plaintext != "secret" {
+		t.Errorf("Expected plaintext: %s, but received: %s", "secret", plaintext)
+	}
+
+	values := crypto.EncryptAesGcmCallStack.Pop()
+	if *values[2].(*string) != "context" {
+		t.Errorf("Expected associated data: %s, but received: %s", "context", *values[2].(*string))
+	}
+	crypto.DecryptAesGcmCallStack.Pop()
+}
+
+func TestSignAndVerify(t *testing.T) {
+	sig, err := crypto.Sign(crypto.Ed25519, "signing", "payload")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	ok, err := crypto.Verify(crypto.Ed25519, "signing", "payload", sig)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if !ok {
+		t.Error("Expected the signature to be valid")
+	}
+
+	ok, err = crypto.Verify(crypto.Ed25519, "signing", "payload", "invalid")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if ok {
+		t.Error("Expected the signature to be invalid")
+	}
+
+	values := crypto.SignCallStack.Pop()
+	if *values[0].(*string) != "ed25519" {
+		t.Errorf("Expected algorithm: %s, but received: %s", "ed25519", *values[0].(*string))
+	}
+	crypto.VerifyCallStack.Pop()
+	crypto.VerifyCallStack.Pop()
+}
+
+func TestJWT(t *testing.T) {
+	token, err := crypto.CreateJWT("HS256", "tokens", map[string]any{"sub": "user1"})
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	values := crypto.CreateJwtCallStack.Pop()
+	if *values[2].(*string) != `{"sub":"user1"}` {
+		t.Errorf("Expected claims: %s, but received: %s", `{"sub":"user1"}`, *values[2].(*string))
+	}
+
+	claims, err := crypto.VerifyJWT("tokens", token)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if claims["sub"] != "user1" {
+		t.Errorf("Expected sub claim: %s, but received: %v", "user1", claims["sub"])
+	}
+
+	_, err = crypto.VerifyJWT("tokens", "invalid")
+	if err == nil || err.Error() != "token is malformed" {
+		t.Errorf("Expected error: %s, but received: %v", "token is malformed", err)
+	}
+	crypto.VerifyJwtCallStack.Pop()
+	crypto.VerifyJwtCallStack.Pop()
+}
@@ -0,0 +1,102 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/testutils"
+)
+
+var HashCallStack = testutils.NewCallStack()
+var HmacCallStack = testutils.NewCallStack()
+var VerifyHmacCallStack = testutils.NewCallStack()
+var EncryptAesGcmCallStack = testutils.NewCallStack()
+var DecryptAesGcmCallStack = testutils.NewCallStack()
+var SignCallStack = testutils.NewCallStack()
+var VerifyCallStack = testutils.NewCallStack()
+var CreateJwtCallStack = testutils.NewCallStack()
+var VerifyJwtCallStack = testutils.NewCallStack()
+
+func hostHash(algorithm, data *string) *string {
+	HashCallStack.Push(algorithm, data)
+
+	if *algorithm != "sha256" {
+		return nil
+	}
+	digest := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	return &digest
+}
+
+func hostHmac(algorithm, keyName, data *string) *string {
+	HmacCallStack.Push(algorithm, keyName, data)
+
+	if *keyName != "webhook" {
+		return nil
+	}
+	mac := "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	return &mac
+}
+
+func hostVerifyHmac(algorithm, keyName, data, mac *string) *string {
+	VerifyHmacCallStack.Push(algorithm, keyName, data, mac)
+
+	json := fmt.Sprint(*mac == "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8")
+	return &json
+}
+
+func hostEncryptAesGcm(keyName, plaintext, associatedData *string) *string {
+	EncryptAesGcmCallStack.Push(keyName, plaintext, associatedData)
+
+	ciphertext := "bm9uY2U=" + *plaintext
+	return &ciphertext
+}
+
+func hostDecryptAesGcm(keyName, ciphertext, associatedData *string) *string {
+	DecryptAesGcmCallStack.Push(keyName, ciphertext, associatedData)
+
+	plaintext := (*ciphertext)[len("bm9uY2U="):]
+	return &plaintext
+}
+
+func hostSign(algorithm, keyName, data *string) *string {
+	SignCallStack.Push(algorithm, keyName, data)
+
+	sig := "c2lnbmF0dXJl"
+	return &sig
+}
+
+func hostVerify(algorithm, keyName, data, signature *string) *string {
+	VerifyCallStack.Push(algorithm, keyName, data, signature)
+
+	json := fmt.Sprint(*signature == "c2lnbmF0dXJl")
+	return &json
+}
+
+func hostCreateJwt(algorithm, keyName, claimsJson *string) *string {
+	CreateJwtCallStack.Push(algorithm, keyName, claimsJson)
+
+	token := "header.payload.signature"
+	return &token
+}
+
+func hostVerifyJwt(keyName, token *string) *string {
+	VerifyJwtCallStack.Push(keyName, token)
+
+	var json string
+	if *token == "header.payload.signature" {
+		json = `{"valid":true,"claims":{"sub":"user1"}}`
+	} else {
+		json = `{"valid":false,"error":"token is malformed"}`
+	}
+	return &json
+}
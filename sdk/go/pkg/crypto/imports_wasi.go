@@ -0,0 +1,48 @@
+//go:build wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package crypto
+
+//go:noescape
+//go:wasmimport modus_crypto hash
+func hostHash(algorithm, data *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto hmac
+func hostHmac(algorithm, keyName, data *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto verifyHmac
+func hostVerifyHmac(algorithm, keyName, data, mac *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto encryptAesGcm
+func hostEncryptAesGcm(keyName, plaintext, associatedData *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto decryptAesGcm
+func hostDecryptAesGcm(keyName, ciphertext, associatedData *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto sign
+func hostSign(algorithm, keyName, data *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto verify
+func hostVerify(algorithm, keyName, data, signature *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto createJwt
+func hostCreateJwt(algorithm, keyName, claimsJson *string) *string
+
+//go:noescape
+//go:wasmimport modus_crypto verifyJwt
+func hostVerifyJwt(keyName, token *string) *string
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
+)
+
+// CopyRows inserts the rows into the table of a postgresql connection with the COPY protocol,
+// and returns the number of rows inserted.  The rows are inserted by a single statement,
+// so either all of them are inserted, or none of them are.
+//
+// Each row is either a struct or map whose fields are named by the columns, or a slice of values
+// in the order of the columns.  A column that is missing from a struct or map is set to null.
+//
+// The size of the rows as JSON is bounded by the maxQueryBytes limit in the manifest,
+// so a large number of rows should be copied in batches.
+func CopyRows[T any](hostName, table string, columns []string, rows []T) (uint64, error) {
+	if len(columns) == 0 {
+		return 0, errors.New("at least one column is required to copy rows")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	columnsJson, err := utils.JsonSerialize(columns)
+	if err != nil {
+		return 0, fmt.Errorf("could not JSON serialize columns: %v", err)
+	}
+
+	rowsJson, err := utils.JsonSerialize(rows)
+	if err != nil {
+		return 0, fmt.Errorf("could not JSON serialize rows: %v", err)
+	}
+
+	sColumns := string(columnsJson)
+	sRows := string(rowsJson)
+	response := hostCopyRows(&hostName, &table, &sColumns, &sRows)
+	if response == nil {
+		return 0, errors.New("no response received from database copy")
+	}
+	return response.RowsAffected, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db_test
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/db"
+)
+
+type testUser struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCopyRowsStructs(t *testing.T) {
+	users := []testUser{{1, "Alice"}, {2, "Bob"}, {3, "Charlie"}}
+	count, err := db.CopyRows(testHostName, "users", []string{"id", "name"}, users)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 rows copied, but received: %d", count)
+	}
+
+	values := db.CopyRowsCallStack.Pop()
+	if *values[1].(*string) != "users" {
+		t.Errorf("Expected table: users, but received: %s", *values[1].(*string))
+	}
+	if *values[2].(*string) != `["id","name"]` {
+		t.Errorf("Expected columns: [\"id\",\"name\"], but received: %s", *values[2].(*string))
+	}
+	expected := `[{"id":1,"name":"Alice"},{"id":2,"name":"Bob"},{"id":3,"name":"Charlie"}]`
+	if *values[3].(*string) != expected {
+		t.Errorf("Expected rows: %s, but received: %s", expected, *values[3].(*string))
+	}
+}
+
+func TestCopyRowsArrays(t *testing.T) {
+	rows := [][]any{{1, "Alice"}, {2, nil}}
+	count, err := db.CopyRows(testHostName, "public.users", []string{"id", "name"}, rows)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows copied, but received: %d", count)
+	}
+
+	values := db.CopyRowsCallStack.Pop()
+	if *values[3].(*string) != `[[1,"Alice"],[2,null]]` {
+		t.Errorf("Expected rows: [[1,\"Alice\"],[2,null]], but received: %s", *values[3].(*string))
+	}
+}
+
+func TestCopyRowsEmpty(t *testing.T) {
+	calls := db.CopyRowsCallStack.Size()
+
+	count, err := db.CopyRows(testHostName, "users", []string{"id"}, []testUser{})
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 rows copied, but received: %d", count)
+	}
+	if n := db.CopyRowsCallStack.Size() - calls; n != 0 {
+		t.Errorf("Expected no host call, but received: %d", n)
+	}
+
+	if _, err := db.CopyRows(testHostName, "users", nil, []testUser{{1, "Alice"}}); err == nil {
+		t.Error("Expected an error copying rows without columns, but received none")
+	}
+}
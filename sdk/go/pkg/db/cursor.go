@@ -0,0 +1,83 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Cursor reads the rows of a query in pages, so that large results don't have to fit in memory at once.
+// The size of each page is also bounded by the maxQueryRows and maxQueryBytes limits of the function.
+// A cursor should be closed when it is no longer needed.  If the function ends before then, the Modus runtime closes it.
+type Cursor[T any] struct {
+	id     string
+	closed bool
+}
+
+// OpenCursor runs the query, and returns a cursor for reading its rows.
+func OpenCursor[T any](hostName, dbType, statement string, params ...any) (*Cursor[T], error) {
+	paramsJson, err := serializeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	statement = strings.TrimSpace(statement)
+	id := hostOpenCursor(&hostName, &dbType, &statement, &paramsJson)
+	if id == nil {
+		return nil, fmt.Errorf("failed to open cursor on %s", hostName)
+	}
+	return &Cursor[T]{id: *id}, nil
+}
+
+// Fetch returns the next page of up to maxRows rows.  If maxRows is zero, the page size is bounded only by the limits.
+// An empty page is returned when there are no more rows.
+func (c *Cursor[T]) Fetch(maxRows int) ([]T, error) {
+	if c.closed {
+		return nil, errors.New("cursor is closed")
+	}
+
+	response := hostFetchCursor(&c.id, int32(maxRows))
+	rows, _, err := parseRows[T](readResponse(response))
+	return rows, err
+}
+
+// Close closes the cursor.  It does nothing if the cursor is already closed.
+func (c *Cursor[T]) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	if hostCloseCursor(&c.id) == nil {
+		return errors.New("failed to close cursor")
+	}
+	return nil
+}
+
+// ForEachPage calls the function with each page of up to pageSize rows, and closes the cursor.
+// It stops at the first error, which is returned.
+func (c *Cursor[T]) ForEachPage(pageSize int, fn func(rows []T) error) error {
+	defer c.Close()
+
+	for {
+		rows, err := c.Fetch(pageSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return c.Close()
+		}
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db_test
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/db"
+)
+
+type person struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCursor(t *testing.T) {
+	cursor, err := db.OpenCursor[person](testHostName, testDbType, "SELECT * FROM people WHERE age >= $1", 18)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err)
+	}
+
+	values := db.OpenCursorCallStack.Pop()
+	if *values[3].(*string) != "[18]" {
+		t.Errorf("Expected params: %s, but received: %s", "[18]", *values[3].(*string))
+	}
+
+	var names []string
+	err = cursor.ForEachPage(2, func(rows []person) error {
+		for _, row := range rows {
+			names = append(names, row.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err)
+	}
+	if len(names) != 3 || names[0] != "Alice" || names[2] != "Charlie" {
+		t.Errorf("Expected names: [Alice Bob Charlie], but received: %v", names)
+	}
+
+	values = db.FetchCursorCallStack.Pop()
+	if *values[0].(*string) != db.MockCursorId {
+		t.Errorf("Expected cursorId: %s, but received: %s", db.MockCursorId, *values[0].(*string))
+	}
+	if values[1].(int32) != 2 {
+		t.Errorf("Expected maxRows: %d, but received: %d", 2, values[1].(int32))
+	}
+
+	if n := db.CloseCursorCallStack.Size(); n != 1 {
+		t.Errorf("Expected 1 close, but received: %d", n)
+	}
+
+	if _, err := cursor.Fetch(2); err == nil {
+		t.Error("Expected an error fetching from a closed cursor, but received none")
+	}
+}
@@ -23,13 +23,24 @@ type HostQueryResponse struct {
 	RowsAffected uint32
 }
 
+type HostCopyResponse struct {
+	RowsAffected uint64
+}
+
 func Execute(hostName, dbType, statement string, params ...any) (uint, error) {
 	_, affected, err := doQuery(hostName, dbType, statement, params...)
 	return affected, err
 }
 
 func Query[T any](hostName, dbType, statement string, params ...any) ([]T, uint, error) {
-	resultJson, affected, err := doQuery(hostName, dbType, statement, params...)
+	return parseRows[T](doQuery(hostName, dbType, statement, params...))
+}
+
+func QueryScalar[T any](hostName, dbType, statement string, params ...any) (T, uint, error) {
+	return parseScalar[T](Query[map[string]any](hostName, dbType, statement, params...))
+}
+
+func parseRows[T any](resultJson *string, affected uint, err error) ([]T, uint, error) {
 	if err != nil {
 		return nil, affected, err
 	}
@@ -44,10 +55,8 @@ func Query[T any](hostName, dbType, statement string, params ...any) ([]T, uint,
 	return rows, affected, nil
 }
 
-func QueryScalar[T any](hostName, dbType, statement string, params ...any) (T, uint, error) {
+func parseScalar[T any](rows []map[string]any, affected uint, err error) (T, uint, error) {
 	var zero T
-
-	rows, affected, err := Query[map[string]any](hostName, dbType, statement, params...)
 	if err != nil {
 		return zero, affected, err
 	}
@@ -73,17 +82,29 @@ func QueryScalar[T any](hostName, dbType, statement string, params ...any) (T, u
 }
 
 func doQuery(hostName, dbType, statement string, params ...any) (*string, uint, error) {
-	paramsJson := "[]"
-	if len(params) > 0 {
-		bytes, err := utils.JsonSerialize(params)
-		if err != nil {
-			return nil, 0, fmt.Errorf("could not JSON serialize query parameters: %v", err)
-		}
-		paramsJson = string(bytes)
+	paramsJson, err := serializeParams(params)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	statement = strings.TrimSpace(statement)
 	response := hostExecuteQuery(&hostName, &dbType, &statement, &paramsJson)
+	return readResponse(response)
+}
+
+func serializeParams(params []any) (string, error) {
+	if len(params) == 0 {
+		return "[]", nil
+	}
+
+	bytes, err := utils.JsonSerialize(params)
+	if err != nil {
+		return "", fmt.Errorf("could not JSON serialize query parameters: %v", err)
+	}
+	return string(bytes), nil
+}
+
+func readResponse(response *HostQueryResponse) (*string, uint, error) {
 	if response == nil {
 		return nil, 0, errors.New("no response received from database query")
 	}
@@ -13,9 +13,18 @@ package db
 
 import (
 	"github.com/hypermodeinc/modus/sdk/go/pkg/testutils"
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
 )
 
 var DatabaseQueryCallStack = testutils.NewCallStack()
+var CopyRowsCallStack = testutils.NewCallStack()
+var BeginTransactionCallStack = testutils.NewCallStack()
+var TransactionQueryCallStack = testutils.NewCallStack()
+var CommitTransactionCallStack = testutils.NewCallStack()
+var RollbackTransactionCallStack = testutils.NewCallStack()
+var OpenCursorCallStack = testutils.NewCallStack()
+var FetchCursorCallStack = testutils.NewCallStack()
+var CloseCursorCallStack = testutils.NewCallStack()
 
 var (
 	MockExecuteStatement  = "UPDATE users SET name = $1 age = $2 WHERE id = $3"
@@ -28,9 +37,86 @@ var (
 	MockQueryScalarParameters = []any{0, 18, false}
 )
 
+const MockTransactionId = "tx1"
+
 func hostExecuteQuery(hostName, dbType, statement, paramsJson *string) *HostQueryResponse {
 	DatabaseQueryCallStack.Push(hostName, dbType, statement, paramsJson)
+	return mockQueryResponse(statement)
+}
+
+func hostCopyRows(hostName, tableName, columnsJson, rowsJson *string) *HostCopyResponse {
+	CopyRowsCallStack.Push(hostName, tableName, columnsJson, rowsJson)
+
+	var rows []any
+	if err := utils.JsonDeserialize([]byte(*rowsJson), &rows); err != nil {
+		return nil
+	}
+	return &HostCopyResponse{RowsAffected: uint64(len(rows))}
+}
+
+func hostBeginTransaction(hostName, dbType *string) *string {
+	BeginTransactionCallStack.Push(hostName, dbType)
+
+	id := MockTransactionId
+	return &id
+}
+
+func hostExecuteQueryInTransaction(transactionId, statement, paramsJson *string) *HostQueryResponse {
+	TransactionQueryCallStack.Push(transactionId, statement, paramsJson)
+	return mockQueryResponse(statement)
+}
+
+func hostCommitTransaction(transactionId *string) *string {
+	CommitTransactionCallStack.Push(transactionId)
+
+	result := "true"
+	return &result
+}
+
+func hostRollbackTransaction(transactionId *string) *string {
+	RollbackTransactionCallStack.Push(transactionId)
+
+	result := "true"
+	return &result
+}
+
+const MockCursorId = "cursor1"
+
+// MockCursorPages are the pages that are returned by a mock cursor, followed by an empty page.
+var MockCursorPages = []string{
+	`[{"id":1,"name":"Alice"},{"id":2,"name":"Bob"}]`,
+	`[{"id":3,"name":"Charlie"}]`,
+}
+
+var mockCursorPage = 0
+
+func hostOpenCursor(hostName, dbType, statement, paramsJson *string) *string {
+	OpenCursorCallStack.Push(hostName, dbType, statement, paramsJson)
+	mockCursorPage = 0
+
+	id := MockCursorId
+	return &id
+}
+
+func hostFetchCursor(cursorId *string, maxRows int32) *HostQueryResponse {
+	FetchCursorCallStack.Push(cursorId, maxRows)
+
+	result := "[]"
+	if mockCursorPage < len(MockCursorPages) {
+		result = MockCursorPages[mockCursorPage]
+		mockCursorPage++
+	}
+	return &HostQueryResponse{ResultJson: &result}
+}
+
+func hostCloseCursor(cursorId *string) *string {
+	CloseCursorCallStack.Push(cursorId)
+
+	result := "true"
+	return &result
+}
 
+func mockQueryResponse(statement *string) *HostQueryResponse {
 	switch *statement {
 	case MockExecuteStatement:
 		return &HostQueryResponse{
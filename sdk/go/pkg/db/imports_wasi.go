@@ -25,3 +25,62 @@ func hostExecuteQuery(hostName, dbType, statement, paramsJson *string) *HostQuer
 	}
 	return (*HostQueryResponse)(response)
 }
+
+//go:noescape
+//go:wasmimport modus_sql_client copyRows
+func _hostCopyRows(hostName, tableName, columnsJson, rowsJson *string) unsafe.Pointer
+
+//modus:import modus_sql_client copyRows
+func hostCopyRows(hostName, tableName, columnsJson, rowsJson *string) *HostCopyResponse {
+	response := _hostCopyRows(hostName, tableName, columnsJson, rowsJson)
+	if response == nil {
+		return nil
+	}
+	return (*HostCopyResponse)(response)
+}
+
+//go:noescape
+//go:wasmimport modus_sql_client beginTransaction
+func hostBeginTransaction(hostName, dbType *string) *string
+
+//go:noescape
+//go:wasmimport modus_sql_client executeQueryInTransaction
+func _hostExecuteQueryInTransaction(transactionId, statement, paramsJson *string) unsafe.Pointer
+
+//modus:import modus_sql_client executeQueryInTransaction
+func hostExecuteQueryInTransaction(transactionId, statement, paramsJson *string) *HostQueryResponse {
+	response := _hostExecuteQueryInTransaction(transactionId, statement, paramsJson)
+	if response == nil {
+		return nil
+	}
+	return (*HostQueryResponse)(response)
+}
+
+//go:noescape
+//go:wasmimport modus_sql_client commitTransaction
+func hostCommitTransaction(transactionId *string) *string
+
+//go:noescape
+//go:wasmimport modus_sql_client rollbackTransaction
+func hostRollbackTransaction(transactionId *string) *string
+
+//go:noescape
+//go:wasmimport modus_sql_client openCursor
+func hostOpenCursor(hostName, dbType, statement, paramsJson *string) *string
+
+//go:noescape
+//go:wasmimport modus_sql_client fetchCursor
+func _hostFetchCursor(cursorId *string, maxRows int32) unsafe.Pointer
+
+//modus:import modus_sql_client fetchCursor
+func hostFetchCursor(cursorId *string, maxRows int32) *HostQueryResponse {
+	response := _hostFetchCursor(cursorId, maxRows)
+	if response == nil {
+		return nil
+	}
+	return (*HostQueryResponse)(response)
+}
+
+//go:noescape
+//go:wasmimport modus_sql_client closeCursor
+func hostCloseCursor(cursorId *string) *string
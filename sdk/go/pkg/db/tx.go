@@ -0,0 +1,105 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tx is a database transaction, which spans any number of queries until it is committed or rolled back.
+// If the function ends before then, the Modus runtime rolls back the transaction.
+type Tx struct {
+	id   string
+	done bool
+}
+
+// BeginTx begins a transaction on the connection.
+func BeginTx(hostName, dbType string) (*Tx, error) {
+	id := hostBeginTransaction(&hostName, &dbType)
+	if id == nil {
+		return nil, fmt.Errorf("failed to begin transaction on %s", hostName)
+	}
+	return &Tx{id: *id}, nil
+}
+
+// WithTx runs the function in a transaction, which is committed if the function returns nil,
+// and rolled back if it returns an error.
+func WithTx(hostName, dbType string, fn func(tx *Tx) error) error {
+	tx, err := BeginTx(hostName, dbType)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Commit commits the transaction.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return errors.New("transaction has already ended")
+	}
+	tx.done = true
+
+	if hostCommitTransaction(&tx.id) == nil {
+		return errors.New("failed to commit transaction")
+	}
+	return nil
+}
+
+// Rollback rolls back the transaction.  It does nothing if the transaction has already ended.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return nil
+	}
+	tx.done = true
+
+	if hostRollbackTransaction(&tx.id) == nil {
+		return errors.New("failed to roll back transaction")
+	}
+	return nil
+}
+
+// Execute runs the statement within the transaction, and returns the number of rows affected.
+func (tx *Tx) Execute(statement string, params ...any) (uint, error) {
+	_, affected, err := tx.doQuery(statement, params...)
+	return affected, err
+}
+
+// QueryTx runs the query within the transaction, and returns the rows and the number of rows affected.
+func QueryTx[T any](tx *Tx, statement string, params ...any) ([]T, uint, error) {
+	return parseRows[T](tx.doQuery(statement, params...))
+}
+
+// QueryScalarTx runs the query within the transaction, and returns the value of the single column of the single row.
+func QueryScalarTx[T any](tx *Tx, statement string, params ...any) (T, uint, error) {
+	return parseScalar[T](QueryTx[map[string]any](tx, statement, params...))
+}
+
+func (tx *Tx) doQuery(statement string, params ...any) (*string, uint, error) {
+	if tx.done {
+		return nil, 0, errors.New("transaction has already ended")
+	}
+
+	paramsJson, err := serializeParams(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	statement = strings.TrimSpace(statement)
+	response := hostExecuteQueryInTransaction(&tx.id, &statement, &paramsJson)
+	return readResponse(response)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package db_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/db"
+)
+
+func TestWithTxCommit(t *testing.T) {
+	commits := db.CommitTransactionCallStack.Size()
+	rollbacks := db.RollbackTransactionCallStack.Size()
+
+	err := db.WithTx(testHostName, testDbType, func(tx *db.Tx) error {
+		count, _, err := db.QueryScalarTx[int](tx, db.MockQueryScalarStatement, db.MockQueryScalarParameters...)
+		if err != nil {
+			return err
+		}
+		if count != 3 {
+			t.Errorf("Expected count: 3, but received: %d", count)
+		}
+
+		affected, err := tx.Execute(db.MockExecuteStatement, db.MockExecuteParameters...)
+		if err != nil {
+			return err
+		}
+		if affected != 3 {
+			t.Errorf("Expected 3 rows affected, but received: %d", affected)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err)
+	}
+
+	values := db.BeginTransactionCallStack.Pop()
+	if *values[0].(*string) != testHostName {
+		t.Errorf("Expected hostName: %s, but received: %s", testHostName, *values[0].(*string))
+	}
+
+	values = db.TransactionQueryCallStack.Pop()
+	if *values[0].(*string) != db.MockTransactionId {
+		t.Errorf("Expected transactionId: %s, but received: %s", db.MockTransactionId, *values[0].(*string))
+	}
+	if *values[1].(*string) != db.MockExecuteStatement {
+		t.Errorf("Expected statement: %s, but received: %s", db.MockExecuteStatement, *values[1].(*string))
+	}
+
+	if n := db.CommitTransactionCallStack.Size() - commits; n != 1 {
+		t.Errorf("Expected 1 commit, but received: %d", n)
+	}
+	if n := db.RollbackTransactionCallStack.Size() - rollbacks; n != 0 {
+		t.Errorf("Expected no rollback, but received: %d", n)
+	}
+}
+
+func TestWithTxRollback(t *testing.T) {
+	commits := db.CommitTransactionCallStack.Size()
+	rollbacks := db.RollbackTransactionCallStack.Size()
+
+	expectedErr := errors.New("insufficient balance")
+	var tx *db.Tx
+	err := db.WithTx(testHostName, testDbType, func(t *db.Tx) error {
+		tx = t
+		return expectedErr
+	})
+	if err != expectedErr {
+		t.Errorf("Expected error: %v, but received: %v", expectedErr, err)
+	}
+
+	if n := db.CommitTransactionCallStack.Size() - commits; n != 0 {
+		t.Errorf("Expected no commit, but received: %d", n)
+	}
+	if n := db.RollbackTransactionCallStack.Size() - rollbacks; n != 1 {
+		t.Errorf("Expected 1 rollback, but received: %d", n)
+	}
+
+	if _, err := tx.Execute(db.MockExecuteStatement); err == nil {
+		t.Error("Expected an error using a transaction that has ended, but received none")
+	}
+}
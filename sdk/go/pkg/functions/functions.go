@@ -0,0 +1,48 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/console"
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
+)
+
+// Call calls another exported function by name, passing the given arguments in order,
+// and returns the function's result.  The function can be in this app, or in any other plugin
+// loaded by the Modus runtime.  It runs in its own module instance, isolated from the caller.
+func Call[T any](fnName string, args ...any) (T, error) {
+	var result T
+
+	if args == nil {
+		args = []any{}
+	}
+
+	bytes, err := utils.JsonSerialize(args)
+	if err != nil {
+		console.Error(err.Error())
+		return result, err
+	}
+
+	argsStr := string(bytes)
+
+	response := hostCallFunction(&fnName, &argsStr)
+	if response == nil {
+		return result, fmt.Errorf("failed to call function %s", fnName)
+	}
+
+	if err := utils.JsonDeserialize([]byte(*response), &result); err != nil {
+		console.Error(err.Error())
+		return result, err
+	}
+
+	return result, nil
+}
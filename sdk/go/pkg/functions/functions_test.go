@@ -0,0 +1,60 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/functions"
+)
+
+func TestCall(t *testing.T) {
+	result, err := functions.Call[string]("sayHello", "World", 42)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if result != "Hello, World!" {
+		t.Errorf("Expected result: %s, but received: %s", "Hello, World!", result)
+	}
+
+	values := functions.CallFunctionCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostCallFunction, but none was made")
+	}
+	if *values[0].(*string) != "sayHello" {
+		t.Errorf("Expected fnName: %s, but received: %s", "sayHello", *values[0].(*string))
+	}
+	if *values[1].(*string) != `["World",42]` {
+		t.Errorf("Expected args: %s, but received: %s", `["World",42]`, *values[1].(*string))
+	}
+}
+
+func TestCallWithoutArgs(t *testing.T) {
+	if _, err := functions.Call[string]("sayHello"); err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	values := functions.CallFunctionCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostCallFunction, but none was made")
+	}
+	if *values[1].(*string) != "[]" {
+		t.Errorf("Expected args: %s, but received: %s", "[]", *values[1].(*string))
+	}
+}
+
+func TestCallError(t *testing.T) {
+	if _, err := functions.Call[string]("missing"); err == nil {
+		t.Errorf("Expected an error, but received none")
+	}
+	functions.CallFunctionCallStack.Pop()
+}
@@ -0,0 +1,27 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package functions
+
+import "github.com/hypermodeinc/modus/sdk/go/pkg/testutils"
+
+var CallFunctionCallStack = testutils.NewCallStack()
+
+func hostCallFunction(fnName, args *string) *string {
+	CallFunctionCallStack.Push(fnName, args)
+
+	if *fnName == "missing" {
+		return nil
+	}
+
+	result := `"Hello, World!"`
+	return &result
+}
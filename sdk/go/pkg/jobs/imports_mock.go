@@ -0,0 +1,55 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs
+
+import "github.com/hypermodeinc/modus/sdk/go/pkg/testutils"
+
+var EnqueueJobCallStack = testutils.NewCallStack()
+var GetJobCallStack = testutils.NewCallStack()
+
+func hostEnqueueJob(fnName, args, options *string) *string {
+	EnqueueJobCallStack.Push(fnName, args, options)
+
+	if *fnName == "missing" {
+		return nil
+	}
+
+	id := "0192a8b4-3c5e-7d6f-8a9b-0c1d2e3f4a5b"
+	return &id
+}
+
+func hostGetJob(id *string) *string {
+	GetJobCallStack.Push(id)
+
+	var json string
+	if *id == "0192a8b4-3c5e-7d6f-8a9b-0c1d2e3f4a5b" {
+		json = `{
+			"id": "0192a8b4-3c5e-7d6f-8a9b-0c1d2e3f4a5b",
+			"function": "generateReport",
+			"priority": 0,
+			"status": "succeeded",
+			"attempts": 1,
+			"maxAttempts": 3,
+			"result": "{\"pages\":3}",
+			"error": null,
+			"executionId": "cs5cl4v9p3tb7fvu6q3g",
+			"createdAt": "2024-10-15T12:00:00Z",
+			"updatedAt": "2024-10-15T12:00:05Z",
+			"runAt": "2024-10-15T12:00:00Z",
+			"finishedAt": "2024-10-15T12:00:05Z"
+		}`
+	} else {
+		json = "null"
+	}
+
+	return &json
+}
@@ -0,0 +1,20 @@
+//go:build wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs
+
+//go:noescape
+//go:wasmimport modus_jobs enqueueJob
+func hostEnqueueJob(fnName, args, options *string) *string
+
+//go:noescape
+//go:wasmimport modus_jobs getJob
+func hostGetJob(id *string) *string
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/console"
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusDead      = "dead"
+)
+
+// Job describes a call to a function that is run in the background.
+type Job struct {
+	Id          string     `json:"id"`
+	Function    string     `json:"function"`
+	Priority    int        `json:"priority"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"maxAttempts"`
+	Result      *string    `json:"result"`
+	Error       *string    `json:"error"`
+	ExecutionId *string    `json:"executionId"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	RunAt       time.Time  `json:"runAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+}
+
+// Options control how a job is run.
+type Options struct {
+	// Jobs with a higher priority are run before jobs with a lower priority.  The default is 0.
+	Priority int `json:"priority,omitempty"`
+
+	// The maximum number of times the job is attempted before it is given up on.  The default is 3.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// Enqueue adds a job to the queue, to call the named function with the given arguments in the background.
+// It returns the ID of the job, which can be used to get its status and result.
+// Jobs require the runtime database to be configured.
+func Enqueue(fnName string, args ...any) (string, error) {
+	return EnqueueWithOptions(fnName, Options{}, args...)
+}
+
+// EnqueueWithOptions is like Enqueue, but allows setting options that control how the job is run.
+func EnqueueWithOptions(fnName string, opts Options, args ...any) (string, error) {
+	if args == nil {
+		args = []any{}
+	}
+
+	argsBytes, err := utils.JsonSerialize(args)
+	if err != nil {
+		console.Error(err.Error())
+		return "", err
+	}
+
+	optsBytes, err := utils.JsonSerialize(opts)
+	if err != nil {
+		console.Error(err.Error())
+		return "", err
+	}
+
+	argsStr := string(argsBytes)
+	optsStr := string(optsBytes)
+
+	id := hostEnqueueJob(&fnName, &argsStr, &optsStr)
+	if id == nil {
+		return "", fmt.Errorf("failed to enqueue job for function %s", fnName)
+	}
+
+	return *id, nil
+}
+
+// Get returns the job with the given ID, or nil if there is no such job.
+func Get(id string) (*Job, error) {
+	response := hostGetJob(&id)
+	if response == nil {
+		return nil, fmt.Errorf("failed to get job %s", id)
+	}
+
+	var job *Job
+	if err := utils.JsonDeserialize([]byte(*response), &job); err != nil {
+		console.Error(err.Error())
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetResult deserializes the result of a job that has succeeded.
+func GetResult[T any](job *Job) (T, error) {
+	var result T
+	if job == nil || job.Status != StatusSucceeded || job.Result == nil {
+		return result, fmt.Errorf("the job has not succeeded")
+	}
+
+	err := utils.JsonDeserialize([]byte(*job.Result), &result)
+	return result, err
+}
@@ -0,0 +1,119 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobs_test
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/jobs"
+)
+
+const testJobId = "0192a8b4-3c5e-7d6f-8a9b-0c1d2e3f4a5b"
+
+func TestEnqueue(t *testing.T) {
+	id, err := jobs.Enqueue("generateReport", "weekly", 42)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if id != testJobId {
+		t.Errorf("Expected id: %s, but received: %s", testJobId, id)
+	}
+
+	values := jobs.EnqueueJobCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostEnqueueJob, but none was made")
+	}
+	if *values[0].(*string) != "generateReport" {
+		t.Errorf("Expected fnName: %s, but received: %s", "generateReport", *values[0].(*string))
+	}
+	if *values[1].(*string) != `["weekly",42]` {
+		t.Errorf("Expected args: %s, but received: %s", `["weekly",42]`, *values[1].(*string))
+	}
+	if *values[2].(*string) != "{}" {
+		t.Errorf("Expected options: %s, but received: %s", "{}", *values[2].(*string))
+	}
+}
+
+func TestEnqueueWithOptions(t *testing.T) {
+	opts := jobs.Options{Priority: 10, MaxAttempts: 5}
+	if _, err := jobs.EnqueueWithOptions("generateReport", opts); err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	values := jobs.EnqueueJobCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostEnqueueJob, but none was made")
+	}
+	if *values[1].(*string) != "[]" {
+		t.Errorf("Expected args: %s, but received: %s", "[]", *values[1].(*string))
+	}
+	expected := `{"priority":10,"maxAttempts":5}`
+	if *values[2].(*string) != expected {
+		t.Errorf("Expected options: %s, but received: %s", expected, *values[2].(*string))
+	}
+}
+
+func TestEnqueueError(t *testing.T) {
+	if _, err := jobs.Enqueue("missing"); err == nil {
+		t.Errorf("Expected an error, but received none")
+	}
+	jobs.EnqueueJobCallStack.Pop()
+}
+
+func TestGet(t *testing.T) {
+	job, err := jobs.Get(testJobId)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if job == nil {
+		t.Fatalf("Expected a job, but received nil")
+	}
+	if job.Id != testJobId {
+		t.Errorf("Expected id: %s, but received: %s", testJobId, job.Id)
+	}
+	if job.Status != jobs.StatusSucceeded {
+		t.Errorf("Expected status: %s, but received: %s", jobs.StatusSucceeded, job.Status)
+	}
+	if job.FinishedAt == nil {
+		t.Errorf("Expected finishedAt to be set")
+	}
+
+	type report struct {
+		Pages int `json:"pages"`
+	}
+	result, err := jobs.GetResult[report](job)
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if result.Pages != 3 {
+		t.Errorf("Expected pages: %d, but received: %d", 3, result.Pages)
+	}
+
+	values := jobs.GetJobCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostGetJob, but none was made")
+	}
+	if *values[0].(*string) != testJobId {
+		t.Errorf("Expected id: %s, but received: %s", testJobId, *values[0].(*string))
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	job, err := jobs.Get("00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if job != nil {
+		t.Errorf("Expected nil, but received a job")
+	}
+	jobs.GetJobCallStack.Pop()
+}
@@ -0,0 +1,69 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+import (
+	"fmt"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/testutils"
+)
+
+var GetCallStack = testutils.NewCallStack()
+var SetCallStack = testutils.NewCallStack()
+var CompareAndSwapCallStack = testutils.NewCallStack()
+var DeleteCallStack = testutils.NewCallStack()
+var ScanCallStack = testutils.NewCallStack()
+
+func hostGet(namespace, key *string) *string {
+	GetCallStack.Push(namespace, key)
+
+	var json string
+	if *key == "greeting" {
+		json = `{"key":"greeting","value":"Hello","version":2}`
+	} else {
+		json = "null"
+	}
+	return &json
+}
+
+func hostSet(namespace, key, value *string, ttlSeconds int32) *string {
+	SetCallStack.Push(namespace, key, value, ttlSeconds)
+
+	json := fmt.Sprintf(`{"key":%q,"value":%q,"version":1}`, *key, *value)
+	return &json
+}
+
+func hostCompareAndSwap(namespace, key *string, version int64, value *string, ttlSeconds int32) *string {
+	CompareAndSwapCallStack.Push(namespace, key, version, value, ttlSeconds)
+
+	var json string
+	if version == 2 {
+		json = fmt.Sprintf(`{"key":%q,"value":%q,"version":3}`, *key, *value)
+	} else {
+		json = "null"
+	}
+	return &json
+}
+
+func hostDelete(namespace, key *string) *string {
+	DeleteCallStack.Push(namespace, key)
+
+	json := fmt.Sprint(*key == "greeting")
+	return &json
+}
+
+func hostScan(namespace, prefix *string, limit int32) *string {
+	ScanCallStack.Push(namespace, prefix, limit)
+
+	json := `[{"key":"user:1","value":"Alice","version":1},{"key":"user:2","value":"Bob","version":4}]`
+	return &json
+}
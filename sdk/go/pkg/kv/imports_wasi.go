@@ -0,0 +1,32 @@
+//go:build wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+//go:noescape
+//go:wasmimport modus_kv get
+func hostGet(namespace, key *string) *string
+
+//go:noescape
+//go:wasmimport modus_kv set
+func hostSet(namespace, key, value *string, ttlSeconds int32) *string
+
+//go:noescape
+//go:wasmimport modus_kv compareAndSwap
+func hostCompareAndSwap(namespace, key *string, version int64, value *string, ttlSeconds int32) *string
+
+//go:noescape
+//go:wasmimport modus_kv delete
+func hostDelete(namespace, key *string) *string
+
+//go:noescape
+//go:wasmimport modus_kv scan
+func hostScan(namespace, prefix *string, limit int32) *string
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/console"
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
+)
+
+// Entry is a value stored in the key-value store.
+type Entry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+
+	// The version is incremented each time the value is set, and is used for compare-and-swap.
+	Version int64 `json:"version"`
+
+	// The time the entry expires, if it was set with a TTL.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type Option func(*Options)
+
+type Options struct {
+	namespace string
+	ttl       time.Duration
+	limit     int
+}
+
+// WithNamespace sets the namespace to use, within the app.  The default namespace is empty.
+func WithNamespace(namespace string) Option {
+	return func(o *Options) {
+		o.namespace = namespace
+	}
+}
+
+// WithTTL sets how long an entry is kept before it expires.  By default, entries do not expire.
+// The TTL is rounded down to whole seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.ttl = ttl
+	}
+}
+
+// WithLimit sets the maximum number of entries returned by Scan.  The default is 100.
+func WithLimit(limit int) Option {
+	return func(o *Options) {
+		o.limit = limit
+	}
+}
+
+func getOptions(opts []Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Get returns the entry for the given key, or nil if there is no such entry.
+func Get(key string, opts ...Option) (*Entry, error) {
+	o := getOptions(opts)
+	response := hostGet(&o.namespace, &key)
+	if response == nil {
+		return nil, fmt.Errorf("failed to get key %s", key)
+	}
+	return parseResponse[*Entry](*response)
+}
+
+// Set sets the value for the given key, and returns the updated entry.
+func Set(key, value string, opts ...Option) (*Entry, error) {
+	o := getOptions(opts)
+	response := hostSet(&o.namespace, &key, &value, int32(o.ttl.Seconds()))
+	if response == nil {
+		return nil, fmt.Errorf("failed to set key %s", key)
+	}
+	return parseResponse[*Entry](*response)
+}
+
+// CompareAndSwap sets the value for the given key, only if the current version of the entry matches the given version.
+// Use version 0 to set the value only if there is no existing entry.
+// It returns the updated entry, or nil if the version did not match.
+func CompareAndSwap(key string, version int64, value string, opts ...Option) (*Entry, error) {
+	o := getOptions(opts)
+	response := hostCompareAndSwap(&o.namespace, &key, version, &value, int32(o.ttl.Seconds()))
+	if response == nil {
+		return nil, fmt.Errorf("failed to swap key %s", key)
+	}
+	return parseResponse[*Entry](*response)
+}
+
+// Delete deletes the entry for the given key, and returns true if the entry existed.
+func Delete(key string, opts ...Option) (bool, error) {
+	o := getOptions(opts)
+	response := hostDelete(&o.namespace, &key)
+	if response == nil {
+		return false, fmt.Errorf("failed to delete key %s", key)
+	}
+	return parseResponse[bool](*response)
+}
+
+// Scan returns the entries whose keys start with the given prefix, in key order.
+func Scan(prefix string, opts ...Option) ([]Entry, error) {
+	o := getOptions(opts)
+	response := hostScan(&o.namespace, &prefix, int32(o.limit))
+	if response == nil {
+		return nil, fmt.Errorf("failed to scan keys with prefix %s", prefix)
+	}
+	return parseResponse[[]Entry](*response)
+}
+
+func parseResponse[T any](response string) (T, error) {
+	var result T
+	if err := utils.JsonDeserialize([]byte(response), &result); err != nil {
+		console.Error(err.Error())
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,153 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/kv"
+)
+
+func TestGet(t *testing.T) {
+	entry, err := kv.Get("greeting", kv.WithNamespace("app"))
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if entry == nil {
+		t.Fatalf("Expected an entry, but received nil")
+	}
+	if entry.Value != "Hello" {
+		t.Errorf("Expected value: %s, but received: %s", "Hello", entry.Value)
+	}
+	if entry.Version != 2 {
+		t.Errorf("Expected version: %d, but received: %d", 2, entry.Version)
+	}
+
+	values := kv.GetCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostGet, but none was made")
+	}
+	if *values[0].(*string) != "app" {
+		t.Errorf("Expected namespace: %s, but received: %s", "app", *values[0].(*string))
+	}
+	if *values[1].(*string) != "greeting" {
+		t.Errorf("Expected key: %s, but received: %s", "greeting", *values[1].(*string))
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	entry, err := kv.Get("missing")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if entry != nil {
+		t.Errorf("Expected nil, but received an entry")
+	}
+
+	values := kv.GetCallStack.Pop()
+	if *values[0].(*string) != "" {
+		t.Errorf("Expected default namespace, but received: %s", *values[0].(*string))
+	}
+}
+
+func TestSet(t *testing.T) {
+	entry, err := kv.Set("session", "abc", kv.WithTTL(90*time.Second))
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if entry.Value != "abc" {
+		t.Errorf("Expected value: %s, but received: %s", "abc", entry.Value)
+	}
+
+	values := kv.SetCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostSet, but none was made")
+	}
+	if *values[2].(*string) != "abc" {
+		t.Errorf("Expected value: %s, but received: %s", "abc", *values[2].(*string))
+	}
+	if values[3].(int32) != 90 {
+		t.Errorf("Expected ttlSeconds: %d, but received: %d", 90, values[3].(int32))
+	}
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	entry, err := kv.CompareAndSwap("greeting", 2, "Hi")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if entry == nil {
+		t.Fatalf("Expected an entry, but received nil")
+	}
+	if entry.Version != 3 {
+		t.Errorf("Expected version: %d, but received: %d", 3, entry.Version)
+	}
+	kv.CompareAndSwapCallStack.Pop()
+
+	entry, err = kv.CompareAndSwap("greeting", 1, "Hi")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if entry != nil {
+		t.Errorf("Expected nil for a version mismatch, but received an entry")
+	}
+
+	values := kv.CompareAndSwapCallStack.Pop()
+	if values[2].(int64) != 1 {
+		t.Errorf("Expected version: %d, but received: %d", 1, values[2].(int64))
+	}
+}
+
+func TestDelete(t *testing.T) {
+	existed, err := kv.Delete("greeting")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if !existed {
+		t.Errorf("Expected the entry to have existed")
+	}
+	kv.DeleteCallStack.Pop()
+
+	existed, err = kv.Delete("missing")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if existed {
+		t.Errorf("Expected the entry to not have existed")
+	}
+	kv.DeleteCallStack.Pop()
+}
+
+func TestScan(t *testing.T) {
+	entries, err := kv.Scan("user:", kv.WithLimit(10))
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, but received: %d", len(entries))
+	}
+	if entries[1].Value != "Bob" {
+		t.Errorf("Expected value: %s, but received: %s", "Bob", entries[1].Value)
+	}
+
+	values := kv.ScanCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostScan, but none was made")
+	}
+	if *values[1].(*string) != "user:" {
+		t.Errorf("Expected prefix: %s, but received: %s", "user:", *values[1].(*string))
+	}
+	if values[2].(int32) != 10 {
+		t.Errorf("Expected limit: %d, but received: %d", 10, values[2].(int32))
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package pagination provides support for functions that return results in pages.
+//
+// A function is paginated when it has a "first" parameter of an integer type, and an "after"
+// parameter of a string type, and returns a slice.  The GraphQL schema will then present the
+// results as a Relay connection.  See https://relay.dev/graphql/connections.htm
+//
+// The "after" parameter receives the id of the last item the client received, or if the items
+// don't have an "id" field, the number of items the client has received so far.  It is empty
+// when the first page is requested.  The function should return up to "first" items that follow,
+// and can return one additional item to indicate that there is another page.
+//
+// Alternatively, the function can return a struct that has exactly two fields: an "Items" slice,
+// and a "PageInfo" field of the [PageInfo] type, to report the page info explicitly.
+package pagination
+
+import "strconv"
+
+// PageInfo describes whether there are more items before or after a page of results.
+type PageInfo struct {
+	// HasNextPage indicates that there are more items after this page.
+	HasNextPage bool
+
+	// HasPreviousPage indicates that there are items before this page.
+	HasPreviousPage bool
+}
+
+// Offset returns the number of items that precede the requested page, for items that don't have an id.
+// It should be called with the value of the "after" parameter of a paginated function.
+func Offset(after string) int {
+	n, err := strconv.Atoi(after)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pagination_test
+
+import (
+	"testing"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/pagination"
+)
+
+func TestOffset(t *testing.T) {
+	tests := map[string]int{
+		"":    0,
+		"0":   0,
+		"25":  25,
+		"-1":  0,
+		"abc": 0,
+	}
+
+	for after, expected := range tests {
+		if actual := pagination.Offset(after); actual != expected {
+			t.Errorf("Offset(%q) = %d, expected %d", after, actual, expected)
+		}
+	}
+}
@@ -24,3 +24,32 @@ func QueryScalar[T any](hostName, statement string, params ...any) (T, uint, err
 func Execute(hostName, statement string, params ...any) (uint, error) {
 	return db.Execute(hostName, dbType, statement, params...)
 }
+
+// CopyRows inserts the rows into the table with the COPY protocol, and returns the number of rows inserted.
+// Each row is either a struct or map whose fields are named by the columns, or a slice of values
+// in the order of the columns.  The size of the rows as JSON is bounded by the maxQueryBytes limit in the manifest.
+func CopyRows[T any](hostName, table string, columns []string, rows []T) (uint64, error) {
+	return db.CopyRows(hostName, table, columns, rows)
+}
+
+// Tx is a database transaction.
+type Tx = db.Tx
+
+// WithTx runs the function in a transaction, which is committed if the function returns nil,
+// and rolled back if it returns an error.
+func WithTx(hostName string, fn func(tx *Tx) error) error {
+	return db.WithTx(hostName, dbType, fn)
+}
+
+func QueryTx[T any](tx *Tx, statement string, params ...any) ([]T, uint, error) {
+	return db.QueryTx[T](tx, statement, params...)
+}
+
+func QueryScalarTx[T any](tx *Tx, statement string, params ...any) (T, uint, error) {
+	return db.QueryScalarTx[T](tx, statement, params...)
+}
+
+// OpenCursor runs the query, and returns a cursor for reading its rows in pages.
+func OpenCursor[T any](hostName, statement string, params ...any) (*db.Cursor[T], error) {
+	return db.OpenCursor[T](hostName, dbType, statement, params...)
+}
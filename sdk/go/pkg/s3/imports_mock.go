@@ -0,0 +1,63 @@
+//go:build !wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3
+
+import (
+	"fmt"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/testutils"
+)
+
+var ListObjectsCallStack = testutils.NewCallStack()
+var GetObjectCallStack = testutils.NewCallStack()
+var PutObjectCallStack = testutils.NewCallStack()
+var DeleteObjectCallStack = testutils.NewCallStack()
+var PresignUrlCallStack = testutils.NewCallStack()
+
+func hostListObjects(connection, prefix, startAfter *string, limit int32) *string {
+	ListObjectsCallStack.Push(connection, prefix, startAfter, limit)
+
+	json := `[{"key":"reports/2024.pdf","size":8,"etag":"\"abc\"","lastModified":"2024-01-01T00:00:00Z"}]`
+	return &json
+}
+
+func hostGetObject(connection, key *string) *[]byte {
+	GetObjectCallStack.Push(connection, key)
+
+	if *key == "missing" {
+		return nil
+	}
+
+	content := []byte("%PDF-1.7")
+	return &content
+}
+
+func hostPutObject(connection, key *string, content *[]byte, contentType *string) *string {
+	PutObjectCallStack.Push(connection, key, content, contentType)
+
+	json := fmt.Sprintf(`{"key":%q,"size":%d,"etag":"\"abc\""}`, *key, len(*content))
+	return &json
+}
+
+func hostDeleteObject(connection, key *string) *string {
+	DeleteObjectCallStack.Push(connection, key)
+
+	json := "true"
+	return &json
+}
+
+func hostPresignUrl(connection, method, key *string, expiresInSeconds int32) *string {
+	PresignUrlCallStack.Push(connection, method, key, expiresInSeconds)
+
+	url := fmt.Sprintf("https://bucket.s3.amazonaws.com/%s?X-Amz-Expires=%d", *key, expiresInSeconds)
+	return &url
+}
@@ -0,0 +1,48 @@
+//go:build wasip1
+
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3
+
+import "unsafe"
+
+//go:noescape
+//go:wasmimport modus_s3_client listObjects
+func hostListObjects(connection, prefix, startAfter *string, limit int32) *string
+
+//go:noescape
+//go:wasmimport modus_s3_client getObject
+func _hostGetObject(connection, key *string) unsafe.Pointer
+
+//modus:import modus_s3_client getObject
+func hostGetObject(connection, key *string) *[]byte {
+	response := _hostGetObject(connection, key)
+	if response == nil {
+		return nil
+	}
+	return (*[]byte)(response)
+}
+
+//go:noescape
+//go:wasmimport modus_s3_client putObject
+func _hostPutObject(connection, key *string, content unsafe.Pointer, contentType *string) *string
+
+//modus:import modus_s3_client putObject
+func hostPutObject(connection, key *string, content *[]byte, contentType *string) *string {
+	return _hostPutObject(connection, key, unsafe.Pointer(content), contentType)
+}
+
+//go:noescape
+//go:wasmimport modus_s3_client deleteObject
+func hostDeleteObject(connection, key *string) *string
+
+//go:noescape
+//go:wasmimport modus_s3_client presignUrl
+func hostPresignUrl(connection, method, key *string, expiresInSeconds int32) *string
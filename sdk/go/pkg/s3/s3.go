@@ -0,0 +1,145 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package s3 provides access to the objects of an S3 connection, which is defined in the app manifest.
+package s3
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/console"
+	"github.com/hypermodeinc/modus/sdk/go/pkg/utils"
+)
+
+// ObjectInfo describes an object in a bucket.
+type ObjectInfo struct {
+	Key  string `json:"key"`
+	Size int64  `json:"size"`
+
+	// The entity tag of the object, which is not provided when using a local directory.
+	ETag string `json:"etag,omitempty"`
+
+	// The time the object was last modified, which is not provided when putting an object to a bucket.
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+type Option func(*Options)
+
+type Options struct {
+	startAfter  string
+	limit       int
+	contentType string
+	expiration  time.Duration
+}
+
+// WithStartAfter sets the key after which ListObjects starts listing, to page through the objects.
+func WithStartAfter(key string) Option {
+	return func(o *Options) {
+		o.startAfter = key
+	}
+}
+
+// WithLimit sets the maximum number of objects returned by ListObjects.  The default and maximum is 1000.
+func WithLimit(limit int) Option {
+	return func(o *Options) {
+		o.limit = limit
+	}
+}
+
+// WithContentType sets the content type of an object written by PutObject.
+func WithContentType(contentType string) Option {
+	return func(o *Options) {
+		o.contentType = contentType
+	}
+}
+
+// WithExpiration sets how long a presigned URL is valid.  The default is 15 minutes, and the maximum is 7 days.
+// The expiration is rounded down to whole seconds.
+func WithExpiration(expiration time.Duration) Option {
+	return func(o *Options) {
+		o.expiration = expiration
+	}
+}
+
+func getOptions(opts []Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// ListObjects returns the objects whose keys start with the given prefix, in key order.
+func ListObjects(connection, prefix string, opts ...Option) ([]ObjectInfo, error) {
+	o := getOptions(opts)
+	response := hostListObjects(&connection, &prefix, &o.startAfter, int32(o.limit))
+	if response == nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s", prefix)
+	}
+	return parseResponse[[]ObjectInfo](*response)
+}
+
+// GetObject returns the content of the object with the given key.
+func GetObject(connection, key string) ([]byte, error) {
+	response := hostGetObject(&connection, &key)
+	if response == nil {
+		return nil, fmt.Errorf("failed to get object %s", key)
+	}
+	return *response, nil
+}
+
+// PutObject writes the content to the object with the given key, replacing any existing object.
+func PutObject(connection, key string, content []byte, opts ...Option) (*ObjectInfo, error) {
+	o := getOptions(opts)
+	response := hostPutObject(&connection, &key, &content, &o.contentType)
+	if response == nil {
+		return nil, fmt.Errorf("failed to put object %s", key)
+	}
+	return parseResponse[*ObjectInfo](*response)
+}
+
+// DeleteObject deletes the object with the given key.  It is not an error if the object does not exist.
+func DeleteObject(connection, key string) error {
+	response := hostDeleteObject(&connection, &key)
+	if response == nil {
+		return fmt.Errorf("failed to delete object %s", key)
+	}
+	return nil
+}
+
+// PresignGetURL returns a URL that can be used to download the object with the given key, without credentials.
+// When the connection uses a local directory, a file URL is returned instead.
+func PresignGetURL(connection, key string, opts ...Option) (string, error) {
+	return presignURL(connection, "GET", key, opts)
+}
+
+// PresignPutURL returns a URL that can be used to upload the object with the given key, without credentials.
+// When the connection uses a local directory, a file URL is returned instead.
+func PresignPutURL(connection, key string, opts ...Option) (string, error) {
+	return presignURL(connection, "PUT", key, opts)
+}
+
+func presignURL(connection, method, key string, opts []Option) (string, error) {
+	o := getOptions(opts)
+	response := hostPresignUrl(&connection, &method, &key, int32(o.expiration.Seconds()))
+	if response == nil {
+		return "", fmt.Errorf("failed to presign %s URL for object %s", method, key)
+	}
+	return *response, nil
+}
+
+func parseResponse[T any](response string) (T, error) {
+	var result T
+	if err := utils.JsonDeserialize([]byte(response), &result); err != nil {
+		console.Error(err.Error())
+		return result, err
+	}
+	return result, nil
+}
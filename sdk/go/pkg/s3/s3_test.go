@@ -0,0 +1,121 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package s3_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hypermodeinc/modus/sdk/go/pkg/s3"
+)
+
+func TestListObjects(t *testing.T) {
+	objects, err := s3.ListObjects("documents", "reports/", s3.WithStartAfter("reports/2023.pdf"), s3.WithLimit(10))
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 object, but received: %d", len(objects))
+	}
+	if objects[0].Key != "reports/2024.pdf" {
+		t.Errorf("Expected key: %s, but received: %s", "reports/2024.pdf", objects[0].Key)
+	}
+	if objects[0].LastModified == nil || objects[0].LastModified.Year() != 2024 {
+		t.Errorf("Expected last modified time in 2024, but received: %v", objects[0].LastModified)
+	}
+
+	values := s3.ListObjectsCallStack.Pop()
+	if values == nil {
+		t.Fatalf("Expected a call to hostListObjects, but none was made")
+	}
+	if *values[0].(*string) != "documents" {
+		t.Errorf("Expected connection: %s, but received: %s", "documents", *values[0].(*string))
+	}
+	if *values[2].(*string) != "reports/2023.pdf" {
+		t.Errorf("Expected start after: %s, but received: %s", "reports/2023.pdf", *values[2].(*string))
+	}
+	if values[3].(int32) != 10 {
+		t.Errorf("Expected limit: %d, but received: %d", 10, values[3].(int32))
+	}
+}
+
+func TestGetObject(t *testing.T) {
+	content, err := s3.GetObject("documents", "reports/2024.pdf")
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if string(content) != "%PDF-1.7" {
+		t.Errorf("Expected content: %s, but received: %s", "%PDF-1.7", string(content))
+	}
+
+	if _, err := s3.GetObject("documents", "missing"); err == nil {
+		t.Error("Expected an error for a missing object, but received none")
+	}
+
+	s3.GetObjectCallStack.Pop()
+	s3.GetObjectCallStack.Pop()
+}
+
+func TestPutObject(t *testing.T) {
+	info, err := s3.PutObject("documents", "summary.txt", []byte("Hello"), s3.WithContentType("text/plain"))
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if info.Size != 5 {
+		t.Errorf("Expected size: %d, but received: %d", 5, info.Size)
+	}
+
+	values := s3.PutObjectCallStack.Pop()
+	if string(*values[2].(*[]byte)) != "Hello" {
+		t.Errorf("Expected content: %s, but received: %s", "Hello", string(*values[2].(*[]byte)))
+	}
+	if *values[3].(*string) != "text/plain" {
+		t.Errorf("Expected content type: %s, but received: %s", "text/plain", *values[3].(*string))
+	}
+}
+
+func TestDeleteObject(t *testing.T) {
+	if err := s3.DeleteObject("documents", "summary.txt"); err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	values := s3.DeleteObjectCallStack.Pop()
+	if *values[1].(*string) != "summary.txt" {
+		t.Errorf("Expected key: %s, but received: %s", "summary.txt", *values[1].(*string))
+	}
+}
+
+func TestPresignURL(t *testing.T) {
+	url, err := s3.PresignPutURL("documents", "upload.pdf", s3.WithExpiration(time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+	if !strings.Contains(url, "X-Amz-Expires=3600") {
+		t.Errorf("Unexpected URL: %s", url)
+	}
+
+	values := s3.PresignUrlCallStack.Pop()
+	if *values[1].(*string) != "PUT" {
+		t.Errorf("Expected method: %s, but received: %s", "PUT", *values[1].(*string))
+	}
+
+	if _, err := s3.PresignGetURL("documents", "upload.pdf"); err != nil {
+		t.Fatalf("Expected no error, but received: %s", err.Error())
+	}
+
+	values = s3.PresignUrlCallStack.Pop()
+	if *values[1].(*string) != "GET" {
+		t.Errorf("Expected method: %s, but received: %s", "GET", *values[1].(*string))
+	}
+	if values[3].(int32) != 0 {
+		t.Errorf("Expected default expiration, but received: %d", values[3].(int32))
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2024 Hypermode Inc.
+ * Licensed under the terms of the Apache License, Version 2.0
+ * See the LICENSE file that accompanied this code for further details.
+ *
+ * SPDX-FileCopyrightText: 2024 Hypermode Inc. <hello@hypermode.com>
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package sqlite
+
+import "github.com/hypermodeinc/modus/sdk/go/pkg/db"
+
+const dbType = "sqlite"
+
+func Query[T any](hostName, statement string, params ...any) ([]T, uint, error) {
+	return db.Query[T](hostName, dbType, statement, params...)
+}
+
+func QueryScalar[T any](hostName, statement string, params ...any) (T, uint, error) {
+	return db.QueryScalar[T](hostName, dbType, statement, params...)
+}
+
+func Execute(hostName, statement string, params ...any) (uint, error) {
+	return db.Execute(hostName, dbType, statement, params...)
+}
+
+// Tx is a database transaction.
+type Tx = db.Tx
+
+// WithTx runs the function in a transaction, which is committed if the function returns nil,
+// and rolled back if it returns an error.
+func WithTx(hostName string, fn func(tx *Tx) error) error {
+	return db.WithTx(hostName, dbType, fn)
+}
+
+func QueryTx[T any](tx *Tx, statement string, params ...any) ([]T, uint, error) {
+	return db.QueryTx[T](tx, statement, params...)
+}
+
+func QueryScalarTx[T any](tx *Tx, statement string, params ...any) (T, uint, error) {
+	return db.QueryScalarTx[T](tx, statement, params...)
+}
+
+// OpenCursor runs the query, and returns a cursor for reading its rows in pages.
+// Cursors can't be used with an in-memory database.
+func OpenCursor[T any](hostName, statement string, params ...any) (*db.Cursor[T], error) {
+	return db.OpenCursor[T](hostName, dbType, statement, params...)
+}
@@ -31,6 +31,7 @@ func PostProcess(config *config.Config, meta *metadata.Metadata) error {
 	writeFuncMake(body, types, imports)
 	writeFuncReadMap(body, types, imports)
 	writeFuncWriteMap(body, types, imports)
+	writeFuncTypeId(body, meta, types, imports)
 
 	header := &bytes.Buffer{}
 	writePostProcessHeader(header, meta, imports)
@@ -227,3 +228,40 @@ func __doWriteMap[M ~map[K]V, K comparable, V any](m M, keys[]K, values[]V) {
 		_, _ = buf.WriteTo(b)
 	}
 }
+
+func writeFuncTypeId(b *bytes.Buffer, meta *metadata.Metadata, types []*metadata.TypeDefinition, imports map[string]string) {
+	buf := &bytes.Buffer{}
+	found := false
+	seen := make(map[string]bool)
+
+	buf.WriteString(`
+//go:export __type_id
+func __type_id(p unsafe.Pointer) uint32 {
+`)
+	buf.WriteString("\tswitch (*(*any)(p)).(type) {\n")
+	for _, t := range types {
+		for _, v := range t.Variants {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+
+			// The id is always that of the struct type, even when the variant is a pointer.
+			def, ok := meta.Types[utils.GetUnderlyingType(v)]
+			if !ok {
+				continue
+			}
+
+			found = true
+			buf.WriteString(fmt.Sprintf(`	case %s:
+		return %d
+`, utils.GetNameForType(v, imports), def.Id))
+		}
+	}
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\treturn 0\n}\n")
+
+	if found {
+		_, _ = buf.WriteTo(b)
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"go/ast"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -37,7 +39,10 @@ func PreProcess(config *config.Config) error {
 		return err
 	}
 
-	functions := getFunctionsNeedingWrappers(pkg)
+	functions, err := getFunctionsNeedingWrappers(pkg)
+	if err != nil {
+		return err
+	}
 	imports := getRequiredImports(functions)
 
 	body := &bytes.Buffer{}
@@ -98,11 +103,12 @@ func writeMainFunc(b *bytes.Buffer, pkg *packages.Package) {
 
 type funcInfo struct {
 	function *ast.FuncDecl
+	receiver string
 	imports  map[string]string
 	aliases  map[string]string
 }
 
-func getFunctionsNeedingWrappers(pkg *packages.Package) []*funcInfo {
+func getFunctionsNeedingWrappers(pkg *packages.Package) ([]*funcInfo, error) {
 	var results []*funcInfo
 	for _, f := range pkg.Syntax {
 
@@ -122,6 +128,18 @@ func getFunctionsNeedingWrappers(pkg *packages.Package) []*funcInfo {
 			if fn, ok := d.(*ast.FuncDecl); ok {
 				if fn.Name.IsExported() && getExportedFuncName(fn) == "" {
 
+					var receiver string
+					if fn.Recv != nil {
+						if !hasFieldDirective(fn) {
+							continue
+						}
+						r, err := getReceiverTypeName(pkg, fn)
+						if err != nil {
+							return nil, err
+						}
+						receiver = r
+					}
+
 					var fields []*ast.Field
 					if fn.Type.Params != nil {
 						fields = append(fields, fn.Type.Params.List...)
@@ -140,6 +158,7 @@ func getFunctionsNeedingWrappers(pkg *packages.Package) []*funcInfo {
 
 					info := &funcInfo{
 						function: fn,
+						receiver: receiver,
 						imports:  usedImports,
 					}
 
@@ -148,7 +167,64 @@ func getFunctionsNeedingWrappers(pkg *packages.Package) []*funcInfo {
 			}
 		}
 	}
-	return results
+	return results, nil
+}
+
+// hasFieldDirective reports whether the method is decorated as follows, to opt in to its use as a field:
+//
+//	//modus:field
+//
+// Methods are not exported otherwise, so that adding methods to a type doesn't change the app's schema.
+func hasFieldDirective(fn *ast.FuncDecl) bool {
+	if fn.Doc != nil {
+		for _, c := range fn.Doc.List {
+			if strings.TrimSpace(c.Text) == "//modus:field" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getReceiverTypeName returns the name of the receiver's type, or an error if the method can't be exported.
+// Methods are exported so that they can be used to resolve fields of the receiver's type.
+func getReceiverTypeName(pkg *packages.Package, fn *ast.FuncDecl) (string, error) {
+	t := fn.Recv.List[0].Type
+	if st, ok := t.(*ast.StarExpr); ok {
+		t = st.X
+	}
+
+	// methods of generic types are not supported
+	id, ok := t.(*ast.Ident)
+	if !ok || !id.IsExported() {
+		return "", fmt.Errorf("method %s can't be used as a field, because its receiver is not an exported non-generic type", fn.Name.Name)
+	}
+	name := id.Name + "." + fn.Name.Name
+
+	// methods must return a value to be used as a field
+	results := fn.Type.Results
+	if results == nil || results.NumFields() == 0 {
+		return "", fmt.Errorf("method %s can't be used as a field, because it doesn't return a value", name)
+	}
+	if id, ok := results.List[0].Type.(*ast.Ident); ok && id.Name == "error" && results.NumFields() == 1 {
+		return "", fmt.Errorf("method %s can't be used as a field, because it only returns an error", name)
+	}
+
+	// The receiver is rebuilt from the JSON of the parent object when the field is resolved,
+	// so fields that aren't part of the JSON would be silently lost.
+	if obj := pkg.Types.Scope().Lookup(id.Name); obj != nil {
+		if st, ok := obj.Type().Underlying().(*types.Struct); ok {
+			for i := 0; i < st.NumFields(); i++ {
+				f := st.Field(i)
+				tag := reflect.StructTag(st.Tag(i)).Get("json")
+				if !f.Exported() || tag == "-" {
+					return "", fmt.Errorf("method %s can't be used as a field, because field %s of type %s would not be passed back to the method", name, f.Name(), id.Name)
+				}
+			}
+		}
+	}
+
+	return id.Name, nil
 }
 
 func getImportNames(e ast.Expr) []string {
@@ -236,6 +312,33 @@ func writeFuncWrappers(b *bytes.Buffer, pkg *packages.Package, imports map[strin
 		params := fn.Type.Params
 		results := fn.Type.Results
 
+		exportName := strings.ToLower(name[:1]) + name[1:]
+		wrapperName := name
+		callee := name
+		fnType := fn.Type
+
+		if info.receiver != "" {
+			// methods are exported as functions that take the receiver as the first parameter
+			exportName = info.receiver + "." + exportName
+			wrapperName = info.receiver + "_" + name
+
+			recv := fn.Recv.List[0]
+			recvName := "receiver"
+			if len(recv.Names) > 0 && recv.Names[0].Name != "_" {
+				recvName = recv.Names[0].Name
+			}
+			callee = recvName + "." + name
+
+			recvParam := &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(recvName)},
+				Type:  recv.Type,
+			}
+			fnType = &ast.FuncType{
+				Params:  &ast.FieldList{List: append([]*ast.Field{recvParam}, params.List...)},
+				Results: results,
+			}
+		}
+
 		hasErrorReturn := false
 		if results != nil {
 			for i := len(results.List) - 1; i >= 0; i-- {
@@ -258,14 +361,13 @@ func writeFuncWrappers(b *bytes.Buffer, pkg *packages.Package, imports map[strin
 		}
 
 		b.WriteString("//go:export ")
-		b.WriteString(strings.ToLower(name[:1]))
-		b.WriteString(name[1:])
+		b.WriteString(exportName)
 		b.WriteString("\n")
 		b.WriteString("func __modus_")
-		b.WriteString(name)
+		b.WriteString(wrapperName)
 
 		buf := &bytes.Buffer{}
-		printer.Fprint(buf, pkg.Fset, fn.Type)
+		printer.Fprint(buf, pkg.Fset, fnType)
 		decl := strings.TrimPrefix(buf.String(), "func")
 		for a, n := range info.aliases {
 			re := regexp.MustCompile(`\b` + a + `\.`)
@@ -314,7 +416,7 @@ func writeFuncWrappers(b *bytes.Buffer, pkg *packages.Package, imports map[strin
 				}
 			}
 			b.WriteString("err := ")
-			b.WriteString(name)
+			b.WriteString(callee)
 			b.Write(inputParams.Bytes())
 			b.WriteByte('\n')
 
@@ -339,7 +441,7 @@ func writeFuncWrappers(b *bytes.Buffer, pkg *packages.Package, imports map[strin
 			if results != nil && results.NumFields() > 0 {
 				b.WriteString("return ")
 			}
-			b.WriteString(name)
+			b.WriteString(callee)
 			b.Write(inputParams.Bytes())
 			b.WriteByte('\n')
 		}
@@ -53,6 +53,10 @@ func CollectProgramInfo(config *config.Config, meta *metadata.Metadata) error {
 			t := transformStruct(name, s, pkgs)
 			t.Id = id
 			meta.Types[name] = t
+		} else if i, ok := t.(*types.Interface); ok && i.NumMethods() > 0 {
+			t := transformInterface(name, i, pkgs)
+			t.Id = id
+			meta.Types[name] = t
 		} else {
 			meta.Types[name] = &metadata.TypeDefinition{
 				Id:   id,
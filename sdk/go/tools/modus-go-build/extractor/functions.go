@@ -33,7 +33,18 @@ func getFuncDeclaration(fn *types.Func, pkgs map[string]*packages.Package) *ast.
 	for _, file := range pkg.Syntax {
 		for _, decl := range file.Decls {
 			if fd, ok := decl.(*ast.FuncDecl); ok {
-				if fd.Name.Name == fnName {
+				if fd.Recv == nil && fd.Name.Name == fnName {
+					return fd
+				}
+			}
+		}
+	}
+
+	// wrappers of methods are named "__modus_<Type>_<Method>"
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv != nil {
+				if getReceiverTypeName(fd)+"_"+fd.Name.Name == fnName {
 					return fd
 				}
 			}
@@ -43,6 +54,17 @@ func getFuncDeclaration(fn *types.Func, pkgs map[string]*packages.Package) *ast.
 	return nil
 }
 
+func getReceiverTypeName(fd *ast.FuncDecl) string {
+	t := fd.Recv.List[0].Type
+	if st, ok := t.(*ast.StarExpr); ok {
+		t = st.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
 func getExportedFunctions(pkgs map[string]*packages.Package) map[string]*types.Func {
 	results := make(map[string]*types.Func)
 	for _, pkg := range pkgs {
@@ -214,9 +236,15 @@ func addRequiredTypes(t types.Type, m map[string]types.Type) bool {
 			return true
 		}
 
-		if s, ok := u.(*types.Struct); ok {
-			for i := 0; i < s.NumFields(); i++ {
-				addRequiredTypes(s.Field(i).Type(), m)
+		switch u := u.(type) {
+		case *types.Struct:
+			for i := 0; i < u.NumFields(); i++ {
+				addRequiredTypes(u.Field(i).Type(), m)
+			}
+		case *types.Interface:
+			// interfaces are represented by the concrete types that implement them
+			for _, v := range getInterfaceVariants(t.Obj().Pkg(), u) {
+				addRequiredTypes(v, m)
 			}
 		}
 
@@ -254,3 +282,36 @@ func addRequiredTypes(t types.Type, m map[string]types.Type) bool {
 
 	return false
 }
+
+// getInterfaceVariants returns the exported struct types that implement the interface.
+// The set of variants is sealed to the package that declares the interface.
+func getInterfaceVariants(pkg *types.Package, iface *types.Interface) []types.Type {
+	if pkg == nil || iface.NumMethods() == 0 {
+		return nil
+	}
+
+	var variants []types.Type
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !tn.Exported() || tn.IsAlias() {
+			continue
+		}
+
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+
+		if types.Implements(named, iface) {
+			variants = append(variants, named)
+		} else if ptr := types.NewPointer(named); types.Implements(ptr, iface) {
+			variants = append(variants, ptr)
+		}
+	}
+
+	return variants
+}
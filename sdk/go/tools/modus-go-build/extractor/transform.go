@@ -52,6 +52,23 @@ func transformStruct(name string, s *types.Struct, pkgs map[string]*packages.Pac
 	}
 }
 
+func transformInterface(name string, i *types.Interface, pkgs map[string]*packages.Package) *metadata.TypeDefinition {
+	pkgName := utils.GetPackageNamesForType(name)[0]
+	pkg := pkgs[pkgName]
+
+	variants := getInterfaceVariants(pkg.Types, i)
+	names := make([]string, len(variants))
+	for j, v := range variants {
+		names[j] = v.String()
+	}
+
+	return &metadata.TypeDefinition{
+		Name:     name,
+		Variants: names,
+		Docs:     getDocs(getTypeDocComments(name, pkgs)),
+	}
+}
+
 func transformFunc(name string, f *types.Func, pkgs map[string]*packages.Package) *metadata.Function {
 	if f == nil {
 		return nil
@@ -120,6 +137,26 @@ func getStructDeclarationAndType(name string, pkgs map[string]*packages.Package)
 	return nil, nil
 }
 
+func getTypeDocComments(name string, pkgs map[string]*packages.Package) *ast.CommentGroup {
+	objName := name[strings.LastIndex(name, ".")+1:]
+	pkgName := utils.GetPackageNamesForType(name)[0]
+	pkg := pkgs[pkgName]
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+				for _, spec := range genDecl.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == objName {
+						return genDecl.Doc
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func getDocs(comments *ast.CommentGroup) *metadata.Docs {
 	if comments == nil {
 		return nil
@@ -49,10 +49,11 @@ type Function struct {
 }
 
 type TypeDefinition struct {
-	Id     uint32   `json:"id"`
-	Name   string   `json:"-"`
-	Fields []*Field `json:"fields,omitempty"`
-	Docs   *Docs    `json:"docs,omitempty"`
+	Id       uint32   `json:"id"`
+	Name     string   `json:"-"`
+	Fields   []*Field `json:"fields,omitempty"`
+	Variants []string `json:"variants,omitempty"`
+	Docs     *Docs    `json:"docs,omitempty"`
 }
 
 type Parameter struct {
@@ -133,6 +134,19 @@ func (t *TypeDefinition) String(m *Metadata) string {
 
 	imports := m.GetImports()
 
+	if len(t.Variants) > 0 {
+		b := strings.Builder{}
+		b.WriteString(utils.GetNameForType(t.Name, imports))
+		b.WriteString(" = ")
+		for i, v := range t.Variants {
+			if i > 0 {
+				b.WriteString(" | ")
+			}
+			b.WriteString(utils.GetNameForType(v, imports))
+		}
+		return b.String()
+	}
+
 	if len(t.Fields) == 0 {
 		return utils.GetNameForType(t.Name, imports)
 	}
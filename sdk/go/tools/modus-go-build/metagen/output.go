@@ -54,7 +54,7 @@ func LogToConsole(meta *metadata.Metadata) {
 	types := make([]string, 0, len(meta.Types))
 	for _, k := range meta.Types.SortedKeys(meta.Module) {
 		t := meta.Types[k]
-		if len(t.Fields) > 0 && strings.HasPrefix(k, meta.Module) {
+		if (len(t.Fields) > 0 || len(t.Variants) > 0) && strings.HasPrefix(k, meta.Module) {
 			types = append(types, k)
 		}
 	}